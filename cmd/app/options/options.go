@@ -3,44 +3,93 @@ package options
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/pflag"
 
 	"github.com/cloudpilot-ai/priceserver/pkg/apis"
 	"github.com/cloudpilot-ai/priceserver/pkg/client"
+	"github.com/cloudpilot-ai/priceserver/pkg/storage"
 )
 
 type Options struct {
-	AWSGlobalAK string
-	AWSGlobalSK string
-	AWSCNAK     string
-	AWSCNSK     string
-
-	AlibabaCloudAKSKPool []client.AKSKPair
+	// Providers are the names of price providers to serve
+	Providers []string
+	// Offline indicates the server serves snapshots only without any credentials
+	Offline bool
+	// DataDir is the directory where the price data is persisted as {provider}_price.json
+	DataDir string
+	// SpotHistoryRetention is how long the spot price history is kept
+	SpotHistoryRetention time.Duration
+	// ExchangeRatesFile is a JSON file of the exchange rates to USD overriding the default ones
+	ExchangeRatesFile string
+	// ExchangeRatesURL is the URL of a rate table fetched periodically, it overrides the static rates
+	ExchangeRatesURL string
+	// ExchangeRatesRefreshInterval is the interval to fetch the rate table
+	ExchangeRatesRefreshInterval time.Duration
+	// GRPCAddress is the address the gRPC API listens on, empty means the gRPC API is disabled
+	GRPCAddress string
 }
 
 func NewOptions() *Options {
-	return &Options{}
+	return &Options{
+		Providers:                    []string{apis.AWSCloudProvider, apis.AlibabaCloudProvider},
+		SpotHistoryRetention:         storage.DefaultSpotHistoryRetention,
+		ExchangeRatesRefreshInterval: 12 * time.Hour,
+		GRPCAddress:                  ":9090",
+	}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&o.Providers, "providers", o.Providers,
+		fmt.Sprintf("The price providers to serve, supported: %s", strings.Join(client.RegisteredProviders(), ",")))
+	fs.BoolVar(&o.Offline, "offline", o.Offline,
+		"Serve the price data from snapshots only without calling cloud APIs, the providers without credentials are always served read-only")
+	fs.StringVar(&o.DataDir, "data-dir", o.DataDir,
+		"The directory to persist the refreshed price data as {provider}_price.json, the persisted data is loaded at startup "+
+			"and the builtin snapshots are used if it's empty or the file doesn't exist")
+	fs.DurationVar(&o.SpotHistoryRetention, "spot-history-retention", o.SpotHistoryRetention,
+		"How long the spot price history is kept, the history is persisted in the data dir if it's set")
+	fs.StringVar(&o.ExchangeRatesFile, "exchange-rates-file", o.ExchangeRatesFile,
+		`A JSON file of the amount of each currency equal to 1 USD, e.g. {"CNY": 7.1}, overriding the default rates`)
+	fs.StringVar(&o.ExchangeRatesURL, "exchange-rates-url", o.ExchangeRatesURL,
+		"The URL of an exchange rate table fetched periodically, the static rates are used if it's empty or fails")
+	fs.DurationVar(&o.ExchangeRatesRefreshInterval, "exchange-rates-refresh-interval", o.ExchangeRatesRefreshInterval,
+		"The interval to fetch the exchange rate table")
+	fs.StringVar(&o.GRPCAddress, "grpc-address", o.GRPCAddress,
+		"The address of the gRPC API serving the same price data as the REST API, empty means it's disabled")
 }
 
 func (o *Options) ApplyAndValidate() error {
-	o.AWSGlobalAK = os.Getenv(apis.AWSGlobalAKEnv)
-	if o.AWSGlobalAK == "" {
-		return fmt.Errorf("aws global access key is not set")
+	if len(o.Providers) == 0 {
+		return fmt.Errorf("no price provider is set")
 	}
-	o.AWSGlobalSK = os.Getenv(apis.AWSGlobalSKEnv)
-	if o.AWSGlobalSK == "" {
-		return fmt.Errorf("aws global secret key is not set")
+
+	supported := client.RegisteredProviders()
+	for _, p := range o.Providers {
+		if !lo.Contains(supported, p) {
+			return fmt.Errorf("unsupported price provider %s, supported: %s", p, strings.Join(supported, ","))
+		}
 	}
-	o.AWSCNAK = os.Getenv(apis.AWSCNAKEnv)
-	if o.AWSCNAK == "" {
-		return fmt.Errorf("aws china access key is not set")
+
+	if o.SpotHistoryRetention <= 0 {
+		return fmt.Errorf("spot history retention must be positive")
 	}
-	o.AWSCNSK = os.Getenv(apis.AWSCNSKEnv)
-	if o.AWSCNSK == "" {
-		return fmt.Errorf("aws china secret key is not set")
+	if o.ExchangeRatesURL != "" && o.ExchangeRatesRefreshInterval <= 0 {
+		return fmt.Errorf("exchange rates refresh interval must be positive")
 	}
-	o.AlibabaCloudAKSKPool = client.ExtractAlibabaCloudAKSKPool()
-	if len(o.AlibabaCloudAKSKPool) == 0 {
-		return fmt.Errorf("alibaba cloud access key and secret key pool is not set")
+
+	if o.DataDir != "" {
+		// The data dir is created when the price data is persisted
+		info, err := os.Stat(o.DataDir)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("invalid data dir: %w", err)
+		}
+		if err == nil && !info.IsDir() {
+			return fmt.Errorf("data dir %s is not a directory", o.DataDir)
+		}
 	}
 
 	return nil
@@ -3,19 +3,26 @@ package app
 import (
 	"context"
 	"flag"
+	"net"
 	"time"
 
 	"github.com/spf13/cobra"
-	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 	cliflag "k8s.io/component-base/cli/flag"
 	"k8s.io/klog"
 
 	"github.com/cloudpilot-ai/priceserver/cmd/app/options"
 	"github.com/cloudpilot-ai/priceserver/pkg/apiserver/router"
 	"github.com/cloudpilot-ai/priceserver/pkg/client"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+	"github.com/cloudpilot-ai/priceserver/pkg/grpcserver"
+	"github.com/cloudpilot-ai/priceserver/pkg/storage"
 	"github.com/cloudpilot-ai/priceserver/pkg/version"
 )
 
+// grpcStopTimeout is how long the running calls are waited for on shutdown, the price watches don't end by themselves
+const grpcStopTimeout = 10 * time.Second
+
 func NewPriceServerCommand(ctx context.Context) *cobra.Command {
 	opts := options.NewOptions()
 
@@ -34,6 +41,10 @@ func NewPriceServerCommand(ctx context.Context) *cobra.Command {
 	}
 
 	fss := cliflag.NamedFlagSets{}
+	genericFlagSet := fss.FlagSet("generic")
+	opts.AddFlags(genericFlagSet)
+	cmd.Flags().AddFlagSet(genericFlagSet)
+
 	logFlagSet := fss.FlagSet("log")
 	klog.InitFlags(flag.CommandLine)
 	logFlagSet.AddGoFlagSet(flag.CommandLine)
@@ -44,33 +55,60 @@ func NewPriceServerCommand(ctx context.Context) *cobra.Command {
 
 func run(ctx context.Context, opts *options.Options) error {
 	klog.Infof("Start cloudpilot-agent, version: %s, commit: %s...", version.Get().GitVersion, version.Get().GitCommit)
-	var (
-		awsPriceClient     *client.AWSPriceClient
-		alibabaCloudClient *client.AlibabaCloudPriceClient
-	)
-
 	timeStart := time.Now()
-	eg, ctx := errgroup.WithContext(ctx)
-	eg.Go(func() (err error) {
-		alibabaCloudClient, err = client.NewAlibabaCloudPriceClient(opts.AlibabaCloudAKSKPool, true)
-		return err
-	})
-
-	eg.Go(func() (err error) {
-		awsPriceClient, err = client.NewAWSPriceClient(opts.AWSGlobalAK, opts.AWSGlobalSK, opts.AWSCNAK, opts.AWSCNSK, true)
+	providerOptions := &client.ProviderOptions{
+		InitialSpotUpdate: true,
+		Offline:           opts.Offline,
+	}
+	if opts.DataDir != "" {
+		store, err := storage.NewFileStore(opts.DataDir)
+		if err != nil {
+			return err
+		}
+		providerOptions.Store = store
+	}
+	spotHistory, err := storage.NewSpotHistory(opts.DataDir, opts.SpotHistoryRetention)
+	if err != nil {
 		return err
-	})
-
-	if err := eg.Wait(); err != nil {
+	}
+	providerOptions.SpotHistory = spotHistory
+	registry, err := client.NewRegistryFromFactories(ctx, opts.Providers, providerOptions)
+	if err != nil {
 		return err
 	}
 
 	klog.Infof("Init price client cost: %v", time.Since(timeStart))
 
-	serverRouter := router.NewPriceServerRouter(awsPriceClient, alibabaCloudClient)
+	var rateSource currency.RateSource
+	rateSource, err = currency.NewStaticRateSource(opts.ExchangeRatesFile)
+	if err != nil {
+		return err
+	}
+	if opts.ExchangeRatesURL != "" {
+		fetched := currency.NewFetchedRateSource(opts.ExchangeRatesURL, opts.ExchangeRatesRefreshInterval, rateSource)
+		go fetched.Run(ctx)
+		rateSource = fetched
+	}
 
-	go awsPriceClient.Run(ctx)
-	go alibabaCloudClient.Run(ctx)
+	serverRouter := router.NewPriceServerRouter(registry, rateSource)
+
+	go registry.Run(ctx)
+	if opts.GRPCAddress != "" {
+		listener, err := net.Listen("tcp", opts.GRPCAddress)
+		if err != nil {
+			return err
+		}
+		grpcServer := grpcserver.NewPriceServer(registry, rateSource)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				klog.Fatalf("Failed to start priceserver grpc server: %v", err)
+			}
+		}()
+		go func() {
+			<-ctx.Done()
+			stopGRPCServer(grpcServer)
+		}()
+	}
 	if err := serverRouter.Run(":8080"); err != nil {
 		klog.Fatalf("Failed to start priceserver router: %v", err)
 	}
@@ -78,3 +116,18 @@ func run(ctx context.Context, opts *options.Options) error {
 	<-ctx.Done()
 	return nil
 }
+
+// stopGRPCServer stops the server gracefully, the calls still running after grpcStopTimeout are canceled
+func stopGRPCServer(server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(grpcStopTimeout):
+		server.Stop()
+	}
+	klog.Infof("Priceserver grpc server is stopped")
+}
@@ -14,12 +14,19 @@ func handleAWSData() error {
 	cnAK := os.Getenv(apis.AWSCNAKEnv)
 	cnSK := os.Getenv(apis.AWSCNSKEnv)
 
-	awsPriceClient, err := client.NewAWSPriceClient(globalAK, globalSK, cnAK, cnSK, false)
+	awsPriceClient, err := client.NewAWSPriceClient(client.AWSConfig{
+		GlobalAK: globalAK,
+		GlobalSK: globalSK,
+		CNAK:     cnAK,
+		CNSK:     cnSK,
+	}, nil, nil, false)
 	if err != nil {
 		return err
 	}
 	awsPriceClient.RefreshOnDemandPrice("", "")
 	awsPriceClient.RefreshSavingsPlanPrice("", "")
+	awsPriceClient.RefreshZoneOfferings("", "")
+	awsPriceClient.RefreshInstanceTypeSpecs("", "")
 
 	data := awsPriceClient.ListRegionsInstancesPrice()
 	marshalData, err := json.Marshal(data)
@@ -37,12 +44,15 @@ func handleAWSData() error {
 func handleAlibabaCloudData() error {
 	alibabaCloudAKSKPool := client.ExtractAlibabaCloudAKSKPool()
 
-	alibabaCloudClient, err := client.NewAlibabaCloudPriceClient(alibabaCloudAKSKPool, false)
+	alibabaCloudClient, err := client.NewAlibabaCloudPriceClient(client.AlibabaCloudConfig{
+		AKSKPool: alibabaCloudAKSKPool,
+	}, nil, nil, false)
 	if err != nil {
 		return err
 	}
 
 	alibabaCloudClient.RefreshOnDemandPrice()
+	alibabaCloudClient.RefreshBillingPrice()
 
 	data := alibabaCloudClient.ListRegionsInstancesPrice()
 	marshalData, err := json.Marshal(data)
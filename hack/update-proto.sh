@@ -0,0 +1,13 @@
+#!/usr/bin/env bash
+
+# Generate the gRPC code of proto/, it requires protoc, protoc-gen-go v1.34.1 and protoc-gen-go-grpc v1.3.0:
+#   go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.34.1
+#   go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.3.0
+set -o errexit
+
+MODULE=github.com/cloudpilot-ai/priceserver
+
+protoc -I proto \
+    --go_out=. --go_opt=module=${MODULE} \
+    --go-grpc_out=. --go-grpc_opt=module=${MODULE} \
+    priceserver/v1/price.proto
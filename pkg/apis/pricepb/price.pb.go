@@ -0,0 +1,3076 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        (unknown)
+// source: priceserver/v1/price.proto
+
+package pricepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PriceSelector selects the prices of a platform and a site in a currency, empty fields mean the default prices
+type PriceSelector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// os is linux, windows, rhel or suse
+	Os string `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
+	// tenancy is shared or dedicated
+	Tenancy string `protobuf:"bytes,2,opt,name=tenancy,proto3" json:"tenancy,omitempty"`
+	// site is cn or intl
+	Site string `protobuf:"bytes,3,opt,name=site,proto3" json:"site,omitempty"`
+	// currency converts the prices, e.g. USD or CNY
+	Currency string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *PriceSelector) Reset() {
+	*x = PriceSelector{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriceSelector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceSelector) ProtoMessage() {}
+
+func (x *PriceSelector) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceSelector.ProtoReflect.Descriptor instead.
+func (*PriceSelector) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PriceSelector) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *PriceSelector) GetTenancy() string {
+	if x != nil {
+		return x.Tenancy
+	}
+	return ""
+}
+
+func (x *PriceSelector) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *PriceSelector) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ListRegionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+}
+
+func (x *ListRegionsRequest) Reset() {
+	*x = ListRegionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRegionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRegionsRequest) ProtoMessage() {}
+
+func (x *ListRegionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRegionsRequest.ProtoReflect.Descriptor instead.
+func (*ListRegionsRequest) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListRegionsRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+type ListRegionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Regions []string `protobuf:"bytes,1,rep,name=regions,proto3" json:"regions,omitempty"`
+}
+
+func (x *ListRegionsResponse) Reset() {
+	*x = ListRegionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRegionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRegionsResponse) ProtoMessage() {}
+
+func (x *ListRegionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRegionsResponse.ProtoReflect.Descriptor instead.
+func (*ListRegionsResponse) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListRegionsResponse) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+type ListPricesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	// regions limits the regions, empty means all
+	Regions  []string       `protobuf:"bytes,2,rep,name=regions,proto3" json:"regions,omitempty"`
+	Selector *PriceSelector `protobuf:"bytes,3,opt,name=selector,proto3" json:"selector,omitempty"`
+}
+
+func (x *ListPricesRequest) Reset() {
+	*x = ListPricesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPricesRequest) ProtoMessage() {}
+
+func (x *ListPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPricesRequest.ProtoReflect.Descriptor instead.
+func (*ListPricesRequest) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPricesRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ListPricesRequest) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *ListPricesRequest) GetSelector() *PriceSelector {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+type ListPricesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// regions is the price data of each region
+	Regions map[string]*RegionalInstancePrice `protobuf:"bytes,1,rep,name=regions,proto3" json:"regions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ListPricesResponse) Reset() {
+	*x = ListPricesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPricesResponse) ProtoMessage() {}
+
+func (x *ListPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPricesResponse.ProtoReflect.Descriptor instead.
+func (*ListPricesResponse) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListPricesResponse) GetRegions() map[string]*RegionalInstancePrice {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+type GetInstancePriceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provider     string         `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Region       string         `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	InstanceType string         `protobuf:"bytes,3,opt,name=instance_type,json=instanceType,proto3" json:"instance_type,omitempty"`
+	Selector     *PriceSelector `protobuf:"bytes,4,opt,name=selector,proto3" json:"selector,omitempty"`
+}
+
+func (x *GetInstancePriceRequest) Reset() {
+	*x = GetInstancePriceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInstancePriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstancePriceRequest) ProtoMessage() {}
+
+func (x *GetInstancePriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstancePriceRequest.ProtoReflect.Descriptor instead.
+func (*GetInstancePriceRequest) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetInstancePriceRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *GetInstancePriceRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GetInstancePriceRequest) GetInstanceType() string {
+	if x != nil {
+		return x.InstanceType
+	}
+	return ""
+}
+
+func (x *GetInstancePriceRequest) GetSelector() *PriceSelector {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+type GetInstancePriceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Price *InstanceTypePrice `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+}
+
+func (x *GetInstancePriceResponse) Reset() {
+	*x = GetInstancePriceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInstancePriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstancePriceResponse) ProtoMessage() {}
+
+func (x *GetInstancePriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstancePriceResponse.ProtoReflect.Descriptor instead.
+func (*GetInstancePriceResponse) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetInstancePriceResponse) GetPrice() *InstanceTypePrice {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type SearchInstancesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// providers limits the providers, empty means all
+	Providers []string `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	// regions limits the regions, empty means all
+	Regions   []string `protobuf:"bytes,2,rep,name=regions,proto3" json:"regions,omitempty"`
+	Arch      string   `protobuf:"bytes,3,opt,name=arch,proto3" json:"arch,omitempty"`
+	MinVcpu   *float64 `protobuf:"fixed64,4,opt,name=min_vcpu,json=minVcpu,proto3,oneof" json:"min_vcpu,omitempty"`
+	MaxVcpu   *float64 `protobuf:"fixed64,5,opt,name=max_vcpu,json=maxVcpu,proto3,oneof" json:"max_vcpu,omitempty"`
+	MinMemory *float64 `protobuf:"fixed64,6,opt,name=min_memory,json=minMemory,proto3,oneof" json:"min_memory,omitempty"`
+	MaxMemory *float64 `protobuf:"fixed64,7,opt,name=max_memory,json=maxMemory,proto3,oneof" json:"max_memory,omitempty"`
+	MinGpu    *float64 `protobuf:"fixed64,8,opt,name=min_gpu,json=minGpu,proto3,oneof" json:"min_gpu,omitempty"`
+	MaxGpu    *float64 `protobuf:"fixed64,9,opt,name=max_gpu,json=maxGpu,proto3,oneof" json:"max_gpu,omitempty"`
+	// zone only matches the instance types available in the zone, the spot price of the zone is used if it's set
+	Zone string `protobuf:"bytes,10,opt,name=zone,proto3" json:"zone,omitempty"`
+	// capacity_type is on-demand, spot or a billing key, default to on-demand
+	CapacityType string `protobuf:"bytes,11,opt,name=capacity_type,json=capacityType,proto3" json:"capacity_type,omitempty"`
+	// max_price is the max price per hour of the capacity type, 0 means unlimited
+	MaxPrice float64        `protobuf:"fixed64,12,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	Selector *PriceSelector `protobuf:"bytes,13,opt,name=selector,proto3" json:"selector,omitempty"`
+	// sort_by is price, pricePerVCPU or pricePerGiB, default to price
+	SortBy string `protobuf:"bytes,14,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	Desc   bool   `protobuf:"varint,15,opt,name=desc,proto3" json:"desc,omitempty"`
+	Offset int32  `protobuf:"varint,16,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit  int32  `protobuf:"varint,17,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *SearchInstancesRequest) Reset() {
+	*x = SearchInstancesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchInstancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchInstancesRequest) ProtoMessage() {}
+
+func (x *SearchInstancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchInstancesRequest.ProtoReflect.Descriptor instead.
+func (*SearchInstancesRequest) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchInstancesRequest) GetProviders() []string {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *SearchInstancesRequest) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *SearchInstancesRequest) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *SearchInstancesRequest) GetMinVcpu() float64 {
+	if x != nil && x.MinVcpu != nil {
+		return *x.MinVcpu
+	}
+	return 0
+}
+
+func (x *SearchInstancesRequest) GetMaxVcpu() float64 {
+	if x != nil && x.MaxVcpu != nil {
+		return *x.MaxVcpu
+	}
+	return 0
+}
+
+func (x *SearchInstancesRequest) GetMinMemory() float64 {
+	if x != nil && x.MinMemory != nil {
+		return *x.MinMemory
+	}
+	return 0
+}
+
+func (x *SearchInstancesRequest) GetMaxMemory() float64 {
+	if x != nil && x.MaxMemory != nil {
+		return *x.MaxMemory
+	}
+	return 0
+}
+
+func (x *SearchInstancesRequest) GetMinGpu() float64 {
+	if x != nil && x.MinGpu != nil {
+		return *x.MinGpu
+	}
+	return 0
+}
+
+func (x *SearchInstancesRequest) GetMaxGpu() float64 {
+	if x != nil && x.MaxGpu != nil {
+		return *x.MaxGpu
+	}
+	return 0
+}
+
+func (x *SearchInstancesRequest) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *SearchInstancesRequest) GetCapacityType() string {
+	if x != nil {
+		return x.CapacityType
+	}
+	return ""
+}
+
+func (x *SearchInstancesRequest) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *SearchInstancesRequest) GetSelector() *PriceSelector {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *SearchInstancesRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *SearchInstancesRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *SearchInstancesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchInstancesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchInstancesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// total is the number of matched instance types before pagination
+	Total    int32                 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Currency string                `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Items    []*InstanceSearchItem `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *SearchInstancesResponse) Reset() {
+	*x = SearchInstancesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchInstancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchInstancesResponse) ProtoMessage() {}
+
+func (x *SearchInstancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchInstancesResponse.ProtoReflect.Descriptor instead.
+func (*SearchInstancesResponse) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchInstancesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchInstancesResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SearchInstancesResponse) GetItems() []*InstanceSearchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type InstanceSearchItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provider     string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Region       string `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	InstanceType string `protobuf:"bytes,3,opt,name=instance_type,json=instanceType,proto3" json:"instance_type,omitempty"`
+	// price is the price per hour of the searched capacity type
+	Price             float64            `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	PricePerVcpu      float64            `protobuf:"fixed64,5,opt,name=price_per_vcpu,json=pricePerVcpu,proto3" json:"price_per_vcpu,omitempty"`
+	PricePerGib       float64            `protobuf:"fixed64,6,opt,name=price_per_gib,json=pricePerGib,proto3" json:"price_per_gib,omitempty"`
+	InstanceTypePrice *InstanceTypePrice `protobuf:"bytes,7,opt,name=instance_type_price,json=instanceTypePrice,proto3" json:"instance_type_price,omitempty"`
+}
+
+func (x *InstanceSearchItem) Reset() {
+	*x = InstanceSearchItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstanceSearchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSearchItem) ProtoMessage() {}
+
+func (x *InstanceSearchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSearchItem.ProtoReflect.Descriptor instead.
+func (*InstanceSearchItem) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InstanceSearchItem) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *InstanceSearchItem) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *InstanceSearchItem) GetInstanceType() string {
+	if x != nil {
+		return x.InstanceType
+	}
+	return ""
+}
+
+func (x *InstanceSearchItem) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *InstanceSearchItem) GetPricePerVcpu() float64 {
+	if x != nil {
+		return x.PricePerVcpu
+	}
+	return 0
+}
+
+func (x *InstanceSearchItem) GetPricePerGib() float64 {
+	if x != nil {
+		return x.PricePerGib
+	}
+	return 0
+}
+
+func (x *InstanceSearchItem) GetInstanceTypePrice() *InstanceTypePrice {
+	if x != nil {
+		return x.InstanceTypePrice
+	}
+	return nil
+}
+
+type WatchPricesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	// last_event_id resumes the stream after the event, empty means only the new events are watched
+	LastEventId string `protobuf:"bytes,2,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	// The filters below are empty to match all
+	Regions       []string `protobuf:"bytes,3,rep,name=regions,proto3" json:"regions,omitempty"`
+	InstanceTypes []string `protobuf:"bytes,4,rep,name=instance_types,json=instanceTypes,proto3" json:"instance_types,omitempty"`
+	CapacityTypes []string `protobuf:"bytes,5,rep,name=capacity_types,json=capacityTypes,proto3" json:"capacity_types,omitempty"`
+}
+
+func (x *WatchPricesRequest) Reset() {
+	*x = WatchPricesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPricesRequest) ProtoMessage() {}
+
+func (x *WatchPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPricesRequest.ProtoReflect.Descriptor instead.
+func (*WatchPricesRequest) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchPricesRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *WatchPricesRequest) GetLastEventId() string {
+	if x != nil {
+		return x.LastEventId
+	}
+	return ""
+}
+
+func (x *WatchPricesRequest) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *WatchPricesRequest) GetInstanceTypes() []string {
+	if x != nil {
+		return x.InstanceTypes
+	}
+	return nil
+}
+
+func (x *WatchPricesRequest) GetCapacityTypes() []string {
+	if x != nil {
+		return x.CapacityTypes
+	}
+	return nil
+}
+
+type WatchPricesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// missed means some events after the last event ID are not kept, the price data should be synced again
+	Missed bool              `protobuf:"varint,1,opt,name=missed,proto3" json:"missed,omitempty"`
+	Event  *PriceChangeEvent `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *WatchPricesResponse) Reset() {
+	*x = WatchPricesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPricesResponse) ProtoMessage() {}
+
+func (x *WatchPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPricesResponse.ProtoReflect.Descriptor instead.
+func (*WatchPricesResponse) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchPricesResponse) GetMissed() bool {
+	if x != nil {
+		return x.Missed
+	}
+	return false
+}
+
+func (x *WatchPricesResponse) GetEvent() *PriceChangeEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// RegionalInstancePrice mirrors apis.RegionalInstancePrice without the legacy EC2 field
+type RegionalInstancePrice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InstanceTypePrices map[string]*InstanceTypePrice `protobuf:"bytes,1,rep,name=instance_type_prices,json=instanceTypePrices,proto3" json:"instance_type_prices,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *RegionalInstancePrice) Reset() {
+	*x = RegionalInstancePrice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionalInstancePrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionalInstancePrice) ProtoMessage() {}
+
+func (x *RegionalInstancePrice) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionalInstancePrice.ProtoReflect.Descriptor instead.
+func (*RegionalInstancePrice) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RegionalInstancePrice) GetInstanceTypePrices() map[string]*InstanceTypePrice {
+	if x != nil {
+		return x.InstanceTypePrices
+	}
+	return nil
+}
+
+// InstanceTypePrice mirrors apis.InstanceTypePrice
+type InstanceTypePrice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Arch                 string   `protobuf:"bytes,1,opt,name=arch,proto3" json:"arch,omitempty"`
+	Vcpu                 float64  `protobuf:"fixed64,2,opt,name=vcpu,proto3" json:"vcpu,omitempty"`
+	Memory               float64  `protobuf:"fixed64,3,opt,name=memory,proto3" json:"memory,omitempty"`
+	Gpu                  float64  `protobuf:"fixed64,4,opt,name=gpu,proto3" json:"gpu,omitempty"`
+	Zones                []string `protobuf:"bytes,5,rep,name=zones,proto3" json:"zones,omitempty"`
+	OnDemandPricePerHour float64  `protobuf:"fixed64,6,opt,name=on_demand_price_per_hour,json=onDemandPricePerHour,proto3" json:"on_demand_price_per_hour,omitempty"`
+	// zone_ids maps the zone names to the zone ids
+	ZoneIds          map[string]string            `protobuf:"bytes,7,rep,name=zone_ids,json=zoneIds,proto3" json:"zone_ids,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ZoneAvailability map[string]*ZoneAvailability `protobuf:"bytes,8,rep,name=zone_availability,json=zoneAvailability,proto3" json:"zone_availability,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Spec             *InstanceTypeSpec            `protobuf:"bytes,9,opt,name=spec,proto3" json:"spec,omitempty"`
+	Currency         string                       `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	// key is {savings plan type}/{term length}/{payment option}
+	AwsEc2Billing map[string]*AWSEC2Billing `protobuf:"bytes,11,rep,name=aws_ec2_billing,json=awsEc2Billing,proto3" json:"aws_ec2_billing,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// key is {offering class}/{term length}/{payment option}
+	AwsEc2ReservedBilling map[string]*AWSEC2ReservedBilling `protobuf:"bytes,12,rep,name=aws_ec2_reserved_billing,json=awsEc2ReservedBilling,proto3" json:"aws_ec2_reserved_billing,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// key is {term length}
+	GcpCommittedUseBilling map[string]*GCPCommittedUseBilling `protobuf:"bytes,13,rep,name=gcp_committed_use_billing,json=gcpCommittedUseBilling,proto3" json:"gcp_committed_use_billing,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// key is {billing type}/{term length}
+	AzureBilling map[string]*AzureBilling `protobuf:"bytes,14,rep,name=azure_billing,json=azureBilling,proto3" json:"azure_billing,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// key is subscription/{term length} or savingsplan/{savings plan type}/{term length}/{payment option}
+	AlibabaCloudBilling map[string]*AlibabaCloudBilling `protobuf:"bytes,15,rep,name=alibaba_cloud_billing,json=alibabaCloudBilling,proto3" json:"alibaba_cloud_billing,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// spot_price_per_hour is the smallest spot price per hour of each zone
+	SpotPricePerHour map[string]float64 `protobuf:"bytes,16,rep,name=spot_price_per_hour,json=spotPricePerHour,proto3" json:"spot_price_per_hour,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	SpotInterruption *SpotInterruption  `protobuf:"bytes,17,opt,name=spot_interruption,json=spotInterruption,proto3" json:"spot_interruption,omitempty"`
+	// spot_placement_scores are the scores by target capacity
+	SpotPlacementScores map[string]*SpotPlacementScores `protobuf:"bytes,18,rep,name=spot_placement_scores,json=spotPlacementScores,proto3" json:"spot_placement_scores,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// platforms are the prices of the other operating systems and tenancies, key is {os}/{tenancy}
+	Platforms map[string]*PlatformPrice `protobuf:"bytes,19,rep,name=platforms,proto3" json:"platforms,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// site_prices are the list prices of the other sites, key is the site
+	SitePrices map[string]*SitePrice `protobuf:"bytes,20,rep,name=site_prices,json=sitePrices,proto3" json:"site_prices,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *InstanceTypePrice) Reset() {
+	*x = InstanceTypePrice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstanceTypePrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceTypePrice) ProtoMessage() {}
+
+func (x *InstanceTypePrice) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceTypePrice.ProtoReflect.Descriptor instead.
+func (*InstanceTypePrice) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *InstanceTypePrice) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *InstanceTypePrice) GetVcpu() float64 {
+	if x != nil {
+		return x.Vcpu
+	}
+	return 0
+}
+
+func (x *InstanceTypePrice) GetMemory() float64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *InstanceTypePrice) GetGpu() float64 {
+	if x != nil {
+		return x.Gpu
+	}
+	return 0
+}
+
+func (x *InstanceTypePrice) GetZones() []string {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetOnDemandPricePerHour() float64 {
+	if x != nil {
+		return x.OnDemandPricePerHour
+	}
+	return 0
+}
+
+func (x *InstanceTypePrice) GetZoneIds() map[string]string {
+	if x != nil {
+		return x.ZoneIds
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetZoneAvailability() map[string]*ZoneAvailability {
+	if x != nil {
+		return x.ZoneAvailability
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetSpec() *InstanceTypeSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *InstanceTypePrice) GetAwsEc2Billing() map[string]*AWSEC2Billing {
+	if x != nil {
+		return x.AwsEc2Billing
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetAwsEc2ReservedBilling() map[string]*AWSEC2ReservedBilling {
+	if x != nil {
+		return x.AwsEc2ReservedBilling
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetGcpCommittedUseBilling() map[string]*GCPCommittedUseBilling {
+	if x != nil {
+		return x.GcpCommittedUseBilling
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetAzureBilling() map[string]*AzureBilling {
+	if x != nil {
+		return x.AzureBilling
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetAlibabaCloudBilling() map[string]*AlibabaCloudBilling {
+	if x != nil {
+		return x.AlibabaCloudBilling
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetSpotPricePerHour() map[string]float64 {
+	if x != nil {
+		return x.SpotPricePerHour
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetSpotInterruption() *SpotInterruption {
+	if x != nil {
+		return x.SpotInterruption
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetSpotPlacementScores() map[string]*SpotPlacementScores {
+	if x != nil {
+		return x.SpotPlacementScores
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetPlatforms() map[string]*PlatformPrice {
+	if x != nil {
+		return x.Platforms
+	}
+	return nil
+}
+
+func (x *InstanceTypePrice) GetSitePrices() map[string]*SitePrice {
+	if x != nil {
+		return x.SitePrices
+	}
+	return nil
+}
+
+type InstanceTypeSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetworkPerformance        string         `protobuf:"bytes,1,opt,name=network_performance,json=networkPerformance,proto3" json:"network_performance,omitempty"`
+	NetworkBandwidthGbps      float64        `protobuf:"fixed64,2,opt,name=network_bandwidth_gbps,json=networkBandwidthGbps,proto3" json:"network_bandwidth_gbps,omitempty"`
+	MaxNetworkInterfaces      int32          `protobuf:"varint,3,opt,name=max_network_interfaces,json=maxNetworkInterfaces,proto3" json:"max_network_interfaces,omitempty"`
+	Ipv4AddressesPerInterface int32          `protobuf:"varint,4,opt,name=ipv4_addresses_per_interface,json=ipv4AddressesPerInterface,proto3" json:"ipv4_addresses_per_interface,omitempty"`
+	LocalStorage              float64        `protobuf:"fixed64,5,opt,name=local_storage,json=localStorage,proto3" json:"local_storage,omitempty"`
+	LocalStorageType          string         `protobuf:"bytes,6,opt,name=local_storage_type,json=localStorageType,proto3" json:"local_storage_type,omitempty"`
+	LocalStorageNvme          bool           `protobuf:"varint,7,opt,name=local_storage_nvme,json=localStorageNvme,proto3" json:"local_storage_nvme,omitempty"`
+	DiskBandwidthMbps         float64        `protobuf:"fixed64,8,opt,name=disk_bandwidth_mbps,json=diskBandwidthMbps,proto3" json:"disk_bandwidth_mbps,omitempty"`
+	DiskMaxBandwidthMbps      float64        `protobuf:"fixed64,9,opt,name=disk_max_bandwidth_mbps,json=diskMaxBandwidthMbps,proto3" json:"disk_max_bandwidth_mbps,omitempty"`
+	Burstable                 bool           `protobuf:"varint,10,opt,name=burstable,proto3" json:"burstable,omitempty"`
+	Hypervisor                string         `protobuf:"bytes,11,opt,name=hypervisor,proto3" json:"hypervisor,omitempty"`
+	CurrentGeneration         *bool          `protobuf:"varint,12,opt,name=current_generation,json=currentGeneration,proto3,oneof" json:"current_generation,omitempty"`
+	GpuModel                  string         `protobuf:"bytes,13,opt,name=gpu_model,json=gpuModel,proto3" json:"gpu_model,omitempty"`
+	GpuManufacturer           string         `protobuf:"bytes,14,opt,name=gpu_manufacturer,json=gpuManufacturer,proto3" json:"gpu_manufacturer,omitempty"`
+	GpuMemory                 float64        `protobuf:"fixed64,15,opt,name=gpu_memory,json=gpuMemory,proto3" json:"gpu_memory,omitempty"`
+	Accelerators              []*Accelerator `protobuf:"bytes,16,rep,name=accelerators,proto3" json:"accelerators,omitempty"`
+}
+
+func (x *InstanceTypeSpec) Reset() {
+	*x = InstanceTypeSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstanceTypeSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceTypeSpec) ProtoMessage() {}
+
+func (x *InstanceTypeSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceTypeSpec.ProtoReflect.Descriptor instead.
+func (*InstanceTypeSpec) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *InstanceTypeSpec) GetNetworkPerformance() string {
+	if x != nil {
+		return x.NetworkPerformance
+	}
+	return ""
+}
+
+func (x *InstanceTypeSpec) GetNetworkBandwidthGbps() float64 {
+	if x != nil {
+		return x.NetworkBandwidthGbps
+	}
+	return 0
+}
+
+func (x *InstanceTypeSpec) GetMaxNetworkInterfaces() int32 {
+	if x != nil {
+		return x.MaxNetworkInterfaces
+	}
+	return 0
+}
+
+func (x *InstanceTypeSpec) GetIpv4AddressesPerInterface() int32 {
+	if x != nil {
+		return x.Ipv4AddressesPerInterface
+	}
+	return 0
+}
+
+func (x *InstanceTypeSpec) GetLocalStorage() float64 {
+	if x != nil {
+		return x.LocalStorage
+	}
+	return 0
+}
+
+func (x *InstanceTypeSpec) GetLocalStorageType() string {
+	if x != nil {
+		return x.LocalStorageType
+	}
+	return ""
+}
+
+func (x *InstanceTypeSpec) GetLocalStorageNvme() bool {
+	if x != nil {
+		return x.LocalStorageNvme
+	}
+	return false
+}
+
+func (x *InstanceTypeSpec) GetDiskBandwidthMbps() float64 {
+	if x != nil {
+		return x.DiskBandwidthMbps
+	}
+	return 0
+}
+
+func (x *InstanceTypeSpec) GetDiskMaxBandwidthMbps() float64 {
+	if x != nil {
+		return x.DiskMaxBandwidthMbps
+	}
+	return 0
+}
+
+func (x *InstanceTypeSpec) GetBurstable() bool {
+	if x != nil {
+		return x.Burstable
+	}
+	return false
+}
+
+func (x *InstanceTypeSpec) GetHypervisor() string {
+	if x != nil {
+		return x.Hypervisor
+	}
+	return ""
+}
+
+func (x *InstanceTypeSpec) GetCurrentGeneration() bool {
+	if x != nil && x.CurrentGeneration != nil {
+		return *x.CurrentGeneration
+	}
+	return false
+}
+
+func (x *InstanceTypeSpec) GetGpuModel() string {
+	if x != nil {
+		return x.GpuModel
+	}
+	return ""
+}
+
+func (x *InstanceTypeSpec) GetGpuManufacturer() string {
+	if x != nil {
+		return x.GpuManufacturer
+	}
+	return ""
+}
+
+func (x *InstanceTypeSpec) GetGpuMemory() float64 {
+	if x != nil {
+		return x.GpuMemory
+	}
+	return 0
+}
+
+func (x *InstanceTypeSpec) GetAccelerators() []*Accelerator {
+	if x != nil {
+		return x.Accelerators
+	}
+	return nil
+}
+
+type Accelerator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type         string  `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Manufacturer string  `protobuf:"bytes,3,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	Count        int32   `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Memory       float64 `protobuf:"fixed64,5,opt,name=memory,proto3" json:"memory,omitempty"`
+}
+
+func (x *Accelerator) Reset() {
+	*x = Accelerator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Accelerator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Accelerator) ProtoMessage() {}
+
+func (x *Accelerator) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Accelerator.ProtoReflect.Descriptor instead.
+func (*Accelerator) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Accelerator) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Accelerator) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Accelerator) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *Accelerator) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Accelerator) GetMemory() float64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+type ZoneAvailability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Available   bool   `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
+	StockStatus string `protobuf:"bytes,2,opt,name=stock_status,json=stockStatus,proto3" json:"stock_status,omitempty"`
+}
+
+func (x *ZoneAvailability) Reset() {
+	*x = ZoneAvailability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZoneAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZoneAvailability) ProtoMessage() {}
+
+func (x *ZoneAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZoneAvailability.ProtoReflect.Descriptor instead.
+func (*ZoneAvailability) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ZoneAvailability) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *ZoneAvailability) GetStockStatus() string {
+	if x != nil {
+		return x.StockStatus
+	}
+	return ""
+}
+
+type SitePrice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OnDemandPricePerHour float64 `protobuf:"fixed64,1,opt,name=on_demand_price_per_hour,json=onDemandPricePerHour,proto3" json:"on_demand_price_per_hour,omitempty"`
+	Currency             string  `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *SitePrice) Reset() {
+	*x = SitePrice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SitePrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SitePrice) ProtoMessage() {}
+
+func (x *SitePrice) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SitePrice.ProtoReflect.Descriptor instead.
+func (*SitePrice) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SitePrice) GetOnDemandPricePerHour() float64 {
+	if x != nil {
+		return x.OnDemandPricePerHour
+	}
+	return 0
+}
+
+func (x *SitePrice) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type PlatformPrice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OnDemandPricePerHour  float64                           `protobuf:"fixed64,1,opt,name=on_demand_price_per_hour,json=onDemandPricePerHour,proto3" json:"on_demand_price_per_hour,omitempty"`
+	AwsEc2Billing         map[string]*AWSEC2Billing         `protobuf:"bytes,2,rep,name=aws_ec2_billing,json=awsEc2Billing,proto3" json:"aws_ec2_billing,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	AwsEc2ReservedBilling map[string]*AWSEC2ReservedBilling `protobuf:"bytes,3,rep,name=aws_ec2_reserved_billing,json=awsEc2ReservedBilling,proto3" json:"aws_ec2_reserved_billing,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SpotPricePerHour      map[string]float64                `protobuf:"bytes,4,rep,name=spot_price_per_hour,json=spotPricePerHour,proto3" json:"spot_price_per_hour,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	SpotInterruption      *SpotInterruption                 `protobuf:"bytes,5,opt,name=spot_interruption,json=spotInterruption,proto3" json:"spot_interruption,omitempty"`
+}
+
+func (x *PlatformPrice) Reset() {
+	*x = PlatformPrice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlatformPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlatformPrice) ProtoMessage() {}
+
+func (x *PlatformPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlatformPrice.ProtoReflect.Descriptor instead.
+func (*PlatformPrice) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PlatformPrice) GetOnDemandPricePerHour() float64 {
+	if x != nil {
+		return x.OnDemandPricePerHour
+	}
+	return 0
+}
+
+func (x *PlatformPrice) GetAwsEc2Billing() map[string]*AWSEC2Billing {
+	if x != nil {
+		return x.AwsEc2Billing
+	}
+	return nil
+}
+
+func (x *PlatformPrice) GetAwsEc2ReservedBilling() map[string]*AWSEC2ReservedBilling {
+	if x != nil {
+		return x.AwsEc2ReservedBilling
+	}
+	return nil
+}
+
+func (x *PlatformPrice) GetSpotPricePerHour() map[string]float64 {
+	if x != nil {
+		return x.SpotPricePerHour
+	}
+	return nil
+}
+
+func (x *PlatformPrice) GetSpotInterruption() *SpotInterruption {
+	if x != nil {
+		return x.SpotInterruption
+	}
+	return nil
+}
+
+type SpotInterruption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bucket  int32  `protobuf:"varint,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Range   string `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	Savings int32  `protobuf:"varint,3,opt,name=savings,proto3" json:"savings,omitempty"`
+}
+
+func (x *SpotInterruption) Reset() {
+	*x = SpotInterruption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpotInterruption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpotInterruption) ProtoMessage() {}
+
+func (x *SpotInterruption) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpotInterruption.ProtoReflect.Descriptor instead.
+func (*SpotInterruption) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SpotInterruption) GetBucket() int32 {
+	if x != nil {
+		return x.Bucket
+	}
+	return 0
+}
+
+func (x *SpotInterruption) GetRange() string {
+	if x != nil {
+		return x.Range
+	}
+	return ""
+}
+
+func (x *SpotInterruption) GetSavings() int32 {
+	if x != nil {
+		return x.Savings
+	}
+	return 0
+}
+
+// SpotPlacementScores are the spot placement scores from 1 to 10 of each zone
+type SpotPlacementScores struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Zones map[string]int32 `protobuf:"bytes,1,rep,name=zones,proto3" json:"zones,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *SpotPlacementScores) Reset() {
+	*x = SpotPlacementScores{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpotPlacementScores) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpotPlacementScores) ProtoMessage() {}
+
+func (x *SpotPlacementScores) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpotPlacementScores.ProtoReflect.Descriptor instead.
+func (*SpotPlacementScores) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SpotPlacementScores) GetZones() map[string]int32 {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+type AWSEC2Billing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rate float64 `protobuf:"fixed64,1,opt,name=rate,proto3" json:"rate,omitempty"`
+}
+
+func (x *AWSEC2Billing) Reset() {
+	*x = AWSEC2Billing{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AWSEC2Billing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AWSEC2Billing) ProtoMessage() {}
+
+func (x *AWSEC2Billing) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AWSEC2Billing.ProtoReflect.Descriptor instead.
+func (*AWSEC2Billing) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AWSEC2Billing) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+type AWSEC2ReservedBilling struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rate       float64 `protobuf:"fixed64,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	UpfrontFee float64 `protobuf:"fixed64,2,opt,name=upfront_fee,json=upfrontFee,proto3" json:"upfront_fee,omitempty"`
+	HourlyRate float64 `protobuf:"fixed64,3,opt,name=hourly_rate,json=hourlyRate,proto3" json:"hourly_rate,omitempty"`
+}
+
+func (x *AWSEC2ReservedBilling) Reset() {
+	*x = AWSEC2ReservedBilling{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AWSEC2ReservedBilling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AWSEC2ReservedBilling) ProtoMessage() {}
+
+func (x *AWSEC2ReservedBilling) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AWSEC2ReservedBilling.ProtoReflect.Descriptor instead.
+func (*AWSEC2ReservedBilling) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AWSEC2ReservedBilling) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *AWSEC2ReservedBilling) GetUpfrontFee() float64 {
+	if x != nil {
+		return x.UpfrontFee
+	}
+	return 0
+}
+
+func (x *AWSEC2ReservedBilling) GetHourlyRate() float64 {
+	if x != nil {
+		return x.HourlyRate
+	}
+	return 0
+}
+
+type GCPCommittedUseBilling struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rate float64 `protobuf:"fixed64,1,opt,name=rate,proto3" json:"rate,omitempty"`
+}
+
+func (x *GCPCommittedUseBilling) Reset() {
+	*x = GCPCommittedUseBilling{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCPCommittedUseBilling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCPCommittedUseBilling) ProtoMessage() {}
+
+func (x *GCPCommittedUseBilling) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCPCommittedUseBilling.ProtoReflect.Descriptor instead.
+func (*GCPCommittedUseBilling) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GCPCommittedUseBilling) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+type AzureBilling struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rate float64 `protobuf:"fixed64,1,opt,name=rate,proto3" json:"rate,omitempty"`
+}
+
+func (x *AzureBilling) Reset() {
+	*x = AzureBilling{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AzureBilling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AzureBilling) ProtoMessage() {}
+
+func (x *AzureBilling) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AzureBilling.ProtoReflect.Descriptor instead.
+func (*AzureBilling) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AzureBilling) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+type AlibabaCloudBilling struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rate       float64 `protobuf:"fixed64,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	UpfrontFee float64 `protobuf:"fixed64,2,opt,name=upfront_fee,json=upfrontFee,proto3" json:"upfront_fee,omitempty"`
+}
+
+func (x *AlibabaCloudBilling) Reset() {
+	*x = AlibabaCloudBilling{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlibabaCloudBilling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlibabaCloudBilling) ProtoMessage() {}
+
+func (x *AlibabaCloudBilling) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlibabaCloudBilling.ProtoReflect.Descriptor instead.
+func (*AlibabaCloudBilling) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AlibabaCloudBilling) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *AlibabaCloudBilling) GetUpfrontFee() float64 {
+	if x != nil {
+		return x.UpfrontFee
+	}
+	return 0
+}
+
+// PriceChangeEvent mirrors apis.PriceChangeEvent
+type PriceChangeEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Provider     string `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	Region       string `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	InstanceType string `protobuf:"bytes,4,opt,name=instance_type,json=instanceType,proto3" json:"instance_type,omitempty"`
+	// zone is only set for the spot prices
+	Zone         string `protobuf:"bytes,5,opt,name=zone,proto3" json:"zone,omitempty"`
+	CapacityType string `protobuf:"bytes,6,opt,name=capacity_type,json=capacityType,proto3" json:"capacity_type,omitempty"`
+	// old_price and new_price are the prices per hour, zero means the price is added or removed
+	OldPrice float64                `protobuf:"fixed64,7,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"`
+	NewPrice float64                `protobuf:"fixed64,8,opt,name=new_price,json=newPrice,proto3" json:"new_price,omitempty"`
+	Currency string                 `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	Time     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *PriceChangeEvent) Reset() {
+	*x = PriceChangeEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_priceserver_v1_price_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriceChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChangeEvent) ProtoMessage() {}
+
+func (x *PriceChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_priceserver_v1_price_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChangeEvent.ProtoReflect.Descriptor instead.
+func (*PriceChangeEvent) Descriptor() ([]byte, []int) {
+	return file_priceserver_v1_price_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PriceChangeEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PriceChangeEvent) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *PriceChangeEvent) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *PriceChangeEvent) GetInstanceType() string {
+	if x != nil {
+		return x.InstanceType
+	}
+	return ""
+}
+
+func (x *PriceChangeEvent) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *PriceChangeEvent) GetCapacityType() string {
+	if x != nil {
+		return x.CapacityType
+	}
+	return ""
+}
+
+func (x *PriceChangeEvent) GetOldPrice() float64 {
+	if x != nil {
+		return x.OldPrice
+	}
+	return 0
+}
+
+func (x *PriceChangeEvent) GetNewPrice() float64 {
+	if x != nil {
+		return x.NewPrice
+	}
+	return 0
+}
+
+func (x *PriceChangeEvent) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PriceChangeEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+var File_priceserver_v1_price_proto protoreflect.FileDescriptor
+
+var file_priceserver_v1_price_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31,
+	0x2f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x69, 0x0a,
+	0x0d, 0x50, 0x72, 0x69, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x74, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x30, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x22, 0x2f, 0x0a, 0x13, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x11,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a,
+	0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x22, 0xc2, 0x01, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x07, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52,
+	0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x61, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xad, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x39, 0x0a, 0x08,
+	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x53, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0xe4, 0x04, 0x0a,
+	0x16, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x61, 0x72, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61,
+	0x72, 0x63, 0x68, 0x12, 0x1e, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x63, 0x70, 0x75, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x69, 0x6e, 0x56, 0x63, 0x70, 0x75,
+	0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x63, 0x70, 0x75, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x56, 0x63, 0x70, 0x75,
+	0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x48, 0x02, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x48, 0x03, 0x52, 0x09, 0x6d,
+	0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x88, 0x01, 0x01, 0x12, 0x1c, 0x0a, 0x07, 0x6d,
+	0x69, 0x6e, 0x5f, 0x67, 0x70, 0x75, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x48, 0x04, 0x52, 0x06,
+	0x6d, 0x69, 0x6e, 0x47, 0x70, 0x75, 0x88, 0x01, 0x01, 0x12, 0x1c, 0x0a, 0x07, 0x6d, 0x61, 0x78,
+	0x5f, 0x67, 0x70, 0x75, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x48, 0x05, 0x52, 0x06, 0x6d, 0x61,
+	0x78, 0x47, 0x70, 0x75, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x39, 0x0a,
+	0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x08,
+	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6f, 0x72, 0x74,
+	0x5f, 0x62, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x64, 0x65, 0x73, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x63, 0x70, 0x75,
+	0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x63, 0x70, 0x75, 0x42, 0x0d, 0x0a,
+	0x0b, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x0d, 0x0a, 0x0b,
+	0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x0a, 0x0a, 0x08, 0x5f,
+	0x6d, 0x69, 0x6e, 0x5f, 0x67, 0x70, 0x75, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
+	0x67, 0x70, 0x75, 0x22, 0x85, 0x01, 0x0a, 0x17, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x12, 0x38, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xa0, 0x02, 0x0a, 0x12,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x74,
+	0x65, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x76,
+	0x63, 0x70, 0x75, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x50, 0x65, 0x72, 0x56, 0x63, 0x70, 0x75, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x5f, 0x70, 0x65, 0x72, 0x5f, 0x67, 0x69, 0x62, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x50, 0x65, 0x72, 0x47, 0x69, 0x62, 0x12, 0x51, 0x0a, 0x13, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x11, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0xbc,
+	0x01, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x12, 0x22, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69,
+	0x74, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d,
+	0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0x65, 0x0a,
+	0x13, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x22, 0xf2, 0x01, 0x0a, 0x15, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x6f,
+	0x0a, 0x14, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x12, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x1a,
+	0x68, 0x0a, 0x17, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x37, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb7, 0x13, 0x0a, 0x11, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x61, 0x72, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61,
+	0x72, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x63, 0x70, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x04, 0x76, 0x63, 0x70, 0x75, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x67, 0x70, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x67, 0x70,
+	0x75, 0x12, 0x14, 0x0a, 0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x18, 0x6f, 0x6e, 0x5f, 0x64, 0x65,
+	0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x68,
+	0x6f, 0x75, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x14, 0x6f, 0x6e, 0x44, 0x65, 0x6d,
+	0x61, 0x6e, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x50, 0x65, 0x72, 0x48, 0x6f, 0x75, 0x72, 0x12,
+	0x49, 0x0a, 0x08, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2e, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x2e, 0x5a, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x7a, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x73, 0x12, 0x64, 0x0a, 0x11, 0x7a, 0x6f,
+	0x6e, 0x65, 0x5f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x2e, 0x5a, 0x6f, 0x6e, 0x65, 0x41, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10,
+	0x7a, 0x6f, 0x6e, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x12, 0x34, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x53, 0x70, 0x65, 0x63,
+	0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x12, 0x5c, 0x0a, 0x0f, 0x61, 0x77, 0x73, 0x5f, 0x65, 0x63, 0x32, 0x5f, 0x62, 0x69,
+	0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x2e, 0x41,
+	0x77, 0x73, 0x45, 0x63, 0x32, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0d, 0x61, 0x77, 0x73, 0x45, 0x63, 0x32, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67,
+	0x12, 0x75, 0x0a, 0x18, 0x61, 0x77, 0x73, 0x5f, 0x65, 0x63, 0x32, 0x5f, 0x72, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x64, 0x5f, 0x62, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x0c, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x77, 0x73, 0x45, 0x63, 0x32, 0x52, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x64, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x15, 0x61, 0x77, 0x73, 0x45, 0x63, 0x32, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64,
+	0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x78, 0x0a, 0x19, 0x67, 0x63, 0x70, 0x5f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x73, 0x65, 0x5f, 0x62, 0x69, 0x6c,
+	0x6c, 0x69, 0x6e, 0x67, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x63,
+	0x70, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x42, 0x69, 0x6c,
+	0x6c, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x16, 0x67, 0x63, 0x70, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e,
+	0x67, 0x12, 0x58, 0x0a, 0x0d, 0x61, 0x7a, 0x75, 0x72, 0x65, 0x5f, 0x62, 0x69, 0x6c, 0x6c, 0x69,
+	0x6e, 0x67, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x7a, 0x75, 0x72,
+	0x65, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x61,
+	0x7a, 0x75, 0x72, 0x65, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x6e, 0x0a, 0x15, 0x61,
+	0x6c, 0x69, 0x62, 0x61, 0x62, 0x61, 0x5f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x62, 0x69, 0x6c,
+	0x6c, 0x69, 0x6e, 0x67, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c,
+	0x69, 0x62, 0x61, 0x62, 0x61, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e,
+	0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x61, 0x6c, 0x69, 0x62, 0x61, 0x62, 0x61, 0x43,
+	0x6c, 0x6f, 0x75, 0x64, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x66, 0x0a, 0x13, 0x73,
+	0x70, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x68, 0x6f,
+	0x75, 0x72, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x70, 0x6f, 0x74,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x50, 0x65, 0x72, 0x48, 0x6f, 0x75, 0x72, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x10, 0x73, 0x70, 0x6f, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x50, 0x65, 0x72, 0x48,
+	0x6f, 0x75, 0x72, 0x12, 0x4d, 0x0a, 0x11, 0x73, 0x70, 0x6f, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x72, 0x75, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x70, 0x6f, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x10, 0x73, 0x70, 0x6f, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x6e, 0x0a, 0x15, 0x73, 0x70, 0x6f, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x3a, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x70, 0x6f, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x73,
+	0x70, 0x6f, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x73, 0x12, 0x4e, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x73, 0x18,
+	0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72,
+	0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72,
+	0x6d, 0x73, 0x12, 0x52, 0x0a, 0x0b, 0x73, 0x69, 0x74, 0x65, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x69, 0x74, 0x65, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x73, 0x69, 0x74, 0x65,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x5a, 0x6f, 0x6e, 0x65, 0x49, 0x64,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x65, 0x0a, 0x15, 0x5a, 0x6f, 0x6e, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x36, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x5a, 0x6f,
+	0x6e, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5f, 0x0a, 0x12, 0x41, 0x77, 0x73,
+	0x45, 0x63, 0x32, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x57, 0x53, 0x45, 0x43, 0x32, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x6f, 0x0a, 0x1a, 0x41, 0x77,
+	0x73, 0x45, 0x63, 0x32, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x42, 0x69, 0x6c, 0x6c,
+	0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3b, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x57, 0x53, 0x45, 0x43,
+	0x32, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x71, 0x0a, 0x1b, 0x47,
+	0x63, 0x70, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x42, 0x69,
+	0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3c, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x43, 0x50,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x42, 0x69, 0x6c, 0x6c,
+	0x69, 0x6e, 0x67, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5d,
+	0x0a, 0x11, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x42, 0x69, 0x6c, 0x6c, 0x69,
+	0x6e, 0x67, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x6b, 0x0a,
+	0x18, 0x41, 0x6c, 0x69, 0x62, 0x61, 0x62, 0x61, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x42, 0x69, 0x6c,
+	0x6c, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x39, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x69, 0x62,
+	0x61, 0x62, 0x61, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x43, 0x0a, 0x15, 0x53, 0x70,
+	0x6f, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x50, 0x65, 0x72, 0x48, 0x6f, 0x75, 0x72, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x6b, 0x0a, 0x18, 0x53, 0x70, 0x6f, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x39, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70,
+	0x6f, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5b, 0x0a, 0x0e,
+	0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x58, 0x0a, 0x0f, 0x53, 0x69, 0x74,
+	0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2f,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x69, 0x74, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x89, 0x06, 0x0a, 0x10, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x2f, 0x0a, 0x13, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x5f, 0x70, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x65,
+	0x72, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x6e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x5f, 0x67,
+	0x62, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x14, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x47, 0x62, 0x70, 0x73, 0x12,
+	0x34, 0x0a, 0x16, 0x6d, 0x61, 0x78, 0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x14, 0x6d, 0x61, 0x78, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x1c, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x19, 0x69, 0x70, 0x76,
+	0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x50, 0x65, 0x72, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x76, 0x6d, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x4e, 0x76, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x64, 0x69, 0x73, 0x6b, 0x5f,
+	0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x5f, 0x6d, 0x62, 0x70, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x64, 0x69, 0x73, 0x6b, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x4d, 0x62, 0x70, 0x73, 0x12, 0x35, 0x0a, 0x17, 0x64, 0x69, 0x73, 0x6b, 0x5f,
+	0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x5f, 0x6d, 0x62,
+	0x70, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x14, 0x64, 0x69, 0x73, 0x6b, 0x4d, 0x61,
+	0x78, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x4d, 0x62, 0x70, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x62, 0x75, 0x72, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x62, 0x75, 0x72, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x68, 0x79, 0x70, 0x65, 0x72, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x68, 0x79, 0x70, 0x65, 0x72, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x12, 0x32, 0x0a, 0x12,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x11, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01,
+	0x12, 0x1b, 0x0a, 0x09, 0x67, 0x70, 0x75, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x70, 0x75, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x29, 0x0a,
+	0x10, 0x67, 0x70, 0x75, 0x5f, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65,
+	0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x67, 0x70, 0x75, 0x4d, 0x61, 0x6e, 0x75,
+	0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x70, 0x75, 0x5f,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x67, 0x70,
+	0x75, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x3f, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x6c,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0c, 0x61, 0x63, 0x63, 0x65,
+	0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x87, 0x01, 0x0a, 0x0b, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66,
+	0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d,
+	0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x53, 0x0a, 0x10, 0x5a, 0x6f, 0x6e,
+	0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73,
+	0x74, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x5f,
+	0x0a, 0x09, 0x53, 0x69, 0x74, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x18, 0x6f,
+	0x6e, 0x5f, 0x64, 0x65, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x70,
+	0x65, 0x72, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x14, 0x6f,
+	0x6e, 0x44, 0x65, 0x6d, 0x61, 0x6e, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x50, 0x65, 0x72, 0x48,
+	0x6f, 0x75, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22,
+	0xde, 0x05, 0x0a, 0x0d, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x36, 0x0a, 0x18, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x14, 0x6f, 0x6e, 0x44, 0x65, 0x6d, 0x61, 0x6e, 0x64, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x50, 0x65, 0x72, 0x48, 0x6f, 0x75, 0x72, 0x12, 0x58, 0x0a, 0x0f, 0x61, 0x77, 0x73,
+	0x5f, 0x65, 0x63, 0x32, 0x5f, 0x62, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x30, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x2e, 0x41, 0x77, 0x73, 0x45, 0x63, 0x32, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x61, 0x77, 0x73, 0x45, 0x63, 0x32, 0x42, 0x69, 0x6c, 0x6c,
+	0x69, 0x6e, 0x67, 0x12, 0x71, 0x0a, 0x18, 0x61, 0x77, 0x73, 0x5f, 0x65, 0x63, 0x32, 0x5f, 0x72,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x62, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x77, 0x73, 0x45, 0x63, 0x32, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x64, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x15, 0x61, 0x77, 0x73, 0x45, 0x63, 0x32, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x42,
+	0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x62, 0x0a, 0x13, 0x73, 0x70, 0x6f, 0x74, 0x5f, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x2e, 0x53, 0x70, 0x6f, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x50, 0x65, 0x72, 0x48,
+	0x6f, 0x75, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x73, 0x70, 0x6f, 0x74, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x50, 0x65, 0x72, 0x48, 0x6f, 0x75, 0x72, 0x12, 0x4d, 0x0a, 0x11, 0x73, 0x70,
+	0x6f, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x6f, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x72, 0x75, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x73, 0x70, 0x6f, 0x74, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x72, 0x75, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x5f, 0x0a, 0x12, 0x41, 0x77, 0x73,
+	0x45, 0x63, 0x32, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x57, 0x53, 0x45, 0x43, 0x32, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x6f, 0x0a, 0x1a, 0x41, 0x77,
+	0x73, 0x45, 0x63, 0x32, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x42, 0x69, 0x6c, 0x6c,
+	0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3b, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x57, 0x53, 0x45, 0x43,
+	0x32, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x43, 0x0a, 0x15, 0x53,
+	0x70, 0x6f, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x50, 0x65, 0x72, 0x48, 0x6f, 0x75, 0x72, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x5a, 0x0a, 0x10, 0x53, 0x70, 0x6f, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x95, 0x01, 0x0a,
+	0x13, 0x53, 0x70, 0x6f, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x73, 0x12, 0x44, 0x0a, 0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x6f, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x2e, 0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x5a, 0x6f,
+	0x6e, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x23, 0x0a, 0x0d, 0x41, 0x57, 0x53, 0x45, 0x43, 0x32, 0x42, 0x69,
+	0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x22, 0x6d, 0x0a, 0x15, 0x41, 0x57, 0x53,
+	0x45, 0x43, 0x32, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x42, 0x69, 0x6c, 0x6c, 0x69,
+	0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x70, 0x66, 0x72, 0x6f, 0x6e,
+	0x74, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x75, 0x70, 0x66,
+	0x72, 0x6f, 0x6e, 0x74, 0x46, 0x65, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x6f, 0x75, 0x72, 0x6c,
+	0x79, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x68, 0x6f,
+	0x75, 0x72, 0x6c, 0x79, 0x52, 0x61, 0x74, 0x65, 0x22, 0x2c, 0x0a, 0x16, 0x47, 0x43, 0x50, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x42, 0x69, 0x6c, 0x6c, 0x69,
+	0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x22, 0x22, 0x0a, 0x0c, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x42,
+	0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x22, 0x4a, 0x0a, 0x13, 0x41, 0x6c,
+	0x69, 0x62, 0x61, 0x62, 0x61, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e,
+	0x67, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x70, 0x66, 0x72, 0x6f, 0x6e, 0x74,
+	0x5f, 0x66, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x75, 0x70, 0x66, 0x72,
+	0x6f, 0x6e, 0x74, 0x46, 0x65, 0x65, 0x22, 0xba, 0x02, 0x0a, 0x10, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12,
+	0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x08, 0x6f, 0x6c, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65,
+	0x77, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6e,
+	0x65, 0x77, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x32, 0xe0, 0x03, 0x0a, 0x0c, 0x50, 0x72, 0x69, 0x63, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x56, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x22, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0a,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x65, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x27, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28,
+	0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x26, 0x2e, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0b,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x23, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2d,
+	0x61, 0x69, 0x2f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_priceserver_v1_price_proto_rawDescOnce sync.Once
+	file_priceserver_v1_price_proto_rawDescData = file_priceserver_v1_price_proto_rawDesc
+)
+
+func file_priceserver_v1_price_proto_rawDescGZIP() []byte {
+	file_priceserver_v1_price_proto_rawDescOnce.Do(func() {
+		file_priceserver_v1_price_proto_rawDescData = protoimpl.X.CompressGZIP(file_priceserver_v1_price_proto_rawDescData)
+	})
+	return file_priceserver_v1_price_proto_rawDescData
+}
+
+var file_priceserver_v1_price_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
+var file_priceserver_v1_price_proto_goTypes = []interface{}{
+	(*PriceSelector)(nil),            // 0: priceserver.v1.PriceSelector
+	(*ListRegionsRequest)(nil),       // 1: priceserver.v1.ListRegionsRequest
+	(*ListRegionsResponse)(nil),      // 2: priceserver.v1.ListRegionsResponse
+	(*ListPricesRequest)(nil),        // 3: priceserver.v1.ListPricesRequest
+	(*ListPricesResponse)(nil),       // 4: priceserver.v1.ListPricesResponse
+	(*GetInstancePriceRequest)(nil),  // 5: priceserver.v1.GetInstancePriceRequest
+	(*GetInstancePriceResponse)(nil), // 6: priceserver.v1.GetInstancePriceResponse
+	(*SearchInstancesRequest)(nil),   // 7: priceserver.v1.SearchInstancesRequest
+	(*SearchInstancesResponse)(nil),  // 8: priceserver.v1.SearchInstancesResponse
+	(*InstanceSearchItem)(nil),       // 9: priceserver.v1.InstanceSearchItem
+	(*WatchPricesRequest)(nil),       // 10: priceserver.v1.WatchPricesRequest
+	(*WatchPricesResponse)(nil),      // 11: priceserver.v1.WatchPricesResponse
+	(*RegionalInstancePrice)(nil),    // 12: priceserver.v1.RegionalInstancePrice
+	(*InstanceTypePrice)(nil),        // 13: priceserver.v1.InstanceTypePrice
+	(*InstanceTypeSpec)(nil),         // 14: priceserver.v1.InstanceTypeSpec
+	(*Accelerator)(nil),              // 15: priceserver.v1.Accelerator
+	(*ZoneAvailability)(nil),         // 16: priceserver.v1.ZoneAvailability
+	(*SitePrice)(nil),                // 17: priceserver.v1.SitePrice
+	(*PlatformPrice)(nil),            // 18: priceserver.v1.PlatformPrice
+	(*SpotInterruption)(nil),         // 19: priceserver.v1.SpotInterruption
+	(*SpotPlacementScores)(nil),      // 20: priceserver.v1.SpotPlacementScores
+	(*AWSEC2Billing)(nil),            // 21: priceserver.v1.AWSEC2Billing
+	(*AWSEC2ReservedBilling)(nil),    // 22: priceserver.v1.AWSEC2ReservedBilling
+	(*GCPCommittedUseBilling)(nil),   // 23: priceserver.v1.GCPCommittedUseBilling
+	(*AzureBilling)(nil),             // 24: priceserver.v1.AzureBilling
+	(*AlibabaCloudBilling)(nil),      // 25: priceserver.v1.AlibabaCloudBilling
+	(*PriceChangeEvent)(nil),         // 26: priceserver.v1.PriceChangeEvent
+	nil,                              // 27: priceserver.v1.ListPricesResponse.RegionsEntry
+	nil,                              // 28: priceserver.v1.RegionalInstancePrice.InstanceTypePricesEntry
+	nil,                              // 29: priceserver.v1.InstanceTypePrice.ZoneIdsEntry
+	nil,                              // 30: priceserver.v1.InstanceTypePrice.ZoneAvailabilityEntry
+	nil,                              // 31: priceserver.v1.InstanceTypePrice.AwsEc2BillingEntry
+	nil,                              // 32: priceserver.v1.InstanceTypePrice.AwsEc2ReservedBillingEntry
+	nil,                              // 33: priceserver.v1.InstanceTypePrice.GcpCommittedUseBillingEntry
+	nil,                              // 34: priceserver.v1.InstanceTypePrice.AzureBillingEntry
+	nil,                              // 35: priceserver.v1.InstanceTypePrice.AlibabaCloudBillingEntry
+	nil,                              // 36: priceserver.v1.InstanceTypePrice.SpotPricePerHourEntry
+	nil,                              // 37: priceserver.v1.InstanceTypePrice.SpotPlacementScoresEntry
+	nil,                              // 38: priceserver.v1.InstanceTypePrice.PlatformsEntry
+	nil,                              // 39: priceserver.v1.InstanceTypePrice.SitePricesEntry
+	nil,                              // 40: priceserver.v1.PlatformPrice.AwsEc2BillingEntry
+	nil,                              // 41: priceserver.v1.PlatformPrice.AwsEc2ReservedBillingEntry
+	nil,                              // 42: priceserver.v1.PlatformPrice.SpotPricePerHourEntry
+	nil,                              // 43: priceserver.v1.SpotPlacementScores.ZonesEntry
+	(*timestamppb.Timestamp)(nil),    // 44: google.protobuf.Timestamp
+}
+var file_priceserver_v1_price_proto_depIdxs = []int32{
+	0,  // 0: priceserver.v1.ListPricesRequest.selector:type_name -> priceserver.v1.PriceSelector
+	27, // 1: priceserver.v1.ListPricesResponse.regions:type_name -> priceserver.v1.ListPricesResponse.RegionsEntry
+	0,  // 2: priceserver.v1.GetInstancePriceRequest.selector:type_name -> priceserver.v1.PriceSelector
+	13, // 3: priceserver.v1.GetInstancePriceResponse.price:type_name -> priceserver.v1.InstanceTypePrice
+	0,  // 4: priceserver.v1.SearchInstancesRequest.selector:type_name -> priceserver.v1.PriceSelector
+	9,  // 5: priceserver.v1.SearchInstancesResponse.items:type_name -> priceserver.v1.InstanceSearchItem
+	13, // 6: priceserver.v1.InstanceSearchItem.instance_type_price:type_name -> priceserver.v1.InstanceTypePrice
+	26, // 7: priceserver.v1.WatchPricesResponse.event:type_name -> priceserver.v1.PriceChangeEvent
+	28, // 8: priceserver.v1.RegionalInstancePrice.instance_type_prices:type_name -> priceserver.v1.RegionalInstancePrice.InstanceTypePricesEntry
+	29, // 9: priceserver.v1.InstanceTypePrice.zone_ids:type_name -> priceserver.v1.InstanceTypePrice.ZoneIdsEntry
+	30, // 10: priceserver.v1.InstanceTypePrice.zone_availability:type_name -> priceserver.v1.InstanceTypePrice.ZoneAvailabilityEntry
+	14, // 11: priceserver.v1.InstanceTypePrice.spec:type_name -> priceserver.v1.InstanceTypeSpec
+	31, // 12: priceserver.v1.InstanceTypePrice.aws_ec2_billing:type_name -> priceserver.v1.InstanceTypePrice.AwsEc2BillingEntry
+	32, // 13: priceserver.v1.InstanceTypePrice.aws_ec2_reserved_billing:type_name -> priceserver.v1.InstanceTypePrice.AwsEc2ReservedBillingEntry
+	33, // 14: priceserver.v1.InstanceTypePrice.gcp_committed_use_billing:type_name -> priceserver.v1.InstanceTypePrice.GcpCommittedUseBillingEntry
+	34, // 15: priceserver.v1.InstanceTypePrice.azure_billing:type_name -> priceserver.v1.InstanceTypePrice.AzureBillingEntry
+	35, // 16: priceserver.v1.InstanceTypePrice.alibaba_cloud_billing:type_name -> priceserver.v1.InstanceTypePrice.AlibabaCloudBillingEntry
+	36, // 17: priceserver.v1.InstanceTypePrice.spot_price_per_hour:type_name -> priceserver.v1.InstanceTypePrice.SpotPricePerHourEntry
+	19, // 18: priceserver.v1.InstanceTypePrice.spot_interruption:type_name -> priceserver.v1.SpotInterruption
+	37, // 19: priceserver.v1.InstanceTypePrice.spot_placement_scores:type_name -> priceserver.v1.InstanceTypePrice.SpotPlacementScoresEntry
+	38, // 20: priceserver.v1.InstanceTypePrice.platforms:type_name -> priceserver.v1.InstanceTypePrice.PlatformsEntry
+	39, // 21: priceserver.v1.InstanceTypePrice.site_prices:type_name -> priceserver.v1.InstanceTypePrice.SitePricesEntry
+	15, // 22: priceserver.v1.InstanceTypeSpec.accelerators:type_name -> priceserver.v1.Accelerator
+	40, // 23: priceserver.v1.PlatformPrice.aws_ec2_billing:type_name -> priceserver.v1.PlatformPrice.AwsEc2BillingEntry
+	41, // 24: priceserver.v1.PlatformPrice.aws_ec2_reserved_billing:type_name -> priceserver.v1.PlatformPrice.AwsEc2ReservedBillingEntry
+	42, // 25: priceserver.v1.PlatformPrice.spot_price_per_hour:type_name -> priceserver.v1.PlatformPrice.SpotPricePerHourEntry
+	19, // 26: priceserver.v1.PlatformPrice.spot_interruption:type_name -> priceserver.v1.SpotInterruption
+	43, // 27: priceserver.v1.SpotPlacementScores.zones:type_name -> priceserver.v1.SpotPlacementScores.ZonesEntry
+	44, // 28: priceserver.v1.PriceChangeEvent.time:type_name -> google.protobuf.Timestamp
+	12, // 29: priceserver.v1.ListPricesResponse.RegionsEntry.value:type_name -> priceserver.v1.RegionalInstancePrice
+	13, // 30: priceserver.v1.RegionalInstancePrice.InstanceTypePricesEntry.value:type_name -> priceserver.v1.InstanceTypePrice
+	16, // 31: priceserver.v1.InstanceTypePrice.ZoneAvailabilityEntry.value:type_name -> priceserver.v1.ZoneAvailability
+	21, // 32: priceserver.v1.InstanceTypePrice.AwsEc2BillingEntry.value:type_name -> priceserver.v1.AWSEC2Billing
+	22, // 33: priceserver.v1.InstanceTypePrice.AwsEc2ReservedBillingEntry.value:type_name -> priceserver.v1.AWSEC2ReservedBilling
+	23, // 34: priceserver.v1.InstanceTypePrice.GcpCommittedUseBillingEntry.value:type_name -> priceserver.v1.GCPCommittedUseBilling
+	24, // 35: priceserver.v1.InstanceTypePrice.AzureBillingEntry.value:type_name -> priceserver.v1.AzureBilling
+	25, // 36: priceserver.v1.InstanceTypePrice.AlibabaCloudBillingEntry.value:type_name -> priceserver.v1.AlibabaCloudBilling
+	20, // 37: priceserver.v1.InstanceTypePrice.SpotPlacementScoresEntry.value:type_name -> priceserver.v1.SpotPlacementScores
+	18, // 38: priceserver.v1.InstanceTypePrice.PlatformsEntry.value:type_name -> priceserver.v1.PlatformPrice
+	17, // 39: priceserver.v1.InstanceTypePrice.SitePricesEntry.value:type_name -> priceserver.v1.SitePrice
+	21, // 40: priceserver.v1.PlatformPrice.AwsEc2BillingEntry.value:type_name -> priceserver.v1.AWSEC2Billing
+	22, // 41: priceserver.v1.PlatformPrice.AwsEc2ReservedBillingEntry.value:type_name -> priceserver.v1.AWSEC2ReservedBilling
+	1,  // 42: priceserver.v1.PriceService.ListRegions:input_type -> priceserver.v1.ListRegionsRequest
+	3,  // 43: priceserver.v1.PriceService.ListPrices:input_type -> priceserver.v1.ListPricesRequest
+	5,  // 44: priceserver.v1.PriceService.GetInstancePrice:input_type -> priceserver.v1.GetInstancePriceRequest
+	7,  // 45: priceserver.v1.PriceService.SearchInstances:input_type -> priceserver.v1.SearchInstancesRequest
+	10, // 46: priceserver.v1.PriceService.WatchPrices:input_type -> priceserver.v1.WatchPricesRequest
+	2,  // 47: priceserver.v1.PriceService.ListRegions:output_type -> priceserver.v1.ListRegionsResponse
+	4,  // 48: priceserver.v1.PriceService.ListPrices:output_type -> priceserver.v1.ListPricesResponse
+	6,  // 49: priceserver.v1.PriceService.GetInstancePrice:output_type -> priceserver.v1.GetInstancePriceResponse
+	8,  // 50: priceserver.v1.PriceService.SearchInstances:output_type -> priceserver.v1.SearchInstancesResponse
+	11, // 51: priceserver.v1.PriceService.WatchPrices:output_type -> priceserver.v1.WatchPricesResponse
+	47, // [47:52] is the sub-list for method output_type
+	42, // [42:47] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_priceserver_v1_price_proto_init() }
+func file_priceserver_v1_price_proto_init() {
+	if File_priceserver_v1_price_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_priceserver_v1_price_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriceSelector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRegionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRegionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPricesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPricesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInstancePriceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInstancePriceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchInstancesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchInstancesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstanceSearchItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchPricesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchPricesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegionalInstancePrice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstanceTypePrice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstanceTypeSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Accelerator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZoneAvailability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SitePrice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlatformPrice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpotInterruption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpotPlacementScores); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AWSEC2Billing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AWSEC2ReservedBilling); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCPCommittedUseBilling); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AzureBilling); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlibabaCloudBilling); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_priceserver_v1_price_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriceChangeEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_priceserver_v1_price_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	file_priceserver_v1_price_proto_msgTypes[14].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_priceserver_v1_price_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   44,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_priceserver_v1_price_proto_goTypes,
+		DependencyIndexes: file_priceserver_v1_price_proto_depIdxs,
+		MessageInfos:      file_priceserver_v1_price_proto_msgTypes,
+	}.Build()
+	File_priceserver_v1_price_proto = out.File
+	file_priceserver_v1_price_proto_rawDesc = nil
+	file_priceserver_v1_price_proto_goTypes = nil
+	file_priceserver_v1_price_proto_depIdxs = nil
+}
@@ -0,0 +1,299 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: priceserver/v1/price.proto
+
+package pricepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PriceService_ListRegions_FullMethodName      = "/priceserver.v1.PriceService/ListRegions"
+	PriceService_ListPrices_FullMethodName       = "/priceserver.v1.PriceService/ListPrices"
+	PriceService_GetInstancePrice_FullMethodName = "/priceserver.v1.PriceService/GetInstancePrice"
+	PriceService_SearchInstances_FullMethodName  = "/priceserver.v1.PriceService/SearchInstances"
+	PriceService_WatchPrices_FullMethodName      = "/priceserver.v1.PriceService/WatchPrices"
+)
+
+// PriceServiceClient is the client API for PriceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PriceServiceClient interface {
+	// ListRegions returns the regions which have price data
+	ListRegions(ctx context.Context, in *ListRegionsRequest, opts ...grpc.CallOption) (*ListRegionsResponse, error)
+	// ListPrices returns the price data of the regions
+	ListPrices(ctx context.Context, in *ListPricesRequest, opts ...grpc.CallOption) (*ListPricesResponse, error)
+	// GetInstancePrice returns the price of an instance type, NOT_FOUND is returned if it has no price
+	GetInstancePrice(ctx context.Context, in *GetInstancePriceRequest, opts ...grpc.CallOption) (*GetInstancePriceResponse, error)
+	// SearchInstances returns the instance types matching the filters across the providers
+	SearchInstances(ctx context.Context, in *SearchInstancesRequest, opts ...grpc.CallOption) (*SearchInstancesResponse, error)
+	// WatchPrices streams the price change events of a provider, the kept events after the last event ID are sent
+	// first. The stream ends with UNAVAILABLE if the client falls behind, it should be resumed with the ID of the last
+	// received event.
+	WatchPrices(ctx context.Context, in *WatchPricesRequest, opts ...grpc.CallOption) (PriceService_WatchPricesClient, error)
+}
+
+type priceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPriceServiceClient(cc grpc.ClientConnInterface) PriceServiceClient {
+	return &priceServiceClient{cc}
+}
+
+func (c *priceServiceClient) ListRegions(ctx context.Context, in *ListRegionsRequest, opts ...grpc.CallOption) (*ListRegionsResponse, error) {
+	out := new(ListRegionsResponse)
+	err := c.cc.Invoke(ctx, PriceService_ListRegions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceServiceClient) ListPrices(ctx context.Context, in *ListPricesRequest, opts ...grpc.CallOption) (*ListPricesResponse, error) {
+	out := new(ListPricesResponse)
+	err := c.cc.Invoke(ctx, PriceService_ListPrices_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceServiceClient) GetInstancePrice(ctx context.Context, in *GetInstancePriceRequest, opts ...grpc.CallOption) (*GetInstancePriceResponse, error) {
+	out := new(GetInstancePriceResponse)
+	err := c.cc.Invoke(ctx, PriceService_GetInstancePrice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceServiceClient) SearchInstances(ctx context.Context, in *SearchInstancesRequest, opts ...grpc.CallOption) (*SearchInstancesResponse, error) {
+	out := new(SearchInstancesResponse)
+	err := c.cc.Invoke(ctx, PriceService_SearchInstances_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceServiceClient) WatchPrices(ctx context.Context, in *WatchPricesRequest, opts ...grpc.CallOption) (PriceService_WatchPricesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PriceService_ServiceDesc.Streams[0], PriceService_WatchPrices_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &priceServiceWatchPricesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PriceService_WatchPricesClient interface {
+	Recv() (*WatchPricesResponse, error)
+	grpc.ClientStream
+}
+
+type priceServiceWatchPricesClient struct {
+	grpc.ClientStream
+}
+
+func (x *priceServiceWatchPricesClient) Recv() (*WatchPricesResponse, error) {
+	m := new(WatchPricesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PriceServiceServer is the server API for PriceService service.
+// All implementations must embed UnimplementedPriceServiceServer
+// for forward compatibility
+type PriceServiceServer interface {
+	// ListRegions returns the regions which have price data
+	ListRegions(context.Context, *ListRegionsRequest) (*ListRegionsResponse, error)
+	// ListPrices returns the price data of the regions
+	ListPrices(context.Context, *ListPricesRequest) (*ListPricesResponse, error)
+	// GetInstancePrice returns the price of an instance type, NOT_FOUND is returned if it has no price
+	GetInstancePrice(context.Context, *GetInstancePriceRequest) (*GetInstancePriceResponse, error)
+	// SearchInstances returns the instance types matching the filters across the providers
+	SearchInstances(context.Context, *SearchInstancesRequest) (*SearchInstancesResponse, error)
+	// WatchPrices streams the price change events of a provider, the kept events after the last event ID are sent
+	// first. The stream ends with UNAVAILABLE if the client falls behind, it should be resumed with the ID of the last
+	// received event.
+	WatchPrices(*WatchPricesRequest, PriceService_WatchPricesServer) error
+	mustEmbedUnimplementedPriceServiceServer()
+}
+
+// UnimplementedPriceServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPriceServiceServer struct {
+}
+
+func (UnimplementedPriceServiceServer) ListRegions(context.Context, *ListRegionsRequest) (*ListRegionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRegions not implemented")
+}
+func (UnimplementedPriceServiceServer) ListPrices(context.Context, *ListPricesRequest) (*ListPricesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPrices not implemented")
+}
+func (UnimplementedPriceServiceServer) GetInstancePrice(context.Context, *GetInstancePriceRequest) (*GetInstancePriceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstancePrice not implemented")
+}
+func (UnimplementedPriceServiceServer) SearchInstances(context.Context, *SearchInstancesRequest) (*SearchInstancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchInstances not implemented")
+}
+func (UnimplementedPriceServiceServer) WatchPrices(*WatchPricesRequest, PriceService_WatchPricesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPrices not implemented")
+}
+func (UnimplementedPriceServiceServer) mustEmbedUnimplementedPriceServiceServer() {}
+
+// UnsafePriceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PriceServiceServer will
+// result in compilation errors.
+type UnsafePriceServiceServer interface {
+	mustEmbedUnimplementedPriceServiceServer()
+}
+
+func RegisterPriceServiceServer(s grpc.ServiceRegistrar, srv PriceServiceServer) {
+	s.RegisterService(&PriceService_ServiceDesc, srv)
+}
+
+func _PriceService_ListRegions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRegionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServiceServer).ListRegions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceService_ListRegions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServiceServer).ListRegions(ctx, req.(*ListRegionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceService_ListPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServiceServer).ListPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceService_ListPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServiceServer).ListPrices(ctx, req.(*ListPricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceService_GetInstancePrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstancePriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServiceServer).GetInstancePrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceService_GetInstancePrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServiceServer).GetInstancePrice(ctx, req.(*GetInstancePriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceService_SearchInstances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchInstancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServiceServer).SearchInstances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceService_SearchInstances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServiceServer).SearchInstances(ctx, req.(*SearchInstancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceService_WatchPrices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPricesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PriceServiceServer).WatchPrices(m, &priceServiceWatchPricesServer{stream})
+}
+
+type PriceService_WatchPricesServer interface {
+	Send(*WatchPricesResponse) error
+	grpc.ServerStream
+}
+
+type priceServiceWatchPricesServer struct {
+	grpc.ServerStream
+}
+
+func (x *priceServiceWatchPricesServer) Send(m *WatchPricesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PriceService_ServiceDesc is the grpc.ServiceDesc for PriceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PriceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "priceserver.v1.PriceService",
+	HandlerType: (*PriceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRegions",
+			Handler:    _PriceService_ListRegions_Handler,
+		},
+		{
+			MethodName: "ListPrices",
+			Handler:    _PriceService_ListPrices_Handler,
+		},
+		{
+			MethodName: "GetInstancePrice",
+			Handler:    _PriceService_GetInstancePrice_Handler,
+		},
+		{
+			MethodName: "SearchInstances",
+			Handler:    _PriceService_SearchInstances_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPrices",
+			Handler:       _PriceService_WatchPrices_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "priceserver/v1/price.proto",
+}
@@ -1,16 +1,45 @@
 package apis
 
+import "time"
+
 type RegionTypeKey struct {
 	Region       string
 	InstanceType string
 }
 
+// ProviderStatus represents the status of a price provider
+type ProviderStatus struct {
+	Name    string `json:"name"`
+	Service string `json:"service"`
+	// ReadOnly means the provider serves a snapshot which is never refreshed
+	ReadOnly bool `json:"readOnly"`
+	// SnapshotTime is the time when the snapshot was taken, it's only set for read-only providers
+	SnapshotTime *time.Time `json:"snapshotTime,omitempty"`
+	Healthy      bool       `json:"healthy"`
+	Message      string     `json:"message,omitempty"`
+}
+
 type RegionalInstancePrice struct {
 	InstanceTypePrices map[string]*InstanceTypePrice `json:"instanceTypePrices"`
 	// TODO: delete this field when all the customer upgrades the components
 	InstanceTypeEC2Price map[string]*InstanceTypePrice `json:"instanceTypeEC2Price"`
 }
 
+// PriceDelta is the changes of the price data of a provider since a version
+type PriceDelta struct {
+	// Version is the version of the price data after the changes are applied
+	Version uint64 `json:"version"`
+	// Reset means the changes since the version are not available, Regions has all the price data which should
+	// replace the data of the client
+	Reset bool `json:"reset,omitempty"`
+	// Regions has the added or changed prices of each region
+	Regions map[string]*RegionalInstancePrice `json:"regions,omitempty"`
+	// RemovedRegions are the regions which have no price data any more
+	RemovedRegions []string `json:"removedRegions,omitempty"`
+	// RemovedInstanceTypes are the instance types removed from each region
+	RemovedInstanceTypes map[string][]string `json:"removedInstanceTypes,omitempty"`
+}
+
 type InstanceTypePrice struct {
 	Arch                 string   `json:"arch"`
 	VCPU                 float64  `json:"vcpu"`
@@ -18,17 +47,423 @@ type InstanceTypePrice struct {
 	GPU                  float64  `json:"gpu"`
 	Zones                []string `json:"zones"`
 	OnDemandPricePerHour float64  `json:"onDemandPricePerHour"`
+	// ZoneIDs maps the zone names to the zone ids, e.g. us-east-1a to use1-az1, the zone names are mapped to
+	// different physical zones in different accounts while the ids are the same
+	ZoneIDs map[string]string `json:"zoneIDs,omitempty"`
+	// ZoneAvailability is the availability of the instance type by zone, Zones only has the available ones
+	ZoneAvailability map[string]ZoneAvailability `json:"zoneAvailability,omitempty"`
+	// Spec is the detailed specification of the instance type
+	Spec *InstanceTypeSpec `json:"spec,omitempty"`
+	// Currency is the currency of all the prices, e.g. USD or CNY
+	Currency string `json:"currency,omitempty"`
 	// AWSEC2Billing represents the cost of saving plan billing
 	// key is {savings plan type}/{term length}/{payment option}
 	AWSEC2Billing map[string]AWSEC2Billing `json:"awsEC2Billing,omitempty"`
+	// AWSEC2ReservedBilling represents the cost of reserved instances
+	// key is {offering class}/{term length}/{payment option}
+	AWSEC2ReservedBilling map[string]AWSEC2ReservedBilling `json:"awsEC2ReservedBilling,omitempty"`
+	// GCPCommittedUseBilling represents the cost of committed use discounts
+	// key is {term length}
+	GCPCommittedUseBilling map[string]GCPCommittedUseBilling `json:"gcpCommittedUseBilling,omitempty"`
+	// AzureBilling represents the cost of reservation and savings plan billing
+	// key is {billing type}/{term length}
+	AzureBilling map[string]AzureBilling `json:"azureBilling,omitempty"`
+	// AlibabaCloudBilling represents the cost of subscription and savings plan billing
+	// key is subscription/{term length} or savingsplan/{savings plan type}/{term length}/{payment option}
+	AlibabaCloudBilling map[string]AlibabaCloudBilling `json:"alibabaCloudBilling,omitempty"`
 	// SpotPricePerHour represents the smallest spot price per hour in different zones
 	SpotPricePerHour map[string]float64 `json:"spotPricePerHour,omitempty"`
+	// SpotInterruption is the frequency of the spot interruptions in the region
+	SpotInterruption *SpotInterruption `json:"spotInterruption,omitempty"`
+	// SpotPlacementScores are the spot placement scores from 1 to 10 by target capacity and then zone, the higher
+	// the more likely a spot request of the capacity succeeds
+	SpotPlacementScores map[string]map[string]int `json:"spotPlacementScores,omitempty"`
+	// Platforms are the prices of the other operating systems and tenancies, the prices above are of linux
+	// with shared tenancy, key is {os}/{tenancy}
+	Platforms map[string]*PlatformPrice `json:"platforms,omitempty"`
+	// SitePrices are the list prices of the other sites, the prices above are of the China site for Alibaba Cloud
+	// and the only site for the others, key is the site
+	SitePrices map[string]*SitePrice `json:"sitePrices,omitempty"`
+}
+
+// InstanceTypeSpec is the detailed specification of an instance type from the instance type APIs of the provider,
+// the fields the provider doesn't report are left empty
+type InstanceTypeSpec struct {
+	// NetworkPerformance is the network performance reported by the provider, e.g. "Up to 12.5 Gigabit"
+	NetworkPerformance   string  `json:"networkPerformance,omitempty"`
+	NetworkBandwidthGbps float64 `json:"networkBandwidthGbps,omitempty"`
+	MaxNetworkInterfaces int     `json:"maxNetworkInterfaces,omitempty"`
+	// IPv4AddressesPerInterface is the max number of private IPv4 addresses of each network interface
+	IPv4AddressesPerInterface int `json:"ipv4AddressesPerInterface,omitempty"`
+	// LocalStorage is the total size of the local disks in GB as reported by the provider
+	LocalStorage float64 `json:"localStorage,omitempty"`
+	// LocalStorageType is ssd or hdd
+	LocalStorageType string `json:"localStorageType,omitempty"`
+	LocalStorageNVMe bool   `json:"localStorageNVMe,omitempty"`
+	// DiskBandwidthMbps and DiskMaxBandwidthMbps are the baseline and the burst bandwidth of the cloud disks, e.g. EBS
+	DiskBandwidthMbps    float64 `json:"diskBandwidthMbps,omitempty"`
+	DiskMaxBandwidthMbps float64 `json:"diskMaxBandwidthMbps,omitempty"`
+	Burstable            bool    `json:"burstable,omitempty"`
+	// Hypervisor is nitro or xen for AWS, it's empty for bare metal instances
+	Hypervisor        string `json:"hypervisor,omitempty"`
+	CurrentGeneration *bool  `json:"currentGeneration,omitempty"`
+	GPUModel          string `json:"gpuModel,omitempty"`
+	GPUManufacturer   string `json:"gpuManufacturer,omitempty"`
+	// GPUMemory is the total memory of all the GPUs in GiB
+	GPUMemory    float64       `json:"gpuMemory,omitempty"`
+	Accelerators []Accelerator `json:"accelerators,omitempty"`
+}
+
+// Accelerator is an accelerator other than GPU attached to an instance type
+type Accelerator struct {
+	// Type is one of AcceleratorInferentia, AcceleratorTrainium and AcceleratorFPGA
+	Type         string `json:"type"`
+	Name         string `json:"name"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	Count        int    `json:"count"`
+	// Memory is the memory of each accelerator in GiB
+	Memory float64 `json:"memory,omitempty"`
+}
+
+const (
+	AcceleratorInferentia = "inferentia"
+	AcceleratorTrainium   = "trainium"
+	AcceleratorFPGA       = "fpga"
+)
+
+// DeepCopy returns a copy of the spec
+func (s *InstanceTypeSpec) DeepCopy() *InstanceTypeSpec {
+	if s == nil {
+		return nil
+	}
+	d := *s
+	if s.CurrentGeneration != nil {
+		d.CurrentGeneration = new(bool)
+		*d.CurrentGeneration = *s.CurrentGeneration
+	}
+	if s.Accelerators != nil {
+		d.Accelerators = make([]Accelerator, len(s.Accelerators))
+		copy(d.Accelerators, s.Accelerators)
+	}
+	return &d
+}
+
+// ZoneAvailability is the availability of an instance type in a zone
+type ZoneAvailability struct {
+	// Available is whether the instance type can be created in the zone
+	Available bool `json:"available"`
+	// StockStatus is the stock level reported by the provider, e.g. WithStock or ClosedWithStock of Alibaba Cloud
+	StockStatus string `json:"stockStatus,omitempty"`
+}
+
+// SitePrice is the price of an instance type on a site of the provider, e.g. the international site
+type SitePrice struct {
+	OnDemandPricePerHour float64 `json:"onDemandPricePerHour"`
+	Currency             string  `json:"currency"`
+}
+
+const (
+	SiteCN   = "cn"
+	SiteIntl = "intl"
+)
+
+// PlatformPrice is the price of an instance type running an operating system with a tenancy
+type PlatformPrice struct {
+	OnDemandPricePerHour  float64                          `json:"onDemandPricePerHour"`
+	AWSEC2Billing         map[string]AWSEC2Billing         `json:"awsEC2Billing,omitempty"`
+	AWSEC2ReservedBilling map[string]AWSEC2ReservedBilling `json:"awsEC2ReservedBilling,omitempty"`
+	SpotPricePerHour      map[string]float64               `json:"spotPricePerHour,omitempty"`
+	SpotInterruption      *SpotInterruption                `json:"spotInterruption,omitempty"`
+}
+
+// SpotInterruption is the frequency of the spot interruptions in the last month from the AWS Spot Instance Advisor
+type SpotInterruption struct {
+	// Bucket is the index of the frequency range from 0 to 4, the higher the more frequent
+	Bucket int `json:"bucket"`
+	// Range is the frequency range of the bucket, i.e. <5%, 5-10%, 10-15%, 15-20% or >20%
+	Range string `json:"range"`
+	// Savings is the percentage saved over the on-demand price
+	Savings int `json:"savings,omitempty"`
+}
+
+const (
+	OSLinux   = "linux"
+	OSWindows = "windows"
+	OSRHEL    = "rhel"
+	OSSUSE    = "suse"
+
+	TenancyShared    = "shared"
+	TenancyDedicated = "dedicated"
+)
+
+// PlatformKey returns the key of the platform in InstanceTypePrice.Platforms
+func PlatformKey(os, tenancy string) string {
+	return os + "/" + tenancy
+}
+
+// IsDefaultPlatform returns true if the platform is linux with shared tenancy, empty means the default one
+func IsDefaultPlatform(os, tenancy string) bool {
+	return (os == "" || os == OSLinux) && (tenancy == "" || tenancy == TenancyShared)
+}
+
+// SpotPriceHistory represents the spot price history of an instance type in [From, To]
+type SpotPriceHistory struct {
+	Region       string    `json:"region"`
+	InstanceType string    `json:"instanceType"`
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	// Step is the interval of resampled points, the raw points are returned if it's empty
+	Step     string `json:"step,omitempty"`
+	Currency string `json:"currency"`
+	// Zones is the history of each zone, key is the zone name
+	Zones map[string]*ZoneSpotPriceHistory `json:"zones"`
+}
+
+type ZoneSpotPriceHistory struct {
+	// Points are sorted by time, the first point may be earlier than From and represents the price in effect at From
+	Points []SpotPricePoint `json:"points"`
+	Stats  *SpotPriceStats  `json:"stats,omitempty"`
+}
+
+type SpotPricePoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Price     float64   `json:"price"`
+}
+
+// SpotPriceStats is weighted by the duration of each price in [From, To]
+type SpotPriceStats struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+	Avg float64 `json:"avg"`
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+}
+
+const (
+	CapacityTypeOnDemand = "on-demand"
+	CapacityTypeSpot     = "spot"
+)
+
+// PriceChangeEvent is a change of a price of an instance type, only the prices of the default platform are watched
+type PriceChangeEvent struct {
+	// ID is increasing in the events of a provider, the stream is resumed after it
+	ID           string `json:"id"`
+	Provider     string `json:"provider"`
+	Region       string `json:"region"`
+	InstanceType string `json:"instanceType"`
+	// Zone is only set for the spot prices
+	Zone string `json:"zone,omitempty"`
+	// CapacityType is on-demand, spot or a billing key
+	CapacityType string `json:"capacityType"`
+	// OldPrice and NewPrice are the prices per hour, zero means the price is added or removed
+	OldPrice float64   `json:"oldPrice"`
+	NewPrice float64   `json:"newPrice"`
+	Currency string    `json:"currency,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// PriceEventFilter selects the price change events, empty fields match all
+type PriceEventFilter struct {
+	Regions       []string
+	InstanceTypes []string
+	CapacityTypes []string
+}
+
+func (f *PriceEventFilter) Match(e *PriceChangeEvent) bool {
+	return matchAny(f.Regions, e.Region) && matchAny(f.InstanceTypes, e.InstanceType) &&
+		matchAny(f.CapacityTypes, e.CapacityType)
+}
+
+func matchAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// PriceStreamMessagePrice carries a price change event
+	PriceStreamMessagePrice = "price"
+	// PriceStreamMessageReset means some events after the last event ID are not kept, the price data should be synced
+	// again, e.g. with the price delta
+	PriceStreamMessageReset = "reset"
+)
+
+// PriceStreamMessage is a message of the price change stream over WebSocket, the type is the event name over SSE
+type PriceStreamMessage struct {
+	Type  string            `json:"type"`
+	Event *PriceChangeEvent `json:"event,omitempty"`
+}
+
+// InstanceSearchResult is a page of the instance types matching the search
+type InstanceSearchResult struct {
+	// Total is the number of matched instance types before pagination
+	Total int `json:"total"`
+	// Currency is the currency of all the prices
+	Currency string                `json:"currency"`
+	Items    []*InstanceSearchItem `json:"items"`
+}
+
+type InstanceSearchItem struct {
+	Provider     string `json:"provider"`
+	Region       string `json:"region"`
+	InstanceType string `json:"instanceType"`
+	// Price is the price per hour of the searched capacity type
+	Price        float64 `json:"price"`
+	PricePerVCPU float64 `json:"pricePerVCPU"`
+	PricePerGiB  float64 `json:"pricePerGiB"`
+	*InstanceTypePrice
+}
+
+// RecommendationRequest describes the resources to run, Pods is used for bin-packing if it's set,
+// otherwise VCPU, Memory and GPU are the minimum specs of a single instance.
+type RecommendationRequest struct {
+	// Providers limits the providers for the cross-provider recommendation, empty means all
+	Providers []string `json:"providers,omitempty"`
+	// Regions limits the regions, empty means all
+	Regions []string `json:"regions,omitempty"`
+	VCPU    float64  `json:"vcpu,omitempty"`
+	Memory  float64  `json:"memory,omitempty"`
+	GPU     float64  `json:"gpu,omitempty"`
+	Arch    string   `json:"arch,omitempty"`
+	// Families are the allowed instance families, e.g. m5 or ecs.g6
+	Families []string `json:"families,omitempty"`
+	// Zones are the allowed zones, the instance types must be available in one of them
+	Zones []string `json:"zones,omitempty"`
+	// CapacityTypes are on-demand, spot or billing keys, default to on-demand
+	CapacityTypes []string         `json:"capacityTypes,omitempty"`
+	Pods          *PodsRequirement `json:"pods,omitempty"`
+	// Limit is the max number of returned recommendations
+	Limit int `json:"limit,omitempty"`
+	// Currency is the currency of the returned prices, default to USD
+	Currency string `json:"currency,omitempty"`
+	// OS and Tenancy select the prices of the platform, default to linux with shared tenancy
+	OS      string `json:"os,omitempty"`
+	Tenancy string `json:"tenancy,omitempty"`
+	// Site selects the prices of the site, cn or intl, default to cn
+	Site string `json:"site,omitempty"`
+}
+
+// PodsRequirement means Count pods requesting VCPU, Memory and GPU each
+type PodsRequirement struct {
+	Count  int     `json:"count"`
+	VCPU   float64 `json:"vcpu"`
+	Memory float64 `json:"memory"`
+	GPU    float64 `json:"gpu,omitempty"`
+}
+
+type RecommendationResult struct {
+	// Instances are the single instance type options sorted by the total price
+	Instances []*InstanceRecommendation `json:"instances"`
+	// Mixes are the cheapest mixes of instance types of each region and capacity type for the pods,
+	// sorted by the total price
+	Mixes []*InstanceMixRecommendation `json:"mixes,omitempty"`
+}
+
+type InstanceRecommendation struct {
+	Provider     string  `json:"provider"`
+	Region       string  `json:"region"`
+	InstanceType string  `json:"instanceType"`
+	CapacityType string  `json:"capacityType"`
+	Arch         string  `json:"arch"`
+	VCPU         float64 `json:"vcpu"`
+	Memory       float64 `json:"memory"`
+	GPU          float64 `json:"gpu"`
+	// Zone is the cheapest allowed zone of the spot price
+	Zone         string  `json:"zone,omitempty"`
+	PricePerHour float64 `json:"pricePerHour"`
+	Currency     string  `json:"currency"`
+	// PodsPerInstance is the number of pods fit in an instance, it's only set for the pods requirement
+	PodsPerInstance int `json:"podsPerInstance,omitempty"`
+	// Count is the number of instances required
+	Count             int     `json:"count"`
+	TotalPricePerHour float64 `json:"totalPricePerHour"`
+}
+
+type InstanceMixRecommendation struct {
+	Provider          string                    `json:"provider"`
+	Region            string                    `json:"region"`
+	CapacityType      string                    `json:"capacityType"`
+	TotalPricePerHour float64                   `json:"totalPricePerHour"`
+	Currency          string                    `json:"currency"`
+	Instances         []*InstanceRecommendation `json:"instances"`
+}
+
+// PriceComparison has the cheapest regions of each capacity type for an instance shape
+type PriceComparison struct {
+	// Currency is the currency of the normalized prices
+	Currency string `json:"currency"`
+	// CapacityTypes are the comparison items sorted by the normalized price, key is the capacity type
+	CapacityTypes map[string][]*PriceComparisonItem `json:"capacityTypes"`
+}
+
+// PriceComparisonItem is the cheapest matched instance type of a region, or a zone for spot
+type PriceComparisonItem struct {
+	Provider     string  `json:"provider"`
+	Region       string  `json:"region"`
+	Zone         string  `json:"zone,omitempty"`
+	InstanceType string  `json:"instanceType"`
+	Arch         string  `json:"arch"`
+	VCPU         float64 `json:"vcpu"`
+	Memory       float64 `json:"memory"`
+	GPU          float64 `json:"gpu"`
+	// Price is the price per hour in the currency of the comparison
+	Price            float64 `json:"price"`
+	OriginalPrice    float64 `json:"originalPrice"`
+	OriginalCurrency string  `json:"originalCurrency"`
 }
 
 type AWSEC2Billing struct {
 	Rate float64 `json:"rate"`
 }
 
+type AWSEC2ReservedBilling struct {
+	// Rate is the effective hourly cost, which is the hourly rate plus the upfront fee amortized over the term
+	Rate       float64 `json:"rate"`
+	UpfrontFee float64 `json:"upfrontFee"`
+	HourlyRate float64 `json:"hourlyRate"`
+}
+
+const (
+	AWSEC2ReservedOfferingClassStandard    = "standard"
+	AWSEC2ReservedOfferingClassConvertible = "convertible"
+)
+
+type GCPCommittedUseBilling struct {
+	Rate float64 `json:"rate"`
+}
+
+type AzureBilling struct {
+	Rate float64 `json:"rate"`
+}
+
+const (
+	AzureBillingReservation = "reservation"
+	AzureBillingSavingsPlan = "savingsplan"
+)
+
+type AlibabaCloudBilling struct {
+	// Rate is the effective hourly cost, the subscription price is amortized over the term
+	Rate float64 `json:"rate"`
+	// UpfrontFee is the price of the whole term of a subscription
+	UpfrontFee float64 `json:"upfrontFee,omitempty"`
+}
+
+const (
+	AlibabaCloudBillingSubscription = "subscription"
+	AlibabaCloudBillingSavingsPlan  = "savingsplan"
+
+	AlibabaCloudSavingsPlanUniversal = "universal"
+	AlibabaCloudSavingsPlanECS       = "ecs"
+)
+
 type AWSEC2SPPaymentOption string
 
 const (
@@ -48,6 +483,86 @@ func (r *RegionalInstancePrice) DeepCopy() *RegionalInstancePrice {
 	return d
 }
 
+// ForPlatform returns the price with the prices of the platform in place of the default ones, false is returned if
+// the instance type has no price of the platform. The returned price shares the maps with i, so it must be copied with
+// DeepCopy before it's modified.
+func (i *InstanceTypePrice) ForPlatform(os, tenancy string) (*InstanceTypePrice, bool) {
+	d := *i
+	d.Platforms = nil
+	if IsDefaultPlatform(os, tenancy) {
+		return &d, true
+	}
+
+	p, ok := i.Platforms[PlatformKey(os, tenancy)]
+	if !ok {
+		return nil, false
+	}
+	d.OnDemandPricePerHour = p.OnDemandPricePerHour
+	d.AWSEC2Billing = p.AWSEC2Billing
+	d.AWSEC2ReservedBilling = p.AWSEC2ReservedBilling
+	d.SpotPricePerHour = p.SpotPricePerHour
+	d.SpotInterruption = p.SpotInterruption
+	d.GCPCommittedUseBilling = nil
+	d.AzureBilling = nil
+	d.AlibabaCloudBilling = nil
+	return &d, true
+}
+
+// ForSite returns the price with the on-demand price of the site, the other prices are removed since they are only
+// available on the default site, false is returned if the instance type has no price of the site. Empty site and cn
+// are the default site. The returned price shares the maps with i like ForPlatform.
+func (i *InstanceTypePrice) ForSite(site string) (*InstanceTypePrice, bool) {
+	if site == "" || site == SiteCN {
+		d := *i
+		d.SitePrices = nil
+		return &d, true
+	}
+
+	p, ok := i.SitePrices[site]
+	if !ok {
+		return nil, false
+	}
+	return &InstanceTypePrice{
+		Arch:                 i.Arch,
+		VCPU:                 i.VCPU,
+		Memory:               i.Memory,
+		GPU:                  i.GPU,
+		Zones:                i.Zones,
+		ZoneIDs:              i.ZoneIDs,
+		ZoneAvailability:     i.ZoneAvailability,
+		Spec:                 i.Spec,
+		OnDemandPricePerHour: p.OnDemandPricePerHour,
+		Currency:             p.Currency,
+	}, true
+}
+
+func (p *PlatformPrice) DeepCopy() *PlatformPrice {
+	d := &PlatformPrice{OnDemandPricePerHour: p.OnDemandPricePerHour}
+	if p.AWSEC2Billing != nil {
+		d.AWSEC2Billing = make(map[string]AWSEC2Billing, len(p.AWSEC2Billing))
+		for k, v := range p.AWSEC2Billing {
+			d.AWSEC2Billing[k] = v
+		}
+	}
+	if p.AWSEC2ReservedBilling != nil {
+		d.AWSEC2ReservedBilling = make(map[string]AWSEC2ReservedBilling, len(p.AWSEC2ReservedBilling))
+		for k, v := range p.AWSEC2ReservedBilling {
+			d.AWSEC2ReservedBilling[k] = v
+		}
+	}
+	if p.SpotPricePerHour != nil {
+		d.SpotPricePerHour = make(map[string]float64, len(p.SpotPricePerHour))
+		for k, v := range p.SpotPricePerHour {
+			d.SpotPricePerHour[k] = v
+		}
+	}
+	if p.SpotInterruption != nil {
+		interruption := *p.SpotInterruption
+		d.SpotInterruption = &interruption
+	}
+	return d
+}
+
 func (i *InstanceTypePrice) DeepCopy() *InstanceTypePrice {
 	d := &InstanceTypePrice{
 		Arch:                 i.Arch,
@@ -56,15 +571,104 @@ func (i *InstanceTypePrice) DeepCopy() *InstanceTypePrice {
 		GPU:                  i.GPU,
 		Zones:                make([]string, len(i.Zones)),
 		OnDemandPricePerHour: i.OnDemandPricePerHour,
+		Currency:             i.Currency,
 		AWSEC2Billing:        make(map[string]AWSEC2Billing),
 		SpotPricePerHour:     make(map[string]float64),
 	}
 	copy(d.Zones, i.Zones)
+	d.Spec = i.Spec.DeepCopy()
+	if i.ZoneIDs != nil {
+		d.ZoneIDs = make(map[string]string, len(i.ZoneIDs))
+		for k, v := range i.ZoneIDs {
+			d.ZoneIDs[k] = v
+		}
+	}
+	if i.ZoneAvailability != nil {
+		d.ZoneAvailability = make(map[string]ZoneAvailability, len(i.ZoneAvailability))
+		for k, v := range i.ZoneAvailability {
+			d.ZoneAvailability[k] = v
+		}
+	}
 	for k, v := range i.AWSEC2Billing {
 		d.AWSEC2Billing[k] = v
 	}
+	if i.AWSEC2ReservedBilling != nil {
+		d.AWSEC2ReservedBilling = make(map[string]AWSEC2ReservedBilling, len(i.AWSEC2ReservedBilling))
+		for k, v := range i.AWSEC2ReservedBilling {
+			d.AWSEC2ReservedBilling[k] = v
+		}
+	}
+	if i.GCPCommittedUseBilling != nil {
+		d.GCPCommittedUseBilling = make(map[string]GCPCommittedUseBilling, len(i.GCPCommittedUseBilling))
+		for k, v := range i.GCPCommittedUseBilling {
+			d.GCPCommittedUseBilling[k] = v
+		}
+	}
+	if i.AzureBilling != nil {
+		d.AzureBilling = make(map[string]AzureBilling, len(i.AzureBilling))
+		for k, v := range i.AzureBilling {
+			d.AzureBilling[k] = v
+		}
+	}
+	if i.AlibabaCloudBilling != nil {
+		d.AlibabaCloudBilling = make(map[string]AlibabaCloudBilling, len(i.AlibabaCloudBilling))
+		for k, v := range i.AlibabaCloudBilling {
+			d.AlibabaCloudBilling[k] = v
+		}
+	}
 	for k, v := range i.SpotPricePerHour {
 		d.SpotPricePerHour[k] = v
 	}
+	if i.SpotInterruption != nil {
+		interruption := *i.SpotInterruption
+		d.SpotInterruption = &interruption
+	}
+	if i.SpotPlacementScores != nil {
+		d.SpotPlacementScores = make(map[string]map[string]int, len(i.SpotPlacementScores))
+		for capacity, scores := range i.SpotPlacementScores {
+			d.SpotPlacementScores[capacity] = make(map[string]int, len(scores))
+			for k, v := range scores {
+				d.SpotPlacementScores[capacity][k] = v
+			}
+		}
+	}
+	if i.Platforms != nil {
+		d.Platforms = make(map[string]*PlatformPrice, len(i.Platforms))
+		for k, v := range i.Platforms {
+			d.Platforms[k] = v.DeepCopy()
+		}
+	}
+	if i.SitePrices != nil {
+		d.SitePrices = make(map[string]*SitePrice, len(i.SitePrices))
+		for k, v := range i.SitePrices {
+			c := *v
+			d.SitePrices[k] = &c
+		}
+	}
 	return d
 }
+
+// PriceCrossCheckReport compares the on-demand prices of a source with the ones of a reference source
+type PriceCrossCheckReport struct {
+	Time      time.Time `json:"time"`
+	Source    string    `json:"source"`
+	Reference string    `json:"reference"`
+	// ReferenceError is set if the prices of the reference source are unavailable
+	ReferenceError string `json:"referenceError,omitempty"`
+	// Tolerance is the max relative difference of the prices regarded as the same
+	Tolerance float64 `json:"tolerance"`
+	// Checked is the number of instance types priced by both sources
+	Checked int `json:"checked"`
+	// Fallbacks is the number of instance types priced by the reference source since the source has no price
+	Fallbacks  int                    `json:"fallbacks"`
+	Mismatches []*PriceCrossCheckItem `json:"mismatches"`
+}
+
+type PriceCrossCheckItem struct {
+	Region         string  `json:"region"`
+	InstanceType   string  `json:"instanceType"`
+	SourcePrice    float64 `json:"sourcePrice"`
+	ReferencePrice float64 `json:"referencePrice"`
+	// Difference is the relative difference to the reference price
+	Difference float64 `json:"difference"`
+}
@@ -1,13 +1,54 @@
 package apis
 
 const (
-	AWSPriceClientContextKey     = "aws"
-	AlibabaCloudClientContextKey = "alibabacloud"
+	AWSCloudProvider     = "aws"
+	AlibabaCloudProvider = "alibabacloud"
+	GCPCloudProvider     = "gcp"
+	AzureCloudProvider   = "azure"
+	TencentCloudProvider = "tencentcloud"
+	HuaweiCloudProvider  = "huaweicloud"
+)
+
+const (
+	PriceProviderContextKey         = "priceProvider"
+	PriceProviderRegistryContextKey = "priceProviderRegistry"
+	ExchangeRateSourceContextKey    = "exchangeRateSource"
+
+	// ReadOnlyHeader is set to true if the price data is served from a snapshot which is never refreshed
+	ReadOnlyHeader = "X-Price-Read-Only"
+	// SnapshotTimeHeader is the time when the snapshot of a read-only provider was taken
+	SnapshotTimeHeader = "X-Price-Snapshot-Time"
 
 	AWSGlobalAKEnv = "AWS_GLOBAL_ACCESS_KEY"
 	AWSGlobalSKEnv = "AWS_GLOBAL_SECRET_KEY"
 	AWSCNAKEnv     = "AWS_CN_ACCESS_KEY"
 	AWSCNSKEnv     = "AWS_CN_SECRET_KEY"
+	// AWSSpotAdvisorEndpointEnv is the URL of the Spot Instance Advisor dataset
+	AWSSpotAdvisorEndpointEnv = "AWS_SPOT_ADVISOR_ENDPOINT"
+	// AWSSpotPlacementScoreTargetsEnv are the instance types and the target capacities to get the spot placement
+	// scores of, e.g. m5.large:10,c5.xlarge:100
+	AWSSpotPlacementScoreTargetsEnv = "AWS_SPOT_PLACEMENT_SCORE_TARGETS"
 
 	AlibabaCloudAKSKPoolEnv = "ALIBABACLOUD_AKSK_POOL"
+	AlibabaCloudEndpointEnv = "ALIBABACLOUD_ENDPOINT"
+	// AlibabaCloudPriceSourceEnv is the source of the on-demand prices, scraper or api
+	AlibabaCloudPriceSourceEnv = "ALIBABACLOUD_PRICE_SOURCE"
+
+	GCPProjectIDEnv       = "GCP_PROJECT_ID"
+	GCPCredentialsFileEnv = "GCP_CREDENTIALS_FILE"
+	GCPCatalogEndpointEnv = "GCP_CATALOG_ENDPOINT"
+	GCPComputeEndpointEnv = "GCP_COMPUTE_ENDPOINT"
+
+	AzureTenantIDEnv             = "AZURE_TENANT_ID"
+	AzureClientIDEnv             = "AZURE_CLIENT_ID"
+	AzureClientSecretEnv         = "AZURE_CLIENT_SECRET"
+	AzureSubscriptionIDEnv       = "AZURE_SUBSCRIPTION_ID"
+	AzureRetailPricesEndpointEnv = "AZURE_RETAIL_PRICES_ENDPOINT"
+	AzureManagementEndpointEnv   = "AZURE_MANAGEMENT_ENDPOINT"
+
+	TencentCloudAKSKPoolEnv = "TENCENTCLOUD_AKSK_POOL"
+	TencentCloudEndpointEnv = "TENCENTCLOUD_ENDPOINT"
+
+	HuaweiCloudAKSKPoolEnv = "HUAWEICLOUD_AKSK_POOL"
+	HuaweiCloudEndpointEnv = "HUAWEICLOUD_ENDPOINT"
 )
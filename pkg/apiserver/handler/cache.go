@@ -0,0 +1,318 @@
+package handler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/singleflight"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+)
+
+// maxCachedResponses limits the responses cached for one version of the price data, the others are not cached
+const maxCachedResponses = 4096
+
+// ResponseCache caches the encoded and gzipped responses of a provider for the current version of its price data
+type ResponseCache struct {
+	mutex     sync.Mutex
+	version   uint64
+	responses map[string]*encodedResponse
+
+	// group makes the concurrent requests of an uncached response wait for the first one
+	group singleflight.Group
+}
+
+func NewResponseCache() *ResponseCache {
+	return &ResponseCache{
+		responses: map[string]*encodedResponse{},
+	}
+}
+
+func (c *ResponseCache) get(version uint64, key string) (*encodedResponse, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.version != version {
+		return nil, false
+	}
+	resp, ok := c.responses[key]
+	return resp, ok
+}
+
+// put caches the response of the version, the responses of the other versions are dropped
+func (c *ResponseCache) put(version uint64, key string, resp *encodedResponse) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if version < c.version {
+		return
+	}
+	if version > c.version {
+		c.version = version
+		c.responses = map[string]*encodedResponse{}
+	}
+	if len(c.responses) >= maxCachedResponses {
+		return
+	}
+	c.responses[key] = resp
+}
+
+// encodedResponse is a response encoded by the handlers
+type encodedResponse struct {
+	status      int
+	contentType string
+	body        []byte
+	// The fields below are only set for the successful responses
+	gzipBody     []byte
+	etag         string
+	lastModified time.Time
+}
+
+// CacheResponse serves the responses of the price data from the cache, so they are encoded and gzipped once for each
+// version of the price data. The strong ETag and Last-Modified headers are returned, and the conditional requests are
+// answered with 304. The responses converted to another currency are not cached since they change with the exchange
+// rates, only the ETag is returned for them.
+func CacheResponse(cache *ResponseCache) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		provider, err := getPriceProvider(ctx)
+		if err != nil {
+			klog.Errorf("failed to get price provider: %v", err)
+			abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+		versioned, ok := provider.(client.VersionedProvider)
+		if !ok || ctx.Query("currency") != "" {
+			serveEncodedResponse(ctx, encodeResponse(ctx, time.Time{}, gzip.DefaultCompression))
+			return
+		}
+
+		serveCachedResponse(ctx, cache, versioned.DataVersion, ctx.Request.URL.RequestURI())
+	}
+}
+
+// CacheQueryResponse caches the responses of the search, comparison and recommendation APIs like CacheResponse, they
+// are cached for the versions of the price data of the providers and the exchange rates, and the body of the request
+// is a part of the key. Only the ETag is returned since the responses also change with the exchange rates.
+func CacheQueryResponse(cache *ResponseCache) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		providers, err := getQueryProviders(ctx)
+		if err != nil {
+			klog.Errorf("failed to get price providers: %v", err)
+			abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rates, err := getExchangeRates(ctx)
+		if err != nil {
+			klog.Errorf("failed to get exchange rates: %v", err)
+			abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		versioned := make([]client.VersionedProvider, 0, len(providers))
+		for _, provider := range providers {
+			v, ok := provider.(client.VersionedProvider)
+			if !ok {
+				serveEncodedResponse(ctx, encodeResponse(ctx, time.Time{}, gzip.DefaultCompression))
+				return
+			}
+			versioned = append(versioned, v)
+		}
+		// The versions only increase, so their sum changes once any of them changes
+		dataVersion := func() (uint64, time.Time) {
+			var version uint64
+			for _, v := range versioned {
+				n, _ := v.DataVersion()
+				version += n
+			}
+			return version, time.Time{}
+		}
+
+		key := ctx.Request.Method + " " + ctx.Request.URL.RequestURI()
+		if ctx.Request.Method == http.MethodPost {
+			body, err := io.ReadAll(ctx.Request.Body)
+			if err != nil {
+				abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+				return
+			}
+			ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+			sum := sha256.Sum256(body)
+			key += " " + hex.EncodeToString(sum[:])
+		}
+		// The currencies of the rates are printed in order
+		key += " " + fmt.Sprint(rates)
+		serveCachedResponse(ctx, cache, dataVersion, key)
+	}
+}
+
+// getQueryProviders returns the provider of the route, or all the providers of the registry for the routes of all
+// providers
+func getQueryProviders(ctx *gin.Context) ([]client.PriceProvider, error) {
+	if _, ok := ctx.Get(apis.PriceProviderContextKey); ok {
+		provider, err := getPriceProvider(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []client.PriceProvider{provider}, nil
+	}
+	registry, err := getPriceProviderRegistry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return registry.List(), nil
+}
+
+// serveCachedResponse serves the response of the key for the current version of the price data, the response is
+// encoded by the remaining handlers and cached if it's not cached yet. dataVersion returns the version of the price
+// data and the time when it was changed.
+func serveCachedResponse(ctx *gin.Context, cache *ResponseCache, dataVersion func() (uint64, time.Time), key string) {
+	version, updatedAt := dataVersion()
+	if resp, ok := cache.get(version, key); ok {
+		serveEncodedResponse(ctx, resp)
+		return
+	}
+
+	var own *encodedResponse
+	shared, _, _ := cache.group.Do(fmt.Sprintf("%d %s", version, key), func() (interface{}, error) {
+		// The response is compressed once for the version, so the best compression is used
+		own = encodeResponse(ctx, updatedAt, gzip.BestCompression)
+		if own.status != http.StatusOK {
+			return own, nil
+		}
+		// The data may be changed while the response is encoded, then the response may be of the newer data, so
+		// it's not cached for the version and has no Last-Modified
+		if v, _ := dataVersion(); v != version {
+			own.lastModified = time.Time{}
+			return own, nil
+		}
+		cache.put(version, key, own)
+		return own, nil
+	})
+	resp := shared.(*encodedResponse)
+	// The failed responses aren't shared, they may be caused by the request
+	if own == nil && resp.status != http.StatusOK {
+		resp = encodeResponse(ctx, updatedAt, gzip.BestCompression)
+	}
+	serveEncodedResponse(ctx, resp)
+}
+
+// bufferedWriter keeps the body written by the handlers instead of sending it
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// encodeResponse runs the remaining handlers and encodes their response, lastModified is the time when the data of
+// the response was changed, zero means unknown
+func encodeResponse(ctx *gin.Context, lastModified time.Time, gzipLevel int) *encodedResponse {
+	writer := &bufferedWriter{ResponseWriter: ctx.Writer}
+	ctx.Writer = writer
+	ctx.Next()
+	ctx.Writer = writer.ResponseWriter
+
+	resp := &encodedResponse{
+		status:      ctx.Writer.Status(),
+		contentType: ctx.Writer.Header().Get("Content-Type"),
+		body:        writer.body.Bytes(),
+	}
+	if resp.status != http.StatusOK {
+		return resp
+	}
+
+	sum := sha256.Sum256(resp.body)
+	resp.etag = hex.EncodeToString(sum[:16])
+	resp.lastModified = lastModified
+	var gzipBody bytes.Buffer
+	gz, err := gzip.NewWriterLevel(&gzipBody, gzipLevel)
+	if err == nil {
+		_, err = gz.Write(resp.body)
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	if err != nil {
+		klog.Errorf("Failed to gzip the response: %v", err)
+		return resp
+	}
+	resp.gzipBody = gzipBody.Bytes()
+	return resp
+}
+
+// serveEncodedResponse writes the response, the gzipped body is written if it's accepted. 304 is written if the
+// response is not modified since the client got it.
+func serveEncodedResponse(ctx *gin.Context, resp *encodedResponse) {
+	defer ctx.Abort()
+
+	if resp.status != http.StatusOK {
+		ctx.Data(resp.status, resp.contentType, resp.body)
+		return
+	}
+
+	header := ctx.Writer.Header()
+	body, etag := resp.body, resp.etag
+	gzipped := resp.gzipBody != nil && strings.Contains(ctx.GetHeader("Accept-Encoding"), "gzip")
+	// The gzipped body is another representation, so it has a different strong ETag
+	if gzipped {
+		body, etag = resp.gzipBody, resp.etag+"-gzip"
+	}
+	etag = strconv.Quote(etag)
+	header.Set("ETag", etag)
+	header.Set("Vary", "Accept-Encoding")
+	if !resp.lastModified.IsZero() {
+		header.Set("Last-Modified", resp.lastModified.UTC().Format(http.TimeFormat))
+	}
+	if notModified(ctx.Request, etag, resp.lastModified) {
+		ctx.Status(http.StatusNotModified)
+		ctx.Writer.WriteHeaderNow()
+		return
+	}
+
+	if gzipped {
+		header.Set("Content-Encoding", "gzip")
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	ctx.Data(http.StatusOK, resp.contentType, body)
+}
+
+// notModified checks the conditional headers of the request, If-Modified-Since is ignored if If-None-Match is set
+func notModified(req *http.Request, etag string, lastModified time.Time) bool {
+	if inm := req.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	ims := req.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+	t, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// The precision of the header is one second
+	return !lastModified.Truncate(time.Second).After(t)
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+)
+
+// testVersionedProvider only versions its data, the other methods of PriceProvider aren't used by the cache
+type testVersionedProvider struct {
+	client.PriceProvider
+	version   uint64
+	updatedAt time.Time
+}
+
+func (p *testVersionedProvider) DataVersion() (uint64, time.Time) {
+	return p.version, p.updatedAt
+}
+
+// newCacheTestRouter returns a router serving the body through the cache, encode is called when the body is encoded
+func newCacheTestRouter(provider client.PriceProvider, encode func(ctx *gin.Context)) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/prices", func(ctx *gin.Context) {
+		ctx.Set(apis.PriceProviderContextKey, provider)
+	}, CacheResponse(NewResponseCache()), encode)
+	return router
+}
+
+func serveCacheTestRequest(router *gin.Engine, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/prices", nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCacheResponse(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	provider := &testVersionedProvider{version: 1, updatedAt: updatedAt}
+	encoded := 0
+	body := `{"price":1}`
+	router := newCacheTestRouter(provider, func(ctx *gin.Context) {
+		encoded++
+		ctx.Data(http.StatusOK, "application/json", []byte(body))
+	})
+
+	w := serveCacheTestRequest(router, nil)
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || w.Body.String() != body || etag == "" {
+		t.Fatalf("got status %d body %q etag %q", w.Code, w.Body.String(), etag)
+	}
+	if got := w.Header().Get("Last-Modified"); got != updatedAt.Format(http.TimeFormat) {
+		t.Errorf("got Last-Modified %q, want %q", got, updatedAt.Format(http.TimeFormat))
+	}
+
+	// The gzipped body has its own ETag
+	w = serveCacheTestRequest(router, map[string]string{"Accept-Encoding": "gzip"})
+	unquoted, _ := strconv.Unquote(etag)
+	if got := w.Header().Get("ETag"); got != strconv.Quote(unquoted+"-gzip") {
+		t.Errorf("got gzip ETag %s, want the ETag with -gzip", got)
+	}
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("got Content-Encoding %q, want gzip", w.Header().Get("Content-Encoding"))
+	}
+	gz, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := io.ReadAll(gz); string(data) != body {
+		t.Errorf("got gzipped body %q, want %q", data, body)
+	}
+	if encoded != 1 {
+		t.Errorf("got the body encoded %d times, want once for the version", encoded)
+	}
+
+	tests := []struct {
+		name       string
+		header     map[string]string
+		wantStatus int
+	}{
+		{name: "matched etag", header: map[string]string{"If-None-Match": etag}, wantStatus: http.StatusNotModified},
+		{name: "weak etag", header: map[string]string{"If-None-Match": `"other", W/` + etag}, wantStatus: http.StatusNotModified},
+		{name: "etag of the other encoding", header: map[string]string{"If-None-Match": etag, "Accept-Encoding": "gzip"},
+			wantStatus: http.StatusOK},
+		{name: "unmatched etag", header: map[string]string{"If-None-Match": `"other"`}, wantStatus: http.StatusOK},
+		{name: "not modified since", header: map[string]string{"If-Modified-Since": updatedAt.Format(http.TimeFormat)},
+			wantStatus: http.StatusNotModified},
+		{name: "modified since", header: map[string]string{"If-Modified-Since": updatedAt.Add(-time.Second).Format(http.TimeFormat)},
+			wantStatus: http.StatusOK},
+		{
+			// If-Modified-Since is ignored with If-None-Match
+			name:       "unmatched etag not modified since",
+			header:     map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": updatedAt.Format(http.TimeFormat)},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveCacheTestRequest(router, tt.header)
+			if w.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("got body %q of the 304 response", w.Body.String())
+			}
+		})
+	}
+
+	// A new version is encoded again, and the old ETag is not matched
+	provider.version = 2
+	body = `{"price":2}`
+	w = serveCacheTestRequest(router, map[string]string{"If-None-Match": etag})
+	if w.Code != http.StatusOK || w.Body.String() != body || w.Header().Get("ETag") == etag {
+		t.Errorf("got status %d body %q etag %s after the version changed", w.Code, w.Body.String(), w.Header().Get("ETag"))
+	}
+	if encoded != 2 {
+		t.Errorf("got the body encoded %d times, want twice for the two versions", encoded)
+	}
+}
+
+func TestCacheResponseVersionChanged(t *testing.T) {
+	provider := &testVersionedProvider{version: 1, updatedAt: time.Now()}
+	encoded := 0
+	router := newCacheTestRouter(provider, func(ctx *gin.Context) {
+		encoded++
+		// The data is changed while the response is encoded
+		if encoded == 1 {
+			provider.version = 2
+		}
+		ctx.Data(http.StatusOK, "application/json", []byte(strconv.Itoa(encoded)))
+	})
+
+	w := serveCacheTestRequest(router, nil)
+	if w.Body.String() != "1" || w.Header().Get("Last-Modified") != "" {
+		t.Errorf("got body %q Last-Modified %q, want the response without Last-Modified", w.Body.String(),
+			w.Header().Get("Last-Modified"))
+	}
+	// The response may be of the newer data, so it's not cached for any version
+	for _, want := range []string{"2", "2"} {
+		if w := serveCacheTestRequest(router, nil); w.Body.String() != want {
+			t.Errorf("got body %q, want %q", w.Body.String(), want)
+		}
+	}
+}
+
+func TestCacheResponseFailure(t *testing.T) {
+	provider := &testVersionedProvider{version: 1}
+	encoded := 0
+	router := newCacheTestRouter(provider, func(ctx *gin.Context) {
+		encoded++
+		ctx.Data(http.StatusNotFound, "text/plain", []byte("not found"))
+	})
+
+	for i := 0; i < 2; i++ {
+		w := serveCacheTestRequest(router, map[string]string{"Accept-Encoding": "gzip"})
+		if w.Code != http.StatusNotFound || w.Header().Get("ETag") != "" || w.Header().Get("Content-Encoding") != "" {
+			t.Errorf("got status %d etag %q encoding %q, want the plain failure", w.Code, w.Header().Get("ETag"),
+				w.Header().Get("Content-Encoding"))
+		}
+	}
+	if encoded != 2 {
+		t.Errorf("got the failure encoded %d times, want it uncached", encoded)
+	}
+}
+
+func TestCacheResponseConverted(t *testing.T) {
+	provider := &testVersionedProvider{version: 1}
+	encoded := 0
+	router := newCacheTestRouter(provider, func(ctx *gin.Context) {
+		encoded++
+		ctx.Data(http.StatusOK, "application/json", []byte(`{"price":7}`))
+	})
+
+	var etags []string
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/prices?currency=CNY", nil))
+		etags = append(etags, w.Header().Get("ETag"))
+		if w.Header().Get("Last-Modified") != "" || w.Body.String() != `{"price":7}` {
+			t.Errorf("got Last-Modified %q body %q", w.Header().Get("Last-Modified"), w.Body.String())
+		}
+	}
+	// The converted responses change with the exchange rates, so they're not cached but still have the ETag
+	if encoded != 2 || etags[0] == "" || etags[0] != etags[1] {
+		t.Errorf("got the converted response encoded %d times with etags %v", encoded, etags)
+	}
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/query"
+)
+
+func ComparePrices(ctx *gin.Context) {
+	registry, err := getPriceProviderRegistry(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider registry: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	klog.V(4).Infof("Start to compare prices...")
+
+	providers, err := getProvidersFromQuery(ctx, registry)
+	if err != nil {
+		abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	opts, err := parseCompareOptions(ctx)
+	if err != nil {
+		abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if opts.Rates, err = getExchangeRates(ctx); err != nil {
+		klog.Errorf("failed to get exchange rates: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	data, err := query.Compare(providers, opts)
+	if err != nil {
+		abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	returnFormattedData(ctx, http.StatusOK, data)
+}
+
+func parseCompareOptions(ctx *gin.Context) (*query.CompareOptions, error) {
+	opts := &query.CompareOptions{
+		Regions:       splitQuery(ctx, "regions"),
+		Arch:          ctx.Query("arch"),
+		Match:         ctx.Query("match"),
+		CapacityTypes: splitQuery(ctx, "capacityTypes"),
+		OS:            ctx.Query("os"),
+		Tenancy:       ctx.Query("tenancy"),
+		Site:          ctx.Query("site"),
+		Currency:      ctx.Query("currency"),
+	}
+
+	specs := []struct {
+		name  string
+		value *float64
+	}{{"vcpu", &opts.VCPU}, {"memory", &opts.Memory}, {"gpu", &opts.GPU}}
+	for _, spec := range specs {
+		v, err := parseOptionalFloat(ctx, spec.name)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			*spec.value = *v
+		}
+	}
+	if s := ctx.Query("limit"); s != "" {
+		var err error
+		if opts.Limit, err = strconv.Atoi(s); err != nil {
+			return nil, fmt.Errorf("invalid limit: %s", s)
+		}
+	}
+	return opts, nil
+}
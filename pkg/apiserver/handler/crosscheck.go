@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+)
+
+func GetPriceCrossCheck(ctx *gin.Context) {
+	provider, err := getPriceProvider(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	klog.V(4).Infof("Start to get %s price cross check report...", provider.Name())
+
+	checker, ok := provider.(client.PriceCrossChecker)
+	if !ok {
+		abortWithFormattedData(ctx, http.StatusNotFound, fmt.Sprintf("price cross check is not supported by %s", provider.Name()))
+		return
+	}
+	report := checker.CrossCheckReport()
+	if report == nil {
+		abortWithFormattedData(ctx, http.StatusNotFound, "no price cross check report is available")
+		return
+	}
+	returnFormattedData(ctx, http.StatusOK, report)
+}
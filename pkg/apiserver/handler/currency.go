@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+	"github.com/cloudpilot-ai/priceserver/pkg/query"
+)
+
+func getExchangeRates(ctx *gin.Context) (currency.Rates, error) {
+	sourceUntyped, ok := ctx.Get(apis.ExchangeRateSourceContextKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to get sourceUntyped from context")
+	}
+	sourceTyped, ok := sourceUntyped.(currency.RateSource)
+	if !ok {
+		return nil, fmt.Errorf("failed to convert exchange rate source")
+	}
+	return sourceTyped.Rates(), nil
+}
+
+// convertRegionsPrice returns the prices converted to the currency parameter, data is returned if it's not set
+func convertRegionsPrice(ctx *gin.Context, provider string,
+	data map[string]*apis.RegionalInstancePrice) (map[string]*apis.RegionalInstancePrice, error) {
+	to := ctx.Query("currency")
+	if to == "" {
+		return data, nil
+	}
+	rates, err := getExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return query.ConvertRegionsPrice(data, provider, rates, to)
+}
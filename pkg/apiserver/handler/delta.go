@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+)
+
+func GetPriceDelta(ctx *gin.Context) {
+	provider, err := getPriceProvider(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	klog.V(4).Infof("Start to get %s price delta...", provider.Name())
+
+	deltaProvider, ok := provider.(client.DeltaProvider)
+	if !ok {
+		abortWithFormattedData(ctx, http.StatusNotFound, fmt.Sprintf("price delta is not supported by %s", provider.Name()))
+		return
+	}
+	// All the price data is returned without since
+	var since uint64
+	if s := ctx.Query("since"); s != "" {
+		since, err = strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			abortWithFormattedData(ctx, http.StatusBadRequest, fmt.Sprintf("invalid since %s", s))
+			return
+		}
+	}
+	returnFormattedData(ctx, http.StatusOK, deltaProvider.PriceDelta(since, ctx.Query("region")))
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+)
+
+// priceEventsPingInterval keeps the idle streams alive through the proxies
+const priceEventsPingInterval = 30 * time.Second
+
+var priceEventsUpgrader = websocket.Upgrader{
+	// All origins are allowed like the other APIs
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// WatchPriceEvents streams the price change events of the provider over WebSocket if the request upgrades the
+// connection, otherwise over Server-Sent Events. The stream is resumed after the Last-Event-ID header or the
+// lastEventId parameter, and it's closed if the client falls behind, so the client should reconnect with the ID of
+// its last event.
+func WatchPriceEvents(ctx *gin.Context) {
+	provider, err := getPriceProvider(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	klog.V(4).Infof("Start to watch %s price events...", provider.Name())
+
+	watcher, ok := provider.(client.PriceWatcher)
+	if !ok {
+		abortWithFormattedData(ctx, http.StatusNotFound, fmt.Sprintf("price events are not supported by %s", provider.Name()))
+		return
+	}
+	lastEventID := ctx.GetHeader("Last-Event-ID")
+	if id := ctx.Query("lastEventId"); id != "" {
+		lastEventID = id
+	}
+	filter := &apis.PriceEventFilter{
+		Regions:       splitQuery(ctx, "regions"),
+		InstanceTypes: splitQuery(ctx, "instanceTypes"),
+		CapacityTypes: splitQuery(ctx, "capacityTypes"),
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+	watch, err := watcher.WatchPrices(watchCtx, lastEventID, filter)
+	if err != nil {
+		abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if websocket.IsWebSocketUpgrade(ctx.Request) {
+		streamPriceEventsOverWebSocket(ctx, watch, cancel)
+		return
+	}
+	streamPriceEventsOverSSE(ctx, watch)
+}
+
+func streamPriceEventsOverSSE(ctx *gin.Context, watch *client.PriceWatch) {
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	// The events shouldn't be buffered by nginx
+	header.Set("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.WriteHeaderNow()
+
+	if watch.Missed {
+		ctx.Render(-1, sse.Event{
+			Event: apis.PriceStreamMessageReset,
+			Data:  &apis.PriceStreamMessage{Type: apis.PriceStreamMessageReset},
+		})
+	}
+	for _, event := range watch.Events {
+		ctx.Render(-1, sse.Event{Id: event.ID, Event: apis.PriceStreamMessagePrice, Data: event})
+	}
+	// The stream is opened for the client without waiting for the first event
+	ctx.Writer.Flush()
+
+	ticker := time.NewTicker(priceEventsPingInterval)
+	defer ticker.Stop()
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-watch.Changes:
+			if !ok {
+				return false
+			}
+			ctx.Render(-1, sse.Event{Id: event.ID, Event: apis.PriceStreamMessagePrice, Data: event})
+		case <-ticker.C:
+			// A comment line is ignored by the clients
+			if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+				return false
+			}
+		case <-ctx.Request.Context().Done():
+			return false
+		}
+		return true
+	})
+}
+
+func streamPriceEventsOverWebSocket(ctx *gin.Context, watch *client.PriceWatch, cancel context.CancelFunc) {
+	conn, err := priceEventsUpgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// The error response is written by the upgrader
+		klog.Errorf("Failed to upgrade the price events to websocket: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	// The messages of the client are discarded, reading is required to handle the control messages
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(msg *apis.PriceStreamMessage) bool {
+		if err := conn.WriteJSON(msg); err != nil {
+			klog.V(4).Infof("Failed to write the price event: %v", err)
+			return false
+		}
+		return true
+	}
+	if watch.Missed && !write(&apis.PriceStreamMessage{Type: apis.PriceStreamMessageReset}) {
+		return
+	}
+	for _, event := range watch.Events {
+		if !write(&apis.PriceStreamMessage{Type: apis.PriceStreamMessagePrice, Event: event}) {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(priceEventsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case event, ok := <-watch.Changes:
+			if !ok {
+				// The watch is closed since the client falls behind or disconnects
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "resume after the last event"),
+					time.Now().Add(time.Second))
+				return
+			}
+			if !write(&apis.PriceStreamMessage{Type: apis.PriceStreamMessagePrice, Event: event}) {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -4,8 +4,32 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"k8s.io/klog"
 )
 
+// healthyStatus is the status of a provider which is able to serve price data
+const healthyStatus = "healthy"
+
+// HealthCheck returns the status of each provider, the server is healthy if any provider is healthy since the
+// healthy ones can still be served
 func HealthCheck(ctx *gin.Context) {
-	returnFormattedData(ctx, http.StatusOK, "Price Server is healthy")
+	registry, err := getPriceProviderRegistry(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider registry: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	code := http.StatusServiceUnavailable
+	providers := map[string]string{}
+	for _, provider := range registry.List() {
+		if err := provider.Health(); err != nil {
+			providers[provider.Name()] = err.Error()
+			continue
+		}
+		providers[provider.Name()] = healthyStatus
+		code = http.StatusOK
+	}
+
+	returnFormattedData(ctx, code, gin.H{"providers": providers})
 }
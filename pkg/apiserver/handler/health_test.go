@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+)
+
+// testHealthProvider only has a name and a health, the other methods of PriceProvider aren't used by the check
+type testHealthProvider struct {
+	client.PriceProvider
+	name string
+	err  error
+}
+
+func (p *testHealthProvider) Name() string { return p.name }
+
+func (p *testHealthProvider) Health() error { return p.err }
+
+func TestHealthCheck(t *testing.T) {
+	unhealthy := errors.New("no price data is available")
+	tests := []struct {
+		name      string
+		providers []client.PriceProvider
+		wantCode  int
+		want      map[string]string
+	}{
+		{
+			name: "all healthy",
+			providers: []client.PriceProvider{
+				&testHealthProvider{name: apis.AWSCloudProvider},
+				&testHealthProvider{name: apis.GCPCloudProvider},
+			},
+			wantCode: http.StatusOK,
+			want:     map[string]string{apis.AWSCloudProvider: healthyStatus, apis.GCPCloudProvider: healthyStatus},
+		},
+		{
+			name: "partially healthy",
+			providers: []client.PriceProvider{
+				&testHealthProvider{name: apis.AWSCloudProvider},
+				&testHealthProvider{name: apis.GCPCloudProvider, err: unhealthy},
+			},
+			wantCode: http.StatusOK,
+			want:     map[string]string{apis.AWSCloudProvider: healthyStatus, apis.GCPCloudProvider: unhealthy.Error()},
+		},
+		{
+			name:      "all unhealthy",
+			providers: []client.PriceProvider{&testHealthProvider{name: apis.AWSCloudProvider, err: unhealthy}},
+			wantCode:  http.StatusServiceUnavailable,
+			want:      map[string]string{apis.AWSCloudProvider: unhealthy.Error()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			registry := client.NewRegistry(tt.providers...)
+			router.GET("/healthz", func(ctx *gin.Context) {
+				ctx.Set(apis.PriceProviderRegistryContextKey, registry)
+			}, HealthCheck)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			if w.Code != tt.wantCode {
+				t.Errorf("got status %d, want %d", w.Code, tt.wantCode)
+			}
+			var body struct {
+				Providers map[string]string `json:"providers"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(body.Providers, tt.want) {
+				t.Errorf("got providers %v, want %v", body.Providers, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+)
+
+func ListRegions(ctx *gin.Context) {
+	provider, err := getPriceProvider(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	klog.V(4).Infof("Start to list %s regions...", provider.Name())
+
+	data := provider.ListRegions()
+	returnFormattedData(ctx, http.StatusOK, data)
+}
+
+func ListAllRegionsPrice(ctx *gin.Context) {
+	provider, err := getPriceProvider(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	klog.V(4).Infof("Start to list %s all regions price...", provider.Name())
+
+	data, err := selectRegionsPrice(ctx, provider.ListRegionsInstancesPrice())
+	if err != nil {
+		abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if data, err = convertRegionsPrice(ctx, provider.Name(), data); err != nil {
+		abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	returnFormattedData(ctx, http.StatusOK, data)
+}
+
+func ListRegionPrice(ctx *gin.Context) {
+	provider, err := getPriceProvider(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	klog.V(4).Infof("Start to list %s price...", provider.Name())
+
+	region := ctx.Param("region")
+	data := provider.ListInstancesPrice(region)
+	if data != nil {
+		regionData := (*data)[region]
+		selected, err := selectRegionsPrice(ctx, map[string]*apis.RegionalInstancePrice{region: &regionData})
+		if err != nil {
+			abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		if selected, err = convertRegionsPrice(ctx, provider.Name(), selected); err != nil {
+			abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		data = &map[string]apis.RegionalInstancePrice{region: *selected[region]}
+	}
+	returnFormattedData(ctx, http.StatusOK, data)
+}
+
+func GetInstancePrice(ctx *gin.Context) {
+	provider, err := getPriceProvider(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	klog.V(4).Infof("Start to get %s instance price...", provider.Name())
+
+	region := ctx.Param("region")
+	instanceType := ctx.Param("instance_type")
+	data := provider.GetInstancePrice(region, instanceType)
+	if data != nil {
+		regionData := &apis.RegionalInstancePrice{
+			InstanceTypePrices: map[string]*apis.InstanceTypePrice{instanceType: data},
+		}
+		selected, err := selectRegionsPrice(ctx, map[string]*apis.RegionalInstancePrice{region: regionData})
+		if err != nil {
+			abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		if selected, err = convertRegionsPrice(ctx, provider.Name(), selected); err != nil {
+			abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		// nil is returned if the instance type has no price of the platform
+		data = selected[region].InstanceTypePrices[instanceType]
+	}
+	returnFormattedData(ctx, http.StatusOK, data)
+}
+
+func getPriceProvider(ctx *gin.Context) (client.PriceProvider, error) {
+	providerUntyped, ok := ctx.Get(apis.PriceProviderContextKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to get providerUntyped from context")
+	}
+	providerTyped, ok := providerUntyped.(client.PriceProvider)
+	if !ok {
+		return nil, fmt.Errorf("failed to convert provider")
+	}
+	return providerTyped, nil
+}
+
+func getPriceProviderRegistry(ctx *gin.Context) (*client.Registry, error) {
+	registryUntyped, ok := ctx.Get(apis.PriceProviderRegistryContextKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to get registryUntyped from context")
+	}
+	registryTyped, ok := registryUntyped.(*client.Registry)
+	if !ok {
+		return nil, fmt.Errorf("failed to convert registry")
+	}
+	return registryTyped, nil
+}
+
+func ListProviders(ctx *gin.Context) {
+	registry, err := getPriceProviderRegistry(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider registry: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var data []apis.ProviderStatus
+	for _, provider := range registry.List() {
+		status := apis.ProviderStatus{
+			Name:    provider.Name(),
+			Service: provider.Service(),
+			Healthy: true,
+		}
+		if readOnly, ok := provider.(client.ReadOnlyProvider); ok {
+			status.ReadOnly = true
+			if t := readOnly.SnapshotTime(); !t.IsZero() {
+				status.SnapshotTime = &t
+			}
+		}
+		if err := provider.Health(); err != nil {
+			status.Healthy = false
+			status.Message = err.Error()
+		}
+		data = append(data, status)
+	}
+	returnFormattedData(ctx, http.StatusOK, data)
+}
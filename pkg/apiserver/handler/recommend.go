@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+	"github.com/cloudpilot-ai/priceserver/pkg/query"
+)
+
+func RecommendInstances(ctx *gin.Context) {
+	provider, err := getPriceProvider(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	klog.V(4).Infof("Start to recommend %s instances...", provider.Name())
+
+	req := &apis.RecommendationRequest{}
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		abortWithFormattedData(ctx, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	recommendInstances(ctx, []client.PriceProvider{provider}, req)
+}
+
+func RecommendAllProvidersInstances(ctx *gin.Context) {
+	registry, err := getPriceProviderRegistry(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider registry: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	klog.V(4).Infof("Start to recommend instances of all providers...")
+
+	req := &apis.RecommendationRequest{}
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		abortWithFormattedData(ctx, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+
+	providers := registry.List()
+	if len(req.Providers) != 0 {
+		providers = nil
+		for _, name := range req.Providers {
+			provider, ok := registry.Get(name)
+			if !ok {
+				abortWithFormattedData(ctx, http.StatusBadRequest, fmt.Sprintf("unknown price provider %s", name))
+				return
+			}
+			providers = append(providers, provider)
+		}
+	}
+	recommendInstances(ctx, providers, req)
+}
+
+func recommendInstances(ctx *gin.Context, providers []client.PriceProvider, req *apis.RecommendationRequest) {
+	rates, err := getExchangeRates(ctx)
+	if err != nil {
+		klog.Errorf("failed to get exchange rates: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	// The currency parameter overrides the one in the body, so it works the same as the other handlers
+	if c := ctx.Query("currency"); c != "" {
+		req.Currency = c
+	}
+
+	data, err := query.Recommend(providers, req, rates)
+	if err != nil {
+		abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	returnFormattedData(ctx, http.StatusOK, data)
+}
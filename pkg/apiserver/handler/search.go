@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+	"github.com/cloudpilot-ai/priceserver/pkg/query"
+)
+
+func SearchInstances(ctx *gin.Context) {
+	provider, err := getPriceProvider(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	klog.V(4).Infof("Start to search %s instances...", provider.Name())
+
+	searchInstances(ctx, []client.PriceProvider{provider})
+}
+
+func SearchAllProvidersInstances(ctx *gin.Context) {
+	registry, err := getPriceProviderRegistry(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider registry: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	klog.V(4).Infof("Start to search instances of all providers...")
+
+	providers, err := getProvidersFromQuery(ctx, registry)
+	if err != nil {
+		abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	searchInstances(ctx, providers)
+}
+
+func searchInstances(ctx *gin.Context, providers []client.PriceProvider) {
+	opts, err := parseSearchOptions(ctx)
+	if err != nil {
+		abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if opts.Rates, err = getExchangeRates(ctx); err != nil {
+		klog.Errorf("failed to get exchange rates: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	data, err := query.Search(providers, opts)
+	if err != nil {
+		abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	returnFormattedData(ctx, http.StatusOK, data)
+}
+
+// getProvidersFromQuery returns the providers in the comma separated providers parameter, empty means all
+func getProvidersFromQuery(ctx *gin.Context, registry *client.Registry) ([]client.PriceProvider, error) {
+	names := splitQuery(ctx, "providers")
+	if len(names) == 0 {
+		return registry.List(), nil
+	}
+
+	var ret []client.PriceProvider
+	for _, name := range names {
+		provider, ok := registry.Get(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown price provider %s", name)
+		}
+		ret = append(ret, provider)
+	}
+	return ret, nil
+}
+
+func parseSearchOptions(ctx *gin.Context) (*query.SearchOptions, error) {
+	opts := &query.SearchOptions{
+		Regions:      splitQuery(ctx, "regions"),
+		Arch:         ctx.Query("arch"),
+		Zone:         ctx.Query("zone"),
+		CapacityType: ctx.Query("capacityType"),
+		SortBy:       ctx.Query("sortBy"),
+		Desc:         ctx.Query("order") == "desc",
+		OS:           ctx.Query("os"),
+		Tenancy:      ctx.Query("tenancy"),
+		Site:         ctx.Query("site"),
+		Currency:     ctx.Query("currency"),
+	}
+
+	var err error
+	ranges := []struct {
+		name string
+		r    *query.Range
+	}{{"VCPU", &opts.VCPU}, {"Memory", &opts.Memory}, {"GPU", &opts.GPU}}
+	for _, r := range ranges {
+		if r.r.Min, err = parseOptionalFloat(ctx, "min"+r.name); err != nil {
+			return nil, err
+		}
+		if r.r.Max, err = parseOptionalFloat(ctx, "max"+r.name); err != nil {
+			return nil, err
+		}
+	}
+	maxPrice, err := parseOptionalFloat(ctx, "maxPrice")
+	if err != nil {
+		return nil, err
+	}
+	if maxPrice != nil {
+		opts.MaxPrice = *maxPrice
+	}
+	if s := ctx.Query("offset"); s != "" {
+		if opts.Offset, err = strconv.Atoi(s); err != nil {
+			return nil, fmt.Errorf("invalid offset: %s", s)
+		}
+	}
+	if s := ctx.Query("limit"); s != "" {
+		if opts.Limit, err = strconv.Atoi(s); err != nil {
+			return nil, fmt.Errorf("invalid limit: %s", s)
+		}
+	}
+	return opts, nil
+}
+
+func parseOptionalFloat(ctx *gin.Context, name string) (*float64, error) {
+	s := ctx.Query(name)
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", name, s)
+	}
+	return &v, nil
+}
+
+// splitQuery returns the values of a comma separated parameter, the parameter can also be repeated
+func splitQuery(ctx *gin.Context, name string) []string {
+	var ret []string
+	for _, v := range ctx.QueryArray(name) {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				ret = append(ret, s)
+			}
+		}
+	}
+	return ret
+}
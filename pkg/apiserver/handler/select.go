@@ -0,0 +1,13 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/query"
+)
+
+// selectRegionsPrice returns the prices of the os, tenancy and site parameters, data is returned if none is set
+func selectRegionsPrice(ctx *gin.Context, data map[string]*apis.RegionalInstancePrice) (map[string]*apis.RegionalInstancePrice, error) {
+	return query.SelectRegionsPrice(data, ctx.Query("os"), ctx.Query("tenancy"), ctx.Query("site"))
+}
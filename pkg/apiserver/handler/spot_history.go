@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+)
+
+const (
+	defaultSpotHistoryRange = 7 * 24 * time.Hour
+	// maxSpotHistorySteps limits the number of resampled points of each zone
+	maxSpotHistorySteps = 10000
+)
+
+func GetSpotPriceHistory(ctx *gin.Context) {
+	provider, err := getPriceProvider(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	registry, err := getPriceProviderRegistry(ctx)
+	if err != nil {
+		klog.Errorf("failed to get price provider registry: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	history := registry.SpotHistory()
+	if history == nil {
+		abortWithFormattedData(ctx, http.StatusNotFound, "spot price history is not enabled")
+		return
+	}
+	klog.V(4).Infof("Start to get %s spot price history...", provider.Name())
+
+	to := time.Now()
+	if v := ctx.Query("to"); v != "" {
+		if to, err = parseTime(v); err != nil {
+			abortWithFormattedData(ctx, http.StatusBadRequest, fmt.Sprintf("invalid to: %v", err))
+			return
+		}
+	}
+	from := to.Add(-defaultSpotHistoryRange)
+	if v := ctx.Query("from"); v != "" {
+		if from, err = parseTime(v); err != nil {
+			abortWithFormattedData(ctx, http.StatusBadRequest, fmt.Sprintf("invalid from: %v", err))
+			return
+		}
+	}
+	if from.After(to) {
+		abortWithFormattedData(ctx, http.StatusBadRequest, "from is after to")
+		return
+	}
+	var step time.Duration
+	if v := ctx.Query("step"); v != "" {
+		if step, err = time.ParseDuration(v); err != nil || step <= 0 {
+			abortWithFormattedData(ctx, http.StatusBadRequest, fmt.Sprintf("invalid step: %s", v))
+			return
+		}
+		if to.Sub(from)/step > maxSpotHistorySteps {
+			abortWithFormattedData(ctx, http.StatusBadRequest,
+				fmt.Sprintf("too many steps, at most %d steps are allowed", maxSpotHistorySteps))
+			return
+		}
+	}
+
+	region := ctx.Param("region")
+	instanceType := ctx.Param("instance_type")
+	zone := ctx.Query("zone")
+	// The history is recorded in the currency of the provider
+	sourceCurrency := client.PriceCurrency(provider.Name(), region)
+	data := &apis.SpotPriceHistory{
+		Region:       region,
+		InstanceType: instanceType,
+		From:         from.UTC(),
+		To:           to.UTC(),
+		Currency:     sourceCurrency,
+		Zones:        map[string]*apis.ZoneSpotPriceHistory{},
+	}
+	rates, err := getExchangeRates(ctx)
+	if err != nil {
+		klog.Errorf("failed to get exchange rates: %v", err)
+		abortWithFormattedData(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if c := ctx.Query("currency"); c != "" {
+		if _, ok := rates[c]; !ok {
+			abortWithFormattedData(ctx, http.StatusBadRequest, fmt.Sprintf("unsupported currency %s", c))
+			return
+		}
+		data.Currency = c
+	}
+	if step > 0 {
+		data.Step = step.String()
+	}
+	for z, points := range history.Query(provider.Name(), region, instanceType, from, to) {
+		if zone != "" && z != zone {
+			continue
+		}
+		for i := range points {
+			if points[i].Price, err = rates.Convert(points[i].Price, sourceCurrency, data.Currency); err != nil {
+				abortWithFormattedData(ctx, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		zoneHistory := &apis.ZoneSpotPriceHistory{
+			Points: points,
+			Stats:  summarizeSpotPrices(points, from, to),
+		}
+		if step > 0 {
+			zoneHistory.Points = resampleSpotPrices(points, from, to, step)
+		}
+		data.Zones[z] = zoneHistory
+	}
+	returnFormattedData(ctx, http.StatusOK, data)
+}
+
+// parseTime accepts RFC3339 or unix seconds
+func parseTime(v string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+// resampleSpotPrices returns the price in effect at every step from from, the steps before the first point are skipped
+func resampleSpotPrices(points []apis.SpotPricePoint, from, to time.Time, step time.Duration) []apis.SpotPricePoint {
+	var ret []apis.SpotPricePoint
+	i := 0
+	for t := from; !t.After(to); t = t.Add(step) {
+		for i < len(points) && !points[i].Timestamp.After(t) {
+			i++
+		}
+		if i == 0 {
+			continue
+		}
+		ret = append(ret, apis.SpotPricePoint{Timestamp: t.UTC(), Price: points[i-1].Price})
+	}
+	return ret
+}
+
+// summarizeSpotPrices weights each price by the duration it's in effect within [from, to], since a point is
+// recorded only when the price changes
+func summarizeSpotPrices(points []apis.SpotPricePoint, from, to time.Time) *apis.SpotPriceStats {
+	if len(points) == 0 {
+		return nil
+	}
+
+	type segment struct {
+		price  float64
+		weight float64
+	}
+	segments := make([]segment, 0, len(points))
+	total := 0.0
+	for i, point := range points {
+		start := point.Timestamp
+		if start.Before(from) {
+			start = from
+		}
+		end := to
+		if i+1 < len(points) && points[i+1].Timestamp.Before(to) {
+			end = points[i+1].Timestamp
+		}
+		weight := math.Max(end.Sub(start).Seconds(), 0)
+		segments = append(segments, segment{price: point.Price, weight: weight})
+		total += weight
+	}
+	// All the points are at the same time, e.g. from equals to
+	if total == 0 {
+		for i := range segments {
+			segments[i].weight = 1
+		}
+		total = float64(len(segments))
+	}
+
+	stats := &apis.SpotPriceStats{Min: math.MaxFloat64}
+	for _, s := range segments {
+		if s.weight == 0 {
+			continue
+		}
+		stats.Min = math.Min(stats.Min, s.price)
+		stats.Max = math.Max(stats.Max, s.price)
+		stats.Avg += s.price * s.weight / total
+	}
+
+	sort.Slice(segments, func(i, j int) bool { return segments[i].price < segments[j].price })
+	percentile := func(q float64) float64 {
+		acc := 0.0
+		for _, s := range segments {
+			acc += s.weight
+			if acc >= q*total {
+				return s.price
+			}
+		}
+		return segments[len(segments)-1].price
+	}
+	stats.P50 = percentile(0.5)
+	stats.P90 = percentile(0.9)
+	stats.P95 = percentile(0.95)
+	stats.P99 = percentile(0.99)
+	return stats
+}
@@ -1,6 +1,8 @@
 package router
 
 import (
+	"time"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
@@ -8,9 +10,10 @@ import (
 	"github.com/cloudpilot-ai/priceserver/pkg/apis"
 	"github.com/cloudpilot-ai/priceserver/pkg/apiserver/handler"
 	"github.com/cloudpilot-ai/priceserver/pkg/client"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
 )
 
-func NewPriceServerRouter(awsPriceClient *client.AWSPriceClient, alibabaCloudClient *client.AlibabaCloudPriceClient) *gin.Engine {
+func NewPriceServerRouter(registry *client.Registry, rateSource currency.RateSource) *gin.Engine {
 	router := gin.Default()
 
 	config := cors.DefaultConfig()
@@ -19,35 +22,61 @@ func NewPriceServerRouter(awsPriceClient *client.AWSPriceClient, alibabaCloudCli
 	corsHandler := cors.New(config)
 	router.Use(corsHandler)
 
-	router.Use(gzip.Gzip(gzip.DefaultCompression))
-
 	router.Use(func(context *gin.Context) {
-		context.Set(apis.AWSPriceClientContextKey, awsPriceClient)
-		context.Set(apis.AlibabaCloudClientContextKey, alibabaCloudClient)
+		context.Set(apis.PriceProviderRegistryContextKey, registry)
+		context.Set(apis.ExchangeRateSourceContextKey, rateSource)
 		context.Next()
 	})
-	initAWSPriceRouter(router)
-	initAlibabaCloudPriceRouter(router)
-	initHealthRouter(router)
+	// The price data responses are compressed by the response cache, the others are compressed by the middleware
+	compress := gzip.Gzip(gzip.DefaultCompression)
+	queryCache := handler.CacheQueryResponse(handler.NewResponseCache())
+	router.GET("/api/v1/providers", compress, handler.ListProviders)
+	router.GET("/api/v1/search", queryCache, handler.SearchAllProvidersInstances)
+	router.POST("/api/v1/recommendations", queryCache, handler.RecommendAllProvidersInstances)
+	router.GET("/api/v1/compare", queryCache, handler.ComparePrices)
+	for _, provider := range registry.List() {
+		initPriceProviderRouter(router, provider, compress)
+	}
+	initHealthRouter(router, compress)
 
 	return router
 }
 
-func initAWSPriceRouter(router *gin.Engine) {
-	group := router.Group("/api/v1/aws")
-	group.GET("/ec2/price", handler.ListAWSAllRegionEC2Price)
-	group.GET("/ec2/regions/:region/price", handler.ListAWSEC2Price)
-	group.GET("/ec2/regions/:region/types/:instance_type/price", handler.GetAWSEC2Price)
+func initPriceProviderRouter(router *gin.Engine, provider client.PriceProvider, compress gin.HandlerFunc) {
+	group := router.Group("/api/v1/" + provider.Name())
+	group.Use(func(context *gin.Context) {
+		context.Set(apis.PriceProviderContextKey, provider)
+		if readOnly, ok := provider.(client.ReadOnlyProvider); ok {
+			context.Header(apis.ReadOnlyHeader, "true")
+			if t := readOnly.SnapshotTime(); !t.IsZero() {
+				context.Header(apis.SnapshotTimeHeader, t.UTC().Format(time.RFC3339))
+			}
+		}
+		context.Next()
+	})
+	responseCache := handler.NewResponseCache()
+	cache := handler.CacheResponse(responseCache)
+	queryCache := handler.CacheQueryResponse(responseCache)
+	initPriceRouter(group, compress, cache, queryCache)
+
+	// The routes with service name are kept for the api compatibility
+	initPriceRouter(group.Group("/"+provider.Service()), compress, cache, queryCache)
 }
 
-func initAlibabaCloudPriceRouter(router *gin.Engine) {
-	group := router.Group("/api/v1/alibabacloud")
-	group.GET("/ecs/price", handler.ListAlibabaCloudAllRegionECSPrice)
-	group.GET("/ecs/regions/:region/price", handler.ListAlibabaCloudECSPrice)
-	group.GET("/ecs/regions/:region/types/:instance_type/price", handler.GetAlibabaCloudECSPrice)
+func initPriceRouter(group *gin.RouterGroup, compress, cache, queryCache gin.HandlerFunc) {
+	group.GET("/regions", cache, handler.ListRegions)
+	group.GET("/search", queryCache, handler.SearchInstances)
+	group.POST("/recommendations", queryCache, handler.RecommendInstances)
+	group.GET("/price", cache, handler.ListAllRegionsPrice)
+	group.GET("/price/delta", cache, handler.GetPriceDelta)
+	group.GET("/price/crosscheck", compress, handler.GetPriceCrossCheck)
+	group.GET("/price/events", handler.WatchPriceEvents)
+	group.GET("/regions/:region/price", cache, handler.ListRegionPrice)
+	group.GET("/regions/:region/types/:instance_type/price", cache, handler.GetInstancePrice)
+	group.GET("/regions/:region/types/:instance_type/spot/history", compress, handler.GetSpotPriceHistory)
 }
 
-func initHealthRouter(router *gin.Engine) {
+func initHealthRouter(router *gin.Engine, compress gin.HandlerFunc) {
 	group := router.Group("/")
-	group.GET("/healthz", handler.HealthCheck)
+	group.GET("/healthz", compress, handler.HealthCheck)
 }
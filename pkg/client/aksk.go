@@ -0,0 +1,48 @@
+package client
+
+import (
+	"math/rand"
+	"os"
+	"strings"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+type AKSKPair struct {
+	AK string
+	SK string
+}
+
+// ExtractAKSKPool extracts the ak/sk pairs from the env with format <ak1>:<sk1>,<ak2>:<sk2>,
+// requests are spread over the pairs to avoid being throttled.
+func ExtractAKSKPool(env string) []AKSKPair {
+	akskPool := os.Getenv(env)
+	if akskPool == "" {
+		return nil
+	}
+
+	akskPair := []AKSKPair{}
+	for _, aksk := range strings.Split(akskPool, ",") {
+		aksk = strings.TrimSpace(aksk)
+		if aksk == "" {
+			continue
+		}
+		akskArray := strings.Split(aksk, ":")
+		if len(akskArray) != 2 {
+			continue
+		}
+
+		akskPair = append(akskPair, AKSKPair{AK: akskArray[0], SK: akskArray[1]})
+	}
+
+	return akskPair
+}
+
+func ExtractAlibabaCloudAKSKPool() []AKSKPair {
+	return ExtractAKSKPool(apis.AlibabaCloudAKSKPoolEnv)
+}
+
+func pickAKSK(pool []AKSKPair) (string, string) {
+	pick := rand.Intn(len(pool))
+	return pool[pick].AK, pool[pick].SK
+}
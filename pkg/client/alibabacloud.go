@@ -6,11 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math/rand"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,66 +22,102 @@ import (
 	util "github.com/alibabacloud-go/tea-utils/v2/service"
 	"github.com/alibabacloud-go/tea/tea"
 	"github.com/samber/lo"
+	"golang.org/x/time/rate"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog"
 
 	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+	"github.com/cloudpilot-ai/priceserver/pkg/storage"
 	"github.com/cloudpilot-ai/priceserver/pkg/tools"
 )
 
 //go:embed builtin-data/*.json
 var file embed.FS
 
-type AKSKPair struct {
-	AK string
-	SK string
-}
-
-func ExtractAlibabaCloudAKSKPool() []AKSKPair {
-	akskPool := os.Getenv(apis.AlibabaCloudAKSKPoolEnv)
-	if akskPool == "" {
-		return nil
-	}
-
-	akskPair := []AKSKPair{}
-	for _, aksk := range strings.Split(akskPool, ",") {
-		aksk = strings.TrimSpace(aksk)
-		if aksk == "" {
-			continue
-		}
-		akskArray := strings.Split(aksk, ":")
-		if len(akskArray) != 2 {
-			continue
-		}
+const alibabaCloudService = "ecs"
+
+const (
+	alibabaCloudBSSEndpoint = "business.aliyuncs.com"
+	alibabaCloudBSSVersion  = "2017-12-14"
+	// alibabaCloudSavingsPlanCommodity is the commodity code of savings plans on the China site
+	alibabaCloudSavingsPlanCommodity = "savingplan_common_public_cn"
+	alibabaCloudBSSPageSize          = 100
+
+	// AlibabaCloudPriceSourceScraper scrapes the on-demand prices from the price page
+	AlibabaCloudPriceSourceScraper = "scraper"
+	// AlibabaCloudPriceSourceAPI gets the on-demand prices from the ECS DescribePrice API, the scraped prices
+	// are used if the API has no price of an instance type
+	AlibabaCloudPriceSourceAPI = "api"
+	// alibabaCloudCrossCheckTolerance is the max relative difference of the prices of the two sources
+	alibabaCloudCrossCheckTolerance = 0.01
+
+	// alibabaCloudDescribePriceQPS limits the DescribePrice requests of all regions, the API is throttled per account
+	alibabaCloudDescribePriceQPS = 20
+	// alibabaCloudDescribePriceRegions is the number of regions whose prices are described at the same time
+	alibabaCloudDescribePriceRegions = 10
+
+	// The status and the stock categories of the resources in DescribeAvailableResource
+	alibabaCloudResourceAvailable  = "Available"
+	alibabaCloudStockWithout       = "WithoutStock"
+	alibabaCloudStockClosedWithout = "ClosedWithoutStock"
+)
 
-		akskPair = append(akskPair, AKSKPair{AK: akskArray[0], SK: akskArray[1]})
-	}
+func init() {
+	RegisterProviderFactory(apis.AlibabaCloudProvider, alibabaCloudService, newAlibabaCloudPriceProvider)
+}
 
-	return akskPair
+// AlibabaCloudConfig contains the settings of the AlibabaCloud price client, the ECS and BSS requests are sent to
+// Endpoint if it's set, e.g. http://127.0.0.1:8081, it's used for a local HTTP stand-in.
+type AlibabaCloudConfig struct {
+	AKSKPool []AKSKPair
+	Endpoint string
+	// PriceSource is the source of the on-demand prices, default to scraper
+	PriceSource string
 }
 
 type AlibabaCloudPriceClient struct {
-	akskPool []AKSKPair
+	config AlibabaCloudConfig
 
-	regionList []string
+	regionList  []string
+	store       storage.Store
+	spotHistory storage.SpotHistoryStore
 
-	dataMutex sync.RWMutex
-	priceData map[string]*apis.RegionalInstancePrice
+	priceData *atomicPriceData
+
+	crossCheckMutex sync.RWMutex
+	// crossCheckReport compares the API prices with the scraped ones, it's only set with the api price source
+	crossCheckReport *apis.PriceCrossCheckReport
+
+	// describePriceLimiter is shared by the on-demand and the subscription price requests
+	describePriceLimiter *rate.Limiter
 }
 
-func NewAlibabaCloudPriceClient(akskPool []AKSKPair, initialSpotUpdate bool) (*AlibabaCloudPriceClient, error) {
-	data, err := file.ReadFile("builtin-data/alibabacloud_price.json")
+func NewAlibabaCloudPriceClient(config AlibabaCloudConfig, store storage.Store, spotHistory storage.SpotHistoryStore,
+	initialSpotUpdate bool) (*AlibabaCloudPriceClient, error) {
+	if len(config.AKSKPool) == 0 {
+		return nil, fmt.Errorf("alibaba cloud access key and secret key pool is empty")
+	}
+	if config.PriceSource == "" {
+		config.PriceSource = AlibabaCloudPriceSourceScraper
+	}
+	if config.PriceSource != AlibabaCloudPriceSourceScraper && config.PriceSource != AlibabaCloudPriceSourceAPI {
+		return nil, fmt.Errorf("unsupported alibaba cloud price source %s", config.PriceSource)
+	}
+
+	priceData, updatedAt, err := loadPriceData(store, apis.AlibabaCloudProvider)
 	if err != nil {
 		return nil, err
 	}
 
 	client := &AlibabaCloudPriceClient{
-		akskPool:   akskPool,
-		regionList: []string{},
-		priceData:  map[string]*apis.RegionalInstancePrice{},
-	}
-	if err := json.Unmarshal(data, &client.priceData); err != nil {
-		return nil, err
+		config:      config,
+		regionList:  []string{},
+		store:       store,
+		spotHistory: spotHistory,
+		priceData:   newAtomicPriceData(apis.AlibabaCloudProvider, priceData, updatedAt),
+
+		describePriceLimiter: rate.NewLimiter(alibabaCloudDescribePriceQPS, alibabaCloudDescribePriceQPS),
 	}
 
 	if err := client.initialRegions(); err != nil {
@@ -89,30 +126,112 @@ func NewAlibabaCloudPriceClient(akskPool []AKSKPair, initialSpotUpdate bool) (*A
 
 	if initialSpotUpdate {
 		client.refreshSpotPrice()
+		persistPriceData(client.store, client)
+		recordSpotHistory(client.spotHistory, client)
 	}
 
 	return client, nil
 }
 
+func newAlibabaCloudPriceProvider(opts *ProviderOptions) (PriceProvider, error) {
+	akskPool := ExtractAlibabaCloudAKSKPool()
+	if len(akskPool) == 0 {
+		return nil, fmt.Errorf("alibaba cloud access key and secret key pool is not set: %w", ErrMissingCredentials)
+	}
+
+	return NewAlibabaCloudPriceClient(AlibabaCloudConfig{
+		AKSKPool:    akskPool,
+		Endpoint:    os.Getenv(apis.AlibabaCloudEndpointEnv),
+		PriceSource: os.Getenv(apis.AlibabaCloudPriceSourceEnv),
+	}, opts.Store, opts.SpotHistory, opts.InitialSpotUpdate)
+}
+
+func (a *AlibabaCloudPriceClient) Name() string {
+	return apis.AlibabaCloudProvider
+}
+
+func (a *AlibabaCloudPriceClient) Service() string {
+	return alibabaCloudService
+}
+
 func (a *AlibabaCloudPriceClient) Run(ctx context.Context) {
+	// The builtin data has no international site prices, the API prices or the cross-check report
+	a.RefreshOnDemandPrice()
+	persistPriceData(a.store, a)
+
 	odTicker := time.NewTicker(time.Hour * 24 * 7)
 	defer odTicker.Stop()
 
 	spotTicker := time.NewTicker(time.Minute * 30)
 	defer spotTicker.Stop()
 
+	// The billing prices take hours to refresh, so they're refreshed off the loop to keep the spot prices fresh. The
+	// builtin data has no subscription and savings plan prices, so they're refreshed at startup.
+	billingTrigger := make(chan struct{}, 1)
+	billingTrigger <- struct{}{}
+	go a.runBillingRefresh(ctx, billingTrigger)
+
 	for {
 		select {
 		case <-odTicker.C:
 			a.RefreshOnDemandPrice()
+			persistPriceData(a.store, a)
+			// The savings plan rates are based on the new on-demand prices
+			select {
+			case billingTrigger <- struct{}{}:
+			default:
+			}
 		case <-spotTicker.C:
 			a.refreshSpotPrice()
+			persistPriceData(a.store, a)
+			recordSpotHistory(a.spotHistory, a)
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// runBillingRefresh refreshes the billing prices once per trigger until ctx is done
+func (a *AlibabaCloudPriceClient) runBillingRefresh(ctx context.Context, trigger <-chan struct{}) {
+	for {
+		select {
+		case <-trigger:
+			a.RefreshBillingPrice()
+			persistPriceData(a.store, a)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Refresh refreshes all the on-demand, subscription, savings plan and spot prices, AlibabaCloud prices
+// are always refreshed for all regions, so region and instance type are ignored.
+func (a *AlibabaCloudPriceClient) Refresh(_, _ string) {
+	a.RefreshOnDemandPrice()
+	a.RefreshBillingPrice()
+	a.refreshSpotPrice()
+	persistPriceData(a.store, a)
+}
+
+func (a *AlibabaCloudPriceClient) Health() error {
+	if len(a.priceData.Load()) == 0 {
+		return fmt.Errorf("no price data is available for alibabacloud")
+	}
+	return nil
+}
+
+func (a *AlibabaCloudPriceClient) DataVersion() (uint64, time.Time) {
+	return a.priceData.Version()
+}
+
+func (a *AlibabaCloudPriceClient) PriceDelta(since uint64, region string) *apis.PriceDelta {
+	return a.priceData.Delta(since, region)
+}
+
+func (a *AlibabaCloudPriceClient) WatchPrices(ctx context.Context, lastEventID string, filter *apis.PriceEventFilter) (*PriceWatch, error) {
+	return a.priceData.events.Watch(ctx, lastEventID, filter)
+}
+
 func getSpotPrice(client *ecsclient.Client, region, instanceType string) (map[string]float64, error) {
 	describeSpotPriceHistoryRequest := &ecsclient.DescribeSpotPriceHistoryRequest{
 		RegionId:     tea.String(region),
@@ -190,39 +309,49 @@ func (a *AlibabaCloudPriceClient) refreshSpotPrice() {
 		}
 	}
 
+	// failed marks the instance types whose spot prices failed to refresh
+	failed := make([]bool, n)
 	workqueue.ParallelizeUntil(context.Background(), 50, n, func(i int) {
 		client, err := a.createECSClient(rsiPricess[i].Region)
 		if err != nil {
 			klog.Errorf("Failed to create ECS client in region %s:%v", rsiPricess[i].Region, err)
+			failed[i] = true
 			return
 		}
 
 		spotPrice, err := getSpotPrice(client, rsiPricess[i].Region, rsiPricess[i].InstanceType)
 		if err != nil {
 			klog.Errorf("Failed to get spot price in region %s:%v", rsiPricess[i].Region, err)
+			failed[i] = true
 			return
 		}
 
 		rsiPricess[i].Info.SpotPricePerHour = spotPrice
 	})
 
-	a.dataMutex.Lock()
-	defer a.dataMutex.Unlock()
-	for i := range rsiPricess {
-		if _, ok := a.priceData[rsiPricess[i].Region]; !ok {
-			a.priceData[rsiPricess[i].Region] = &apis.RegionalInstancePrice{
-				InstanceTypePrices: map[string]*apis.InstanceTypePrice{},
-			}
-		}
-		if _, ok := a.priceData[rsiPricess[i].Region].InstanceTypePrices[rsiPricess[i].InstanceType]; ok {
-			rsiPricess[i].Info.OnDemandPricePerHour = a.priceData[rsiPricess[i].Region].InstanceTypePrices[rsiPricess[i].InstanceType].OnDemandPricePerHour
-		}
-		a.priceData[rsiPricess[i].Region].InstanceTypePrices[rsiPricess[i].InstanceType] = rsiPricess[i].Info
-	}
+	a.putSpotPrices(rsiPricess, failed)
 
 	klog.Infof("All spot prices are refreshed for AlibabaCloud")
 }
 
+// putSpotPrices puts the instance types with the refreshed spot prices, the other prices are kept. The old spot
+// prices are kept if they failed to refresh.
+func (a *AlibabaCloudPriceClient) putSpotPrices(prices []RegionalSpotInstancePriceSplit, failed []bool) {
+	a.priceData.Update(func(w *priceDataWriter) {
+		for i := range prices {
+			if old, ok := w.Get(prices[i].Region, prices[i].InstanceType); ok {
+				prices[i].Info.OnDemandPricePerHour = old.OnDemandPricePerHour
+				prices[i].Info.AlibabaCloudBilling = old.AlibabaCloudBilling
+				prices[i].Info.SitePrices = old.SitePrices
+				if failed[i] {
+					prices[i].Info.SpotPricePerHour = old.SpotPricePerHour
+				}
+			}
+			w.Put(prices[i].Region, prices[i].InstanceType, prices[i].Info)
+		}
+	})
+}
+
 type ECSPrice struct {
 	PricingInfo map[string]ECSPriceDetail `json:"pricingInfo"`
 }
@@ -235,9 +364,33 @@ type ECSHoursPrice struct {
 	Price string `json:"price"`
 }
 
-func getECSPrice() (map[string]map[string]float64, error) {
-	baseUrl := "https://www.aliyun.com/price/ecs/ecs-pricing/zh"
-	baseResp, err := http.Get(baseUrl)
+// alibabaCloudPriceSites are the price pages of the sites, the URL of the price list is found in the page
+var alibabaCloudPriceSites = map[string]struct {
+	page            string
+	priceURLPattern *regexp.Regexp
+	currency        string
+}{
+	// This is referring https://www.aliyun.com/price/ecs/ecs-pricing/zh#/?_k=zmi2qe
+	apis.SiteCN: {
+		page:            "https://www.aliyun.com/price/ecs/ecs-pricing/zh",
+		priceURLPattern: regexp.MustCompile(`https://g.alicdn.com/aliyun/ecs-price-info/[0-9.]+`),
+		currency:        currency.CNY,
+	},
+	apis.SiteIntl: {
+		page:            "https://www.alibabacloud.com/price/ecs/ecs-pricing/en",
+		priceURLPattern: regexp.MustCompile(`https://g.alicdn.com/aliyun/ecs-price-info-intl/[0-9.]+`),
+		currency:        currency.USD,
+	},
+}
+
+// getECSPrice returns the linux on-demand prices of the site by region and instance type
+func getECSPrice(site string) (map[string]map[string]float64, error) {
+	return fetchECSPrice(alibabaCloudPriceSites[site].page, alibabaCloudPriceSites[site].priceURLPattern)
+}
+
+// fetchECSPrice finds the URL of the price list in the page and returns the linux on-demand prices of the list
+func fetchECSPrice(page string, priceURLPattern *regexp.Regexp) (map[string]map[string]float64, error) {
+	baseResp, err := http.Get(page)
 	if err != nil {
 		klog.Errorf("Get ecs price failed: %v", err)
 		return nil, err
@@ -251,10 +404,7 @@ func getECSPrice() (map[string]map[string]float64, error) {
 		return nil, err
 	}
 
-	// This is referring https://www.aliyun.com/price/ecs/ecs-pricing/zh#/?_k=zmi2qe
-	pattern := `https://g.alicdn.com/aliyun/ecs-price-info/[0-9.]+`
-	re := regexp.MustCompile(pattern)
-	priceUrl := re.FindString(string(data))
+	priceUrl := priceURLPattern.FindString(string(data))
 	if priceUrl == "" {
 		klog.Errorf("Cloud not find price url")
 		return nil, fmt.Errorf("cloud not find price url")
@@ -284,34 +434,67 @@ func getECSPrice() (map[string]map[string]float64, error) {
 		klog.Errorf("Get ecs price failed: %v", err)
 		return nil, err
 	}
+	return parseECSPrice(&ecsPrice), nil
+}
 
+// parseECSPrice returns the linux prices of the price list, the key of the list is
+// {region}::{instance type}::{network type}::{os}::..., the malformed entries are skipped
+func parseECSPrice(ecsPrice *ECSPrice) map[string]map[string]float64 {
 	ret := map[string]map[string]float64{}
 	for k, v := range ecsPrice.PricingInfo {
 		parts := strings.Split(k, "::")
+		if len(parts) < 4 {
+			klog.Warningf("Skip the ecs price of the malformed key %q", k)
+			continue
+		}
 		regions := parts[0]
 		os := parts[3]
 		instanceType := parts[1]
-		if os == "linux" {
-			price, err := strconv.ParseFloat(v.Hours[0].Price, 64)
-			if err != nil {
-				klog.Errorf("Get ecs price failed: %v", err)
-				return nil, err
-			}
-			if ret[regions] == nil {
-				ret[regions] = map[string]float64{}
-			}
-			ret[regions][instanceType] = price
+		if os != "linux" {
+			continue
 		}
+		if len(v.Hours) == 0 {
+			klog.Warningf("Skip the ecs price of %q without the hourly price", k)
+			continue
+		}
+		price, err := strconv.ParseFloat(v.Hours[0].Price, 64)
+		if err != nil {
+			klog.Warningf("Skip the ecs price of %q: %v", k, err)
+			continue
+		}
+		if ret[regions] == nil {
+			ret[regions] = map[string]float64{}
+		}
+		ret[regions][instanceType] = price
 	}
 
-	return ret, nil
+	return ret
 }
 
 func (a *AlibabaCloudPriceClient) RefreshOnDemandPrice() {
-	priceInfo, err := getECSPrice()
-	if err != nil {
+	useAPI := a.config.PriceSource == AlibabaCloudPriceSourceAPI
+	// The scraped prices are the fallback of the API prices
+	priceInfo, err := getECSPrice(apis.SiteCN)
+	if err != nil && !useAPI {
 		return
 	}
+	// The international site prices are kept if they are unavailable
+	intlPriceInfo, intlErr := getECSPrice(apis.SiteIntl)
+
+	var report *apis.PriceCrossCheckReport
+	var reportMutex sync.Mutex
+	if useAPI {
+		report = &apis.PriceCrossCheckReport{
+			Time:       time.Now().UTC(),
+			Source:     AlibabaCloudPriceSourceAPI,
+			Reference:  AlibabaCloudPriceSourceScraper,
+			Tolerance:  alibabaCloudCrossCheckTolerance,
+			Mismatches: []*apis.PriceCrossCheckItem{},
+		}
+		if err != nil {
+			report.ReferenceError = err.Error()
+		}
+	}
 
 	handleFunc := func(paras ...interface{}) {
 		region := paras[0].(string)
@@ -319,20 +502,37 @@ func (a *AlibabaCloudPriceClient) RefreshOnDemandPrice() {
 		if err != nil {
 			return
 		}
+		var apiPrices map[string]float64
+		if useAPI {
+			apiPrices = a.describeOnDemandPrices(region, lo.Keys(instanceTypes))
+		}
 
-		for instanceType := range instanceTypes {
-			instanceTypes[instanceType].OnDemandPricePerHour = priceInfo[region][instanceType]
-			if _, ok := a.priceData[region]; !ok {
-				continue
-			}
-			if _, ok := a.priceData[region].InstanceTypePrices[instanceType]; !ok {
-				continue
+		a.priceData.Update(func(w *priceDataWriter) {
+			for instanceType := range instanceTypes {
+				price := priceInfo[region][instanceType]
+				if useAPI {
+					reportMutex.Lock()
+					price = crossCheckPrice(report, region, instanceType, apiPrices[instanceType], price)
+					reportMutex.Unlock()
+				}
+				instanceTypes[instanceType].OnDemandPricePerHour = price
+				if p := intlPriceInfo[region][instanceType]; p > 0 {
+					instanceTypes[instanceType].SitePrices = map[string]*apis.SitePrice{
+						apis.SiteIntl: {OnDemandPricePerHour: p, Currency: alibabaCloudPriceSites[apis.SiteIntl].currency},
+					}
+				}
+				old, ok := w.Get(region, instanceType)
+				if !ok {
+					continue
+				}
+				instanceTypes[instanceType].SpotPricePerHour = old.SpotPricePerHour
+				instanceTypes[instanceType].AlibabaCloudBilling = old.AlibabaCloudBilling
+				if intlErr != nil {
+					instanceTypes[instanceType].SitePrices = old.SitePrices
+				}
 			}
-			instanceTypes[instanceType].SpotPricePerHour = a.priceData[region].InstanceTypePrices[instanceType].SpotPricePerHour
-		}
-		a.dataMutex.Lock()
-		a.priceData[region] = &apis.RegionalInstancePrice{InstanceTypePrices: instanceTypes}
-		a.dataMutex.Unlock()
+			w.PutRegion(region, instanceTypes)
+		})
 	}
 
 	priceTask := tools.NewParallelTask(handleFunc)
@@ -343,19 +543,333 @@ func (a *AlibabaCloudPriceClient) RefreshOnDemandPrice() {
 	}
 	priceTask.Process()
 
+	if report != nil {
+		sort.Slice(report.Mismatches, func(i, j int) bool {
+			return report.Mismatches[i].Difference > report.Mismatches[j].Difference
+		})
+		if len(report.Mismatches) != 0 || report.ReferenceError != "" {
+			klog.Warningf("AlibabaCloud on-demand prices of %d of %d instance types differ from the scraped ones, reference error: %q",
+				len(report.Mismatches), report.Checked, report.ReferenceError)
+		}
+		a.crossCheckMutex.Lock()
+		a.crossCheckReport = report
+		a.crossCheckMutex.Unlock()
+	}
+
 	klog.Infof("All on-demand prices are refreshed for AlibabaCloud")
 }
 
-func (a *AlibabaCloudPriceClient) listInstanceTypes(region string) (map[string]*apis.InstanceTypePrice, error) {
+// describeOnDemandPrices returns the hourly prices of the instance types from the DescribePrice API, the
+// instance types failed to get the price are skipped
+func (a *AlibabaCloudPriceClient) describeOnDemandPrices(region string, instanceTypes []string) map[string]float64 {
 	client, err := a.createECSClient(region)
 	if err != nil {
-		return nil, err
+		return nil
 	}
 
-	zonesResp, err := client.DescribeZonesWithOptions(&ecsclient.DescribeZonesRequest{RegionId: tea.String(region)},
-		&util.RuntimeOptions{})
+	prices := make([]float64, len(instanceTypes))
+	workqueue.ParallelizeUntil(context.Background(), 5, len(instanceTypes), func(i int) {
+		_ = a.describePriceLimiter.Wait(context.Background())
+		price, err := describeInstancePrice(client, region, instanceTypes[i], 1, "Hour")
+		if err != nil {
+			klog.Errorf("Failed to describe price of instance %s in region %s:%v", instanceTypes[i], region, err)
+			return
+		}
+		prices[i] = price
+	})
+
+	ret := map[string]float64{}
+	for i, instanceType := range instanceTypes {
+		if prices[i] > 0 {
+			ret[instanceType] = prices[i]
+		}
+	}
+	return ret
+}
+
+// crossCheckPrice adds the prices to the report and returns the price to use, the reference price is used if
+// the source has no price
+func crossCheckPrice(report *apis.PriceCrossCheckReport, region, instanceType string, source, reference float64) float64 {
+	if source <= 0 {
+		if reference > 0 {
+			report.Fallbacks++
+		}
+		return reference
+	}
+	if reference <= 0 {
+		return source
+	}
+
+	report.Checked++
+	if difference := math.Abs(source-reference) / reference; difference > report.Tolerance {
+		report.Mismatches = append(report.Mismatches, &apis.PriceCrossCheckItem{
+			Region:         region,
+			InstanceType:   instanceType,
+			SourcePrice:    source,
+			ReferencePrice: reference,
+			Difference:     difference,
+		})
+	}
+	return source
+}
+
+func (a *AlibabaCloudPriceClient) CrossCheckReport() *apis.PriceCrossCheckReport {
+	a.crossCheckMutex.RLock()
+	defer a.crossCheckMutex.RUnlock()
+
+	return a.crossCheckReport
+}
+
+// alibabaCloudSubscriptionTerms are the periods of the subscription prices, hours is used to amortize the price
+var alibabaCloudSubscriptionTerms = []struct {
+	term      string
+	period    int32
+	priceUnit string
+	hours     float64
+}{
+	{term: "1mo", period: 1, priceUnit: "Month", hours: 365 * 24 / 12},
+	{term: "1yr", period: 1, priceUnit: "Year", hours: 365 * 24},
+	{term: "3yr", period: 3, priceUnit: "Year", hours: 3 * 365 * 24},
+}
+
+// alibabaCloudSavingsPlanPayModes maps the pay modes of the BSS API to the payment options
+var alibabaCloudSavingsPlanPayModes = map[string]apis.AWSEC2SPPaymentOption{
+	"total": apis.AWSEC2SPPaymentOptionAllUpfront,
+	"half":  apis.AWSEC2SPPaymentOptionPartialUpfront,
+	"zero":  apis.AWSEC2SPPaymentOptionNoUpfront,
+}
+
+// describeInstancePrice returns the price of the instance type for the whole period, the price unit is Hour for
+// pay-as-you-go, and Month or Year for subscription
+func describeInstancePrice(client *ecsclient.Client, region, instanceType string, period int32, priceUnit string) (float64, error) {
+	resp, err := client.DescribePriceWithOptions(&ecsclient.DescribePriceRequest{
+		RegionId:            tea.String(region),
+		ResourceType:        tea.String("instance"),
+		InstanceType:        tea.String(instanceType),
+		InstanceNetworkType: tea.String("vpc"),
+		Period:              tea.Int32(period),
+		PriceUnit:           tea.String(priceUnit),
+		Amount:              tea.Int32(1),
+	}, &util.RuntimeOptions{})
+	if err != nil {
+		return 0, err
+	}
+	if resp.Body == nil || resp.Body.PriceInfo == nil || resp.Body.PriceInfo.Price == nil {
+		return 0, fmt.Errorf("no price of instance %s in region %s", instanceType, region)
+	}
+
+	// The price includes the system disk, only the instance type part is used
+	price := resp.Body.PriceInfo.Price
+	if price.DetailInfos != nil {
+		for _, detail := range price.DetailInfos.DetailInfo {
+			if tea.StringValue(detail.Resource) == "instanceType" {
+				return float32ToFloat64(tea.Float32Value(detail.TradePrice)), nil
+			}
+		}
+	}
+	return float32ToFloat64(tea.Float32Value(price.TradePrice)), nil
+}
+
+// float32ToFloat64 keeps the shortest decimal representation, e.g. 0.33 instead of 0.33000001311302185
+func float32ToFloat64(v float32) float64 {
+	ret, _ := strconv.ParseFloat(strconv.FormatFloat(float64(v), 'f', -1, 32), 64)
+	return ret
+}
+
+type savingsPlanDiscount struct {
+	Region string `json:"Region"`
+	// Spec is an instance type or an instance family, empty means all instance types
+	Spec string `json:"Spec"`
+	// ContractDiscount is the ratio of the savings plan rate to the pay-as-you-go price
+	ContractDiscount float64 `json:"ContractDiscount"`
+}
+
+type querySavingsPlansDiscountResponse struct {
+	Success bool   `json:"Success"`
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+	Data    struct {
+		TotalCount int                   `json:"TotalCount"`
+		Items      []savingsPlanDiscount `json:"Items"`
+	} `json:"Data"`
+}
+
+// getSavingsPlanDiscounts returns the discounts of all regions for the savings plan type, pay mode and years
+func getSavingsPlanDiscounts(client *openapi.Client, spnType, payMode string, years int) ([]savingsPlanDiscount, error) {
+	params := &openapi.Params{
+		Action:      tea.String("QuerySavingsPlansDiscount"),
+		Version:     tea.String(alibabaCloudBSSVersion),
+		Protocol:    tea.String("HTTPS"),
+		Pathname:    tea.String("/"),
+		Method:      tea.String("POST"),
+		AuthType:    tea.String("AK"),
+		Style:       tea.String("RPC"),
+		ReqBodyType: tea.String("formData"),
+		BodyType:    tea.String("json"),
+	}
+
+	var ret []savingsPlanDiscount
+	for page := 1; ; page++ {
+		request := &openapi.OpenApiRequest{
+			Query: map[string]*string{
+				"CommodityCode": tea.String(alibabaCloudSavingsPlanCommodity),
+				"SpnType":       tea.String(spnType),
+				"PayMode":       tea.String(payMode),
+				"CycleNum":      tea.String(strconv.Itoa(years)),
+				"CycleUnit":     tea.String("Year"),
+				"PageNum":       tea.String(strconv.Itoa(page)),
+				"PageSize":      tea.String(strconv.Itoa(alibabaCloudBSSPageSize)),
+			},
+		}
+		resp, err := client.CallApi(params, request, &util.RuntimeOptions{})
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(resp["body"])
+		if err != nil {
+			return nil, err
+		}
+		var body querySavingsPlansDiscountResponse
+		if err := json.Unmarshal(data, &body); err != nil {
+			return nil, err
+		}
+		if !body.Success {
+			return nil, fmt.Errorf("failed to query savings plan discount, code: %s, message: %s", body.Code, body.Message)
+		}
+
+		ret = append(ret, body.Data.Items...)
+		if len(body.Data.Items) == 0 || page*alibabaCloudBSSPageSize >= body.Data.TotalCount {
+			break
+		}
+	}
+	return ret, nil
+}
+
+// matchSavingsPlanDiscount returns the discount of the instance type, the instance type discount is preferred
+// over the instance family one, e.g. ecs.g6.large over ecs.g6, and the family one over the one of all instance types
+func matchSavingsPlanDiscount(discounts []savingsPlanDiscount, region, instanceType string) (float64, bool) {
+	ret, matched := 0.0, -1
+	for _, d := range discounts {
+		if d.Region != region || d.ContractDiscount <= 0 {
+			continue
+		}
+		specificity := -1
+		switch {
+		case d.Spec == instanceType:
+			specificity = 2
+		case d.Spec != "" && strings.HasPrefix(instanceType, d.Spec+"."):
+			specificity = 1
+		case d.Spec == "":
+			specificity = 0
+		}
+		if specificity > matched {
+			ret, matched = d.ContractDiscount, specificity
+		}
+	}
+	return ret, matched >= 0
+}
+
+// RefreshBillingPrice refreshes the subscription prices from the ECS DescribePrice API and the savings plan rates
+// from the BSS QuerySavingsPlansDiscount API, the savings plan rates are based on the on-demand prices, so it
+// should be called after RefreshOnDemandPrice. The prices are merged per billing key, the old ones are kept if
+// they failed to refresh.
+func (a *AlibabaCloudPriceClient) RefreshBillingPrice() {
+	data := a.priceData.Load()
+	regions := lo.Keys(data)
+
+	// nil billing removes the billing key since it's no longer offered
+	billings := map[string]map[string]map[string]*apis.AlibabaCloudBilling{}
+	var mutex sync.Mutex
+	setBilling := func(region, instanceType, key string, billing *apis.AlibabaCloudBilling) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if billings[region] == nil {
+			billings[region] = map[string]map[string]*apis.AlibabaCloudBilling{}
+		}
+		if billings[region][instanceType] == nil {
+			billings[region][instanceType] = map[string]*apis.AlibabaCloudBilling{}
+		}
+		billings[region][instanceType][key] = billing
+	}
+
+	workqueue.ParallelizeUntil(context.Background(), alibabaCloudDescribePriceRegions, len(regions), func(i int) {
+		region := regions[i]
+		client, err := a.createECSClient(region)
+		if err != nil {
+			klog.Errorf("Failed to create ECS client in region %s:%v", region, err)
+			return
+		}
+		for instanceType := range data[region].InstanceTypePrices {
+			for _, term := range alibabaCloudSubscriptionTerms {
+				_ = a.describePriceLimiter.Wait(context.Background())
+				price, err := describeInstancePrice(client, region, instanceType, term.period, term.priceUnit)
+				if err != nil {
+					klog.Errorf("Failed to get %s subscription price of instance %s in region %s:%v", term.term, instanceType, region, err)
+					continue
+				}
+				key := fmt.Sprintf("%s/%s", apis.AlibabaCloudBillingSubscription, term.term)
+				if price <= 0 {
+					setBilling(region, instanceType, key, nil)
+					continue
+				}
+				setBilling(region, instanceType, key, &apis.AlibabaCloudBilling{Rate: price / term.hours, UpfrontFee: price})
+			}
+		}
+	})
+
+	if client, err := a.createBSSClient(); err == nil {
+		for _, spnType := range []string{apis.AlibabaCloudSavingsPlanUniversal, apis.AlibabaCloudSavingsPlanECS} {
+			for payMode, paymentOption := range alibabaCloudSavingsPlanPayModes {
+				for _, years := range []int{1, 3} {
+					discounts, err := getSavingsPlanDiscounts(client, spnType, payMode, years)
+					if err != nil {
+						klog.Errorf("Failed to get %s savings plan discounts:%v", spnType, err)
+						continue
+					}
+					key := fmt.Sprintf("%s/%s/%dyr/%s", apis.AlibabaCloudBillingSavingsPlan, spnType, years, paymentOption)
+					for region, d := range data {
+						for instanceType, price := range d.InstanceTypePrices {
+							discount, ok := matchSavingsPlanDiscount(discounts, region, instanceType)
+							if !ok || price.OnDemandPricePerHour <= 0 {
+								setBilling(region, instanceType, key, nil)
+								continue
+							}
+							setBilling(region, instanceType, key, &apis.AlibabaCloudBilling{Rate: price.OnDemandPricePerHour * discount})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	a.priceData.Update(func(w *priceDataWriter) {
+		for region, types := range billings {
+			for instanceType, billing := range types {
+				price, ok := w.Mutable(region, instanceType)
+				if !ok {
+					continue
+				}
+				if price.AlibabaCloudBilling == nil {
+					price.AlibabaCloudBilling = map[string]apis.AlibabaCloudBilling{}
+				}
+				for key, b := range billing {
+					if b == nil {
+						delete(price.AlibabaCloudBilling, key)
+						continue
+					}
+					price.AlibabaCloudBilling[key] = *b
+				}
+			}
+		}
+	})
+
+	klog.Infof("All subscription and savings plan prices are refreshed for AlibabaCloud")
+}
+
+func (a *AlibabaCloudPriceClient) listInstanceTypes(region string) (map[string]*apis.InstanceTypePrice, error) {
+	client, err := a.createECSClient(region)
 	if err != nil {
-		klog.Errorf("Failed to list zones in region %s:%v", region, err)
 		return nil, err
 	}
 
@@ -377,35 +891,99 @@ func (a *AlibabaCloudPriceClient) listInstanceTypes(region string) (map[string]*
 		return nil, err
 	}
 
+	availability := extractZoneAvailability(availableTypesResp.Body)
+
 	ret := map[string]*apis.InstanceTypePrice{}
 	for _, item := range typesResp.Body.InstanceTypes.InstanceType {
-		if !isSupportedResource(tea.StringValue(item.InstanceTypeId),
-			availableTypesResp.Body.AvailableZones.AvailableZone[0].AvailableResources.AvailableResource[0].SupportedResources) {
+		zones, ok := availability[tea.StringValue(item.InstanceTypeId)]
+		if !ok {
 			continue
 		}
+		available := lo.Filter(lo.Keys(zones), func(zone string, _ int) bool {
+			return zones[zone].Available
+		})
+		sort.Strings(available)
 		ret[tea.StringValue(item.InstanceTypeId)] = &apis.InstanceTypePrice{
-			Arch:   extractECSArch(tea.ToString(item.CpuArchitecture)),
-			VCPU:   float64(tea.Int32Value(item.CpuCoreCount)),
-			Memory: float64(tea.Float32Value(item.MemorySize)),
-			GPU:    float64(tea.Int32Value(item.GPUAmount)),
-			Zones: lo.Map(zonesResp.Body.Zones.Zone, func(item *ecsclient.DescribeZonesResponseBodyZonesZone, index int) string {
-				return tea.StringValue(item.ZoneId)
-			}),
+			Arch:             extractECSArch(tea.ToString(item.CpuArchitecture)),
+			VCPU:             float64(tea.Int32Value(item.CpuCoreCount)),
+			Memory:           float64(tea.Float32Value(item.MemorySize)),
+			GPU:              float64(tea.Int32Value(item.GPUAmount)),
+			Currency:         PriceCurrency(apis.AlibabaCloudProvider, region),
+			Zones:            available,
+			ZoneAvailability: zones,
+			Spec:             extractECSSpec(item),
 		}
 	}
 
 	return ret, nil
 }
 
-func isSupportedResource(instanceType string,
-	supportedResource *ecsclient.DescribeAvailableResourceResponseBodyAvailableZonesAvailableZoneAvailableResourcesAvailableResourceSupportedResources) bool {
-	for _, i := range supportedResource.SupportedResource {
-		if tea.StringValue(i.Value) == instanceType {
-			return true
+// extractECSSpec returns the detailed specification of the ECS instance type
+func extractECSSpec(item *ecsclient.DescribeInstanceTypesResponseBodyInstanceTypesInstanceType) *apis.InstanceTypeSpec {
+	spec := &apis.InstanceTypeSpec{
+		// The bandwidth is in Kbit/s
+		NetworkBandwidthGbps:      float64(tea.Int32Value(item.InstanceBandwidthRx)) / 1000 / 1000,
+		MaxNetworkInterfaces:      int(tea.Int32Value(item.EniQuantity)),
+		IPv4AddressesPerInterface: int(tea.Int32Value(item.EniPrivateIpAddressQuantity)),
+		LocalStorage:              float64(tea.Int32Value(item.LocalStorageAmount)) * float64(tea.Int64Value(item.LocalStorageCapacity)),
+		Burstable:                 tea.StringValue(item.InstanceFamilyLevel) == "CreditEntryLevel",
+		GPUModel:                  tea.StringValue(item.GPUSpec),
+		// The GPU memory is of each GPU
+		GPUMemory: float64(tea.Float32Value(item.GPUMemorySize)) * float64(tea.Int32Value(item.GPUAmount)),
+	}
+	if spec.LocalStorage > 0 {
+		// local_hdd_pro are SATA HDDs and local_ssd_pro are NVMe SSDs
+		spec.LocalStorageType = "ssd"
+		if strings.Contains(tea.StringValue(item.LocalStorageCategory), "hdd") {
+			spec.LocalStorageType = "hdd"
+		} else {
+			spec.LocalStorageNVMe = true
+		}
+	}
+
+	return spec
+}
+
+// extractZoneAvailability returns the availability of the instance types by zone from all the zones of the
+// DescribeAvailableResource response, the status of the zone is used if the instance type has none
+func extractZoneAvailability(body *ecsclient.DescribeAvailableResourceResponseBody) map[string]map[string]apis.ZoneAvailability {
+	ret := map[string]map[string]apis.ZoneAvailability{}
+	if body == nil || body.AvailableZones == nil {
+		return ret
+	}
+	for _, zone := range body.AvailableZones.AvailableZone {
+		if zone.AvailableResources == nil {
+			continue
+		}
+		for _, resource := range zone.AvailableResources.AvailableResource {
+			if resource.SupportedResources == nil {
+				continue
+			}
+			for _, supported := range resource.SupportedResources.SupportedResource {
+				status := lo.CoalesceOrEmpty(tea.StringValue(supported.Status), tea.StringValue(zone.Status))
+				category := lo.CoalesceOrEmpty(tea.StringValue(supported.StatusCategory), tea.StringValue(zone.StatusCategory))
+				instanceType := tea.StringValue(supported.Value)
+				if _, ok := ret[instanceType]; !ok {
+					ret[instanceType] = map[string]apis.ZoneAvailability{}
+				}
+				ret[instanceType][tea.StringValue(zone.ZoneId)] = apis.ZoneAvailability{
+					Available:   isAlibabaCloudResourceAvailable(status, category),
+					StockStatus: category,
+				}
+			}
 		}
 	}
 
-	return false
+	return ret
+}
+
+// isAlibabaCloudResourceAvailable returns whether the resource can be created, the resources out of stock are
+// sold out, the ones without a status are treated as available
+func isAlibabaCloudResourceAvailable(status, category string) bool {
+	if status != "" && status != alibabaCloudResourceAvailable {
+		return false
+	}
+	return category != alibabaCloudStockWithout && category != alibabaCloudStockClosedWithout
 }
 
 func extractECSArch(unFormatedArch string) string {
@@ -448,15 +1026,30 @@ func (a *AlibabaCloudPriceClient) initialRegions() error {
 	return nil
 }
 
-func (a *AlibabaCloudPriceClient) createECSClient(region string) (*ecsclient.Client, error) {
+func (a *AlibabaCloudPriceClient) openapiConfig(region string) (*openapi.Config, error) {
 	// Take one ak/sk from pool
-	pick := rand.Intn(len(a.akskPool))
-	ak, sk := a.akskPool[pick].AK, a.akskPool[pick].SK
+	ak, sk := pickAKSK(a.config.AKSKPool)
 	config := &openapi.Config{
 		AccessKeyId:     tea.String(ak),
 		AccessKeySecret: tea.String(sk),
 		RegionId:        tea.String(region),
 	}
+	if a.config.Endpoint != "" {
+		endpoint, err := url.Parse(a.config.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint %s: %w", a.config.Endpoint, err)
+		}
+		config.Endpoint = tea.String(endpoint.Host)
+		config.Protocol = tea.String(endpoint.Scheme)
+	}
+	return config, nil
+}
+
+func (a *AlibabaCloudPriceClient) createECSClient(region string) (*ecsclient.Client, error) {
+	config, err := a.openapiConfig(region)
+	if err != nil {
+		return nil, err
+	}
 	client, err := ecsclient.NewClient(config)
 	if err != nil {
 		klog.Errorf("Failed to create ecs client:%v", err)
@@ -465,35 +1058,43 @@ func (a *AlibabaCloudPriceClient) createECSClient(region string) (*ecsclient.Cli
 	return client, nil
 }
 
-func (a *AlibabaCloudPriceClient) ListRegionsInstancesPrice() map[string]*apis.RegionalInstancePrice {
-	a.dataMutex.RLock()
-	defer a.dataMutex.RUnlock()
-
-	ret := make(map[string]*apis.RegionalInstancePrice)
-	for k, v := range a.priceData {
-		ret[k] = v.DeepCopy()
+func (a *AlibabaCloudPriceClient) createBSSClient() (*openapi.Client, error) {
+	// BSS is a global service served in cn-hangzhou
+	config, err := a.openapiConfig("cn-hangzhou")
+	if err != nil {
+		return nil, err
 	}
-	return ret
+	if a.config.Endpoint == "" {
+		config.Endpoint = tea.String(alibabaCloudBSSEndpoint)
+	}
+	client, err := openapi.NewClient(config)
+	if err != nil {
+		klog.Errorf("Failed to create bss client:%v", err)
+		return nil, err
+	}
+	return client, nil
 }
 
-func (a *AlibabaCloudPriceClient) ListInstancesPrice(region string) *map[string]apis.RegionalInstancePrice {
-	a.dataMutex.RLock()
-	defer a.dataMutex.RUnlock()
+func (a *AlibabaCloudPriceClient) ListRegions() []string {
+	return sortedRegions(a.priceData.Load())
+}
 
-	d, ok := a.priceData[region]
+func (a *AlibabaCloudPriceClient) ListRegionsInstancesPrice() map[string]*apis.RegionalInstancePrice {
+	return a.priceData.Load()
+}
+
+func (a *AlibabaCloudPriceClient) ListInstancesPrice(region string) *map[string]apis.RegionalInstancePrice {
+	d, ok := a.priceData.Load()[region]
 	if !ok {
 		return nil
 	}
 	return &map[string]apis.RegionalInstancePrice{
-		region: *d.DeepCopy(),
+		region: *d,
 	}
 }
 
 func (a *AlibabaCloudPriceClient) GetInstancePrice(region, instanceType string) *apis.InstanceTypePrice {
-	a.dataMutex.RLock()
-	defer a.dataMutex.RUnlock()
-
-	regionData, ok := a.priceData[region]
+	regionData, ok := a.priceData.Load()[region]
 	if !ok {
 		return nil
 	}
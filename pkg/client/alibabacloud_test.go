@@ -0,0 +1,405 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	ecsclient "github.com/alibabacloud-go/ecs-20140526/v4/client"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+func TestMatchSavingsPlanDiscount(t *testing.T) {
+	discounts := []savingsPlanDiscount{
+		{Region: "cn-hangzhou", ContractDiscount: 0.7},
+		{Region: "cn-hangzhou", Spec: "ecs.g6", ContractDiscount: 0.6},
+		{Region: "cn-hangzhou", Spec: "ecs.g6.large", ContractDiscount: 0.5},
+		{Region: "cn-hangzhou", Spec: "ecs.c6", ContractDiscount: 0},
+		{Region: "cn-beijing", Spec: "ecs.g6", ContractDiscount: 0.65},
+	}
+	tests := []struct {
+		region       string
+		instanceType string
+		want         float64
+		wantOK       bool
+	}{
+		{region: "cn-hangzhou", instanceType: "ecs.g6.large", want: 0.5, wantOK: true},
+		{region: "cn-hangzhou", instanceType: "ecs.g6.xlarge", want: 0.6, wantOK: true},
+		// The family is matched by the prefix with the dot, so ecs.g6e is not in ecs.g6
+		{region: "cn-hangzhou", instanceType: "ecs.g6e.large", want: 0.7, wantOK: true},
+		// The discount without rate is skipped
+		{region: "cn-hangzhou", instanceType: "ecs.c6.large", want: 0.7, wantOK: true},
+		{region: "cn-beijing", instanceType: "ecs.g6.large", want: 0.65, wantOK: true},
+		{region: "cn-beijing", instanceType: "ecs.c6.large"},
+		{region: "cn-shanghai", instanceType: "ecs.g6.large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.region+"/"+tt.instanceType, func(t *testing.T) {
+			got, ok := matchSavingsPlanDiscount(discounts, tt.region, tt.instanceType)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("got %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGetSavingsPlanDiscounts(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("x-acs-action") != "QuerySavingsPlansDiscount" || r.Form.Get("SpnType") != "universal" ||
+			r.Form.Get("CycleNum") != "1" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		page := r.Form.Get("PageNum")
+		pages = append(pages, page)
+
+		resp := querySavingsPlansDiscountResponse{Success: true}
+		// The last page is partial
+		resp.Data.TotalCount = alibabaCloudBSSPageSize + 1
+		n := alibabaCloudBSSPageSize
+		if page == "2" {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			resp.Data.Items = append(resp.Data.Items, savingsPlanDiscount{
+				Region:           "cn-hangzhou",
+				Spec:             fmt.Sprintf("ecs.g%s.%d", page, i),
+				ContractDiscount: 0.5,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	a := &AlibabaCloudPriceClient{config: AlibabaCloudConfig{
+		AKSKPool: []AKSKPair{{AK: "ak", SK: "sk"}},
+		Endpoint: server.URL,
+	}}
+	client, err := a.createBSSClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	discounts, err := getSavingsPlanDiscounts(client, "universal", "total", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 2 || pages[0] != "1" || pages[1] != "2" {
+		t.Errorf("got pages %v, want 1 and 2", pages)
+	}
+	if len(discounts) != alibabaCloudBSSPageSize+1 || discounts[alibabaCloudBSSPageSize].Spec != "ecs.g2.0" {
+		t.Errorf("got %d discounts, want the items of both pages", len(discounts))
+	}
+}
+
+func TestGetSavingsPlanDiscountsFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"Success": false, "Code": "NotApplicable", "Message": "the site is not supported"}`)
+	}))
+	defer server.Close()
+
+	a := &AlibabaCloudPriceClient{config: AlibabaCloudConfig{
+		AKSKPool: []AKSKPair{{AK: "ak", SK: "sk"}},
+		Endpoint: server.URL,
+	}}
+	client, err := a.createBSSClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getSavingsPlanDiscounts(client, "universal", "total", 1); err == nil {
+		t.Errorf("got no error, want the failure of the response")
+	}
+}
+
+func TestCrossCheckPrice(t *testing.T) {
+	tests := []struct {
+		name              string
+		source, reference float64
+		want              float64
+		wantReport        apis.PriceCrossCheckReport
+	}{
+		{
+			name:   "same price",
+			source: 1, reference: 1,
+			want:       1,
+			wantReport: apis.PriceCrossCheckReport{Tolerance: 0.01, Checked: 1},
+		},
+		{
+			name:   "within the tolerance",
+			source: 1.005, reference: 1,
+			want:       1.005,
+			wantReport: apis.PriceCrossCheckReport{Tolerance: 0.01, Checked: 1},
+		},
+		{
+			name:   "mismatch",
+			source: 1.5, reference: 1,
+			want: 1.5,
+			wantReport: apis.PriceCrossCheckReport{Tolerance: 0.01, Checked: 1, Mismatches: []*apis.PriceCrossCheckItem{
+				{Region: "cn-hangzhou", InstanceType: "ecs.g6.large", SourcePrice: 1.5, ReferencePrice: 1, Difference: 0.5},
+			}},
+		},
+		{
+			name:   "fallback",
+			source: 0, reference: 1,
+			want:       1,
+			wantReport: apis.PriceCrossCheckReport{Tolerance: 0.01, Fallbacks: 1},
+		},
+		{
+			name:   "no reference",
+			source: 1, reference: 0,
+			want:       1,
+			wantReport: apis.PriceCrossCheckReport{Tolerance: 0.01},
+		},
+		{name: "no price", wantReport: apis.PriceCrossCheckReport{Tolerance: 0.01}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &apis.PriceCrossCheckReport{Tolerance: 0.01}
+			got := crossCheckPrice(report, "cn-hangzhou", "ecs.g6.large", tt.source, tt.reference)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(*report, tt.wantReport) {
+				t.Errorf("got report %+v, want %+v", *report, tt.wantReport)
+			}
+		})
+	}
+}
+
+func TestIsAlibabaCloudResourceAvailable(t *testing.T) {
+	tests := []struct {
+		status, category string
+		want             bool
+	}{
+		{status: "Available", category: "WithStock", want: true},
+		{status: "Available", category: "ClosedWithStock", want: true},
+		{status: "Available", category: "WithoutStock"},
+		{status: "Available", category: "ClosedWithoutStock"},
+		{status: "SoldOut", category: "WithStock"},
+		// The resources without a status are treated as available
+		{want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status+"/"+tt.category, func(t *testing.T) {
+			if got := isAlibabaCloudResourceAvailable(tt.status, tt.category); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractZoneAvailability(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want map[string]map[string]apis.ZoneAvailability
+	}{
+		{
+			name: "status of the instance types",
+			body: `{"AvailableZones": {"AvailableZone": [{"ZoneId": "cn-hangzhou-h", "Status": "Available", "StatusCategory": "WithStock",
+  "AvailableResources": {"AvailableResource": [{"Type": "InstanceType", "SupportedResources": {"SupportedResource": [
+    {"Value": "ecs.g6.large", "Status": "Available", "StatusCategory": "WithStock"},
+    {"Value": "ecs.g6.xlarge", "Status": "SoldOut", "StatusCategory": "WithoutStock"}
+  ]}}]}}]}}`,
+			want: map[string]map[string]apis.ZoneAvailability{
+				"ecs.g6.large":  {"cn-hangzhou-h": {Available: true, StockStatus: "WithStock"}},
+				"ecs.g6.xlarge": {"cn-hangzhou-h": {StockStatus: "WithoutStock"}},
+			},
+		},
+		{
+			name: "status of the zone",
+			body: `{"AvailableZones": {"AvailableZone": [
+  {"ZoneId": "cn-hangzhou-h", "Status": "Available", "StatusCategory": "ClosedWithoutStock",
+    "AvailableResources": {"AvailableResource": [{"SupportedResources": {"SupportedResource": [{"Value": "ecs.g6.large"}]}}]}},
+  {"ZoneId": "cn-hangzhou-i", "Status": "Available", "StatusCategory": "WithStock",
+    "AvailableResources": {"AvailableResource": [{"SupportedResources": {"SupportedResource": [{"Value": "ecs.g6.large"}]}}]}}
+]}}`,
+			want: map[string]map[string]apis.ZoneAvailability{
+				"ecs.g6.large": {
+					"cn-hangzhou-h": {StockStatus: "ClosedWithoutStock"},
+					"cn-hangzhou-i": {Available: true, StockStatus: "WithStock"},
+				},
+			},
+		},
+		{
+			name: "no resources",
+			body: `{"AvailableZones": {"AvailableZone": [{"ZoneId": "cn-hangzhou-h"}, {"ZoneId": "cn-hangzhou-i", "AvailableResources": {"AvailableResource": [{}]}}]}}`,
+			want: map[string]map[string]apis.ZoneAvailability{},
+		},
+		{name: "no zones", body: `{}`, want: map[string]map[string]apis.ZoneAvailability{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &ecsclient.DescribeAvailableResourceResponseBody{}
+			if err := json.Unmarshal([]byte(tt.body), body); err != nil {
+				t.Fatal(err)
+			}
+			if got := extractZoneAvailability(body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractECSSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		item string
+		want *apis.InstanceTypeSpec
+	}{
+		{
+			name: "general purpose",
+			item: `{"InstanceTypeId": "ecs.g7.large", "InstanceBandwidthRx": 2000000, "EniQuantity": 3, "EniPrivateIpAddressQuantity": 6,
+  "InstanceFamilyLevel": "EnterpriseLevel"}`,
+			want: &apis.InstanceTypeSpec{NetworkBandwidthGbps: 2, MaxNetworkInterfaces: 3, IPv4AddressesPerInterface: 6},
+		},
+		{
+			name: "burstable",
+			item: `{"InstanceTypeId": "ecs.t6-c1m1.large", "InstanceFamilyLevel": "CreditEntryLevel"}`,
+			want: &apis.InstanceTypeSpec{Burstable: true},
+		},
+		{
+			name: "local ssd",
+			item: `{"InstanceTypeId": "ecs.i3.2xlarge", "LocalStorageAmount": 1, "LocalStorageCapacity": 1788, "LocalStorageCategory": "local_ssd_pro"}`,
+			want: &apis.InstanceTypeSpec{LocalStorage: 1788, LocalStorageType: "ssd", LocalStorageNVMe: true},
+		},
+		{
+			name: "local hdd",
+			item: `{"InstanceTypeId": "ecs.d1ne.2xlarge", "LocalStorageAmount": 4, "LocalStorageCapacity": 5500, "LocalStorageCategory": "local_hdd_pro"}`,
+			want: &apis.InstanceTypeSpec{LocalStorage: 22000, LocalStorageType: "hdd"},
+		},
+		{
+			// The GPU memory is reported for each GPU
+			name: "gpu",
+			item: `{"InstanceTypeId": "ecs.gn6i-c8g1.2xlarge", "GPUAmount": 2, "GPUSpec": "NVIDIA T4", "GPUMemorySize": 16}`,
+			want: &apis.InstanceTypeSpec{GPUModel: "NVIDIA T4", GPUMemory: 32},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &ecsclient.DescribeInstanceTypesResponseBodyInstanceTypesInstanceType{}
+			if err := json.Unmarshal([]byte(tt.item), item); err != nil {
+				t.Fatal(err)
+			}
+			if got := extractECSSpec(item); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlibabaCloudPriceURLPattern(t *testing.T) {
+	tests := []struct {
+		site string
+		page string
+		want string
+	}{
+		{site: apis.SiteCN, page: "testdata/alibabacloud-price-page-cn.html", want: "https://g.alicdn.com/aliyun/ecs-price-info/2.0.219"},
+		{site: apis.SiteIntl, page: "testdata/alibabacloud-price-page-intl.html", want: "https://g.alicdn.com/aliyun/ecs-price-info-intl/1.0.87"},
+		// The pattern of a site must not match the page of the other site
+		{site: apis.SiteCN, page: "testdata/alibabacloud-price-page-intl.html", want: ""},
+		{site: apis.SiteIntl, page: "testdata/alibabacloud-price-page-cn.html", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s", tt.site, tt.page), func(t *testing.T) {
+			data, err := os.ReadFile(tt.page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := alibabaCloudPriceSites[tt.site].priceURLPattern.FindString(string(data)); got != tt.want {
+				t.Errorf("got price URL %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchECSPrice(t *testing.T) {
+	priceList, err := os.ReadFile("testdata/alibabacloud-instance-price.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/price/ecs/ecs-pricing/en", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `<script src="%s/aliyun/ecs-price-info-intl/1.0.87/index.js"></script>`, server.URL)
+	})
+	mux.HandleFunc("/aliyun/ecs-price-info-intl/1.0.87/price/download/instancePrice.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(priceList)
+	})
+
+	tests := []struct {
+		name    string
+		page    string
+		want    map[string]map[string]float64
+		wantErr bool
+	}{
+		{
+			name: "malformed entries are skipped",
+			page: "/price/ecs/ecs-pricing/en",
+			want: map[string]map[string]float64{
+				"cn-hangzhou": {"ecs.g6.large": 0.39},
+				"cn-beijing":  {"ecs.c6.xlarge": 0.66},
+			},
+		},
+		{
+			name:    "no price URL in the page",
+			page:    "/aliyun/ecs-price-info-intl/1.0.87/price/download/instancePrice.json",
+			wantErr: true,
+		},
+	}
+	pattern := regexp.MustCompile(regexp.QuoteMeta(server.URL) + `/aliyun/ecs-price-info-intl/[0-9.]+`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fetchECSPrice(server.URL+tt.page, pattern)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPutSpotPrices(t *testing.T) {
+	billing := map[string]apis.AlibabaCloudBilling{"subscription/1yr": {Rate: 0.5, UpfrontFee: 4380}}
+	a := &AlibabaCloudPriceClient{priceData: newAtomicPriceData(apis.AlibabaCloudProvider, map[string]*apis.RegionalInstancePrice{
+		"cn-hangzhou": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+			"ecs.g6.large":  {OnDemandPricePerHour: 0.8, AlibabaCloudBilling: billing, SpotPricePerHour: map[string]float64{"cn-hangzhou-h": 0.2}},
+			"ecs.g6.xlarge": {OnDemandPricePerHour: 1.6, SpotPricePerHour: map[string]float64{"cn-hangzhou-h": 0.4}},
+		}},
+	}, time.Time{})}
+	a.putSpotPrices([]RegionalSpotInstancePriceSplit{
+		{Region: "cn-hangzhou", InstanceType: "ecs.g6.large", Info: &apis.InstanceTypePrice{
+			VCPU: 2, SpotPricePerHour: map[string]float64{"cn-hangzhou-h": 0.15}}},
+		{Region: "cn-hangzhou", InstanceType: "ecs.g6.xlarge", Info: &apis.InstanceTypePrice{VCPU: 4}},
+	}, []bool{false, true})
+
+	prices := a.priceData.Load()["cn-hangzhou"].InstanceTypePrices
+	large := prices["ecs.g6.large"]
+	if large.VCPU != 2 || large.OnDemandPricePerHour != 0.8 || !reflect.DeepEqual(large.AlibabaCloudBilling, billing) {
+		t.Errorf("got ecs.g6.large %+v, want the other prices kept", large)
+	}
+	if want := map[string]float64{"cn-hangzhou-h": 0.15}; !reflect.DeepEqual(large.SpotPricePerHour, want) {
+		t.Errorf("got spot prices %v of ecs.g6.large, want %v", large.SpotPricePerHour, want)
+	}
+	// The old spot prices are kept if they failed to refresh
+	xlarge := prices["ecs.g6.xlarge"]
+	if want := map[string]float64{"cn-hangzhou-h": 0.4}; xlarge.VCPU != 4 || !reflect.DeepEqual(xlarge.SpotPricePerHour, want) {
+		t.Errorf("got ecs.g6.xlarge %+v, want the spot prices %v", xlarge, want)
+	}
+}
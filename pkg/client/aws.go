@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,8 +25,18 @@ import (
 	"k8s.io/klog"
 
 	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/storage"
 )
 
+const awsService = "ec2"
+
+// awsDefaultSpotAdvisorEndpoint is the dataset of the Spot Instance Advisor, https://aws.amazon.com/ec2/spot/instance-advisor/
+const awsDefaultSpotAdvisorEndpoint = "https://spot-bid-advisor.s3.amazonaws.com/spot-advisor-data.json"
+
+func init() {
+	RegisterProviderFactory(apis.AWSCloudProvider, awsService, newAWSPriceProvider)
+}
+
 type PriceItem struct {
 	Product struct {
 		Attributes struct {
@@ -39,107 +52,248 @@ type PriceItem struct {
 				PricePerUnit map[string]string `json:"pricePerUnit"`
 			} `json:"priceDimensions"`
 		} `json:"onDemand"`
+		Reserved map[string]struct {
+			PriceDimensions map[string]struct {
+				// Unit is Quantity for the upfront fee and Hrs for the recurring fee
+				Unit         string            `json:"unit"`
+				PricePerUnit map[string]string `json:"pricePerUnit"`
+			} `json:"priceDimensions"`
+			TermAttributes struct {
+				LeaseContractLength string `json:"LeaseContractLength"`
+				OfferingClass       string `json:"OfferingClass"`
+				PurchaseOption      string `json:"PurchaseOption"`
+			} `json:"termAttributes"`
+		} `json:"reserved"`
 	} `json:"terms"`
 }
 
+// AWSConfig contains the settings of the AWS price client
+type AWSConfig struct {
+	GlobalAK string
+	GlobalSK string
+	CNAK     string
+	CNSK     string
+	// SpotAdvisorEndpoint is the URL of the Spot Instance Advisor dataset, default to the public one
+	SpotAdvisorEndpoint string
+	// SpotPlacementScoreTargets are the instance types and the target capacities to get the spot placement
+	// scores of, the scores are not refreshed if it's empty
+	SpotPlacementScoreTargets []AWSSpotPlacementScoreTarget
+}
+
+// AWSSpotPlacementScoreTarget is an instance type and the number of instances to get the spot placement scores of
+type AWSSpotPlacementScoreTarget struct {
+	InstanceType   string
+	TargetCapacity int32
+}
+
 type AWSPriceClient struct {
-	globalAK string
-	globalSK string
-	cnAK     string
-	cnSK     string
+	config AWSConfig
 
+	httpClient     *http.Client
 	triggerChannel chan apis.RegionTypeKey
+	store          storage.Store
+	spotHistory    storage.SpotHistoryStore
 
-	dataMutex sync.Mutex
-	priceData map[string]*apis.RegionalInstancePrice
+	priceData *atomicPriceData
 }
 
-func NewAWSPriceClient(globalAK, globalSK, cnAK, cnSK string, initialSpotUpdate bool) (*AWSPriceClient, error) {
-	data, err := file.ReadFile("builtin-data/aws_price.json")
+func NewAWSPriceClient(config AWSConfig, store storage.Store, spotHistory storage.SpotHistoryStore, initialSpotUpdate bool) (*AWSPriceClient, error) {
+	if config.SpotAdvisorEndpoint == "" {
+		config.SpotAdvisorEndpoint = awsDefaultSpotAdvisorEndpoint
+	}
+
+	priceData, updatedAt, err := loadPriceData(store, apis.AWSCloudProvider)
 	if err != nil {
 		return nil, err
 	}
 
 	client := &AWSPriceClient{
-		globalAK:       globalAK,
-		globalSK:       globalSK,
-		cnAK:           cnAK,
-		cnSK:           cnSK,
+		config:         config,
+		httpClient:     &http.Client{Timeout: time.Minute},
 		triggerChannel: make(chan apis.RegionTypeKey, 100),
-		priceData:      map[string]*apis.RegionalInstancePrice{},
-	}
-	if err := json.Unmarshal(data, &client.priceData); err != nil {
-		return nil, err
+		store:          store,
+		spotHistory:    spotHistory,
+		priceData:      newAtomicPriceData(apis.AWSCloudProvider, priceData, updatedAt),
 	}
 
 	if initialSpotUpdate {
 		client.refreshSpotPrices("", "")
+		persistPriceData(client.store, client)
+		recordSpotHistory(client.spotHistory, client)
 	}
 
 	return client, nil
 }
 
+func newAWSPriceProvider(opts *ProviderOptions) (PriceProvider, error) {
+	config := AWSConfig{
+		GlobalAK:            os.Getenv(apis.AWSGlobalAKEnv),
+		GlobalSK:            os.Getenv(apis.AWSGlobalSKEnv),
+		CNAK:                os.Getenv(apis.AWSCNAKEnv),
+		CNSK:                os.Getenv(apis.AWSCNSKEnv),
+		SpotAdvisorEndpoint: os.Getenv(apis.AWSSpotAdvisorEndpointEnv),
+	}
+	if config.GlobalAK == "" {
+		return nil, fmt.Errorf("aws global access key is not set: %w", ErrMissingCredentials)
+	}
+	if config.GlobalSK == "" {
+		return nil, fmt.Errorf("aws global secret key is not set: %w", ErrMissingCredentials)
+	}
+	if config.CNAK == "" {
+		return nil, fmt.Errorf("aws china access key is not set: %w", ErrMissingCredentials)
+	}
+	if config.CNSK == "" {
+		return nil, fmt.Errorf("aws china secret key is not set: %w", ErrMissingCredentials)
+	}
+	targets, err := parseAWSSpotPlacementScoreTargets(os.Getenv(apis.AWSSpotPlacementScoreTargetsEnv))
+	if err != nil {
+		return nil, err
+	}
+	config.SpotPlacementScoreTargets = targets
+
+	return NewAWSPriceClient(config, opts.Store, opts.SpotHistory, opts.InitialSpotUpdate)
+}
+
+// parseAWSSpotPlacementScoreTargets parses the targets in the format of <instance type>:<capacity>,...
+func parseAWSSpotPlacementScoreTargets(s string) ([]AWSSpotPlacementScoreTarget, error) {
+	var ret []AWSSpotPlacementScoreTarget
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		instanceType, capacity, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid spot placement score target %s", item)
+		}
+		n, err := strconv.ParseInt(capacity, 10, 32)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid target capacity of spot placement score target %s", item)
+		}
+		ret = append(ret, AWSSpotPlacementScoreTarget{InstanceType: instanceType, TargetCapacity: int32(n)})
+	}
+	return ret, nil
+}
+
+func (a *AWSPriceClient) Name() string {
+	return apis.AWSCloudProvider
+}
+
+func (a *AWSPriceClient) Service() string {
+	return awsService
+}
+
 func (a *AWSPriceClient) Run(ctx context.Context) {
+	// The zones are only set from the zone offerings
+	a.RefreshZoneOfferings("", "")
+	// The spot interruptions and placement scores aren't refreshed for the missed instance types
+	a.RefreshSpotInterruptions("", "")
+	a.RefreshSpotPlacementScores("")
+	persistPriceData(a.store, a)
+
 	odTicker := time.NewTicker(time.Hour * 24 * 7)
 	defer odTicker.Stop()
 
 	spotTicker := time.NewTicker(time.Minute * 30)
 	defer spotTicker.Stop()
 
+	zoneTicker := time.NewTicker(time.Hour * 24)
+	defer zoneTicker.Stop()
+
+	spotScoreTicker := time.NewTicker(time.Hour * 6)
+	defer spotScoreTicker.Stop()
+
 	for {
 		select {
 		case <-odTicker.C:
 			a.RefreshOnDemandPrice("", "")
 			a.RefreshSavingsPlanPrice("", "")
+			a.RefreshInstanceTypeSpecs("", "")
+			// The new instance types have no zones until the zone offerings are refreshed
+			a.RefreshZoneOfferings("", "")
+			persistPriceData(a.store, a)
 		case <-spotTicker.C:
 			a.refreshSpotPrices("", "")
+			persistPriceData(a.store, a)
+			recordSpotHistory(a.spotHistory, a)
+		case <-zoneTicker.C:
+			a.RefreshZoneOfferings("", "")
+			persistPriceData(a.store, a)
+		case <-spotScoreTicker.C:
+			a.RefreshSpotInterruptions("", "")
+			a.RefreshSpotPlacementScores("")
+			persistPriceData(a.store, a)
 		case <-ctx.Done():
 			return
 		case k := <-a.triggerChannel:
-			a.RefreshOnDemandPrice(k.Region, k.InstanceType)
-			a.RefreshSavingsPlanPrice(k.Region, k.InstanceType)
-			a.refreshSpotPrices(k.Region, k.InstanceType)
+			a.Refresh(k.Region, k.InstanceType)
 		}
 	}
 }
 
-func (a *AWSPriceClient) putSpotPriceData(region string, priceData []types.SpotPrice) {
-	a.dataMutex.Lock()
-	defer a.dataMutex.Unlock()
+func (a *AWSPriceClient) Refresh(region, instanceType string) {
+	a.RefreshOnDemandPrice(region, instanceType)
+	a.RefreshSavingsPlanPrice(region, instanceType)
+	a.refreshSpotPrices(region, instanceType)
+	a.RefreshZoneOfferings(region, instanceType)
+	a.RefreshInstanceTypeSpecs(region, instanceType)
+	persistPriceData(a.store, a)
+}
 
-	if _, ok := a.priceData[region]; !ok {
-		a.priceData[region] = &apis.RegionalInstancePrice{
-			InstanceTypePrices: make(map[string]*apis.InstanceTypePrice),
-		}
+func (a *AWSPriceClient) Health() error {
+	if len(a.priceData.Load()) == 0 {
+		return fmt.Errorf("no price data is available for aws")
 	}
+	return nil
+}
 
-	for _, item := range priceData {
-		instanceType := string(item.InstanceType)
-		price, err := strconv.ParseFloat(*item.SpotPrice, 64)
-		if err != nil || price == 0 {
-			klog.Errorf("Failed to parse price, %v", err)
-			continue
-		}
+func (a *AWSPriceClient) DataVersion() (uint64, time.Time) {
+	return a.priceData.Version()
+}
 
-		d, ok := a.priceData[region].InstanceTypePrices[instanceType]
-		if !ok {
-			continue
-		}
-		if d.SpotPricePerHour == nil {
-			d.SpotPricePerHour = make(map[string]float64)
-		}
+func (a *AWSPriceClient) PriceDelta(since uint64, region string) *apis.PriceDelta {
+	return a.priceData.Delta(since, region)
+}
 
-		d.SpotPricePerHour[*item.AvailabilityZone] = price
-		a.priceData[region].InstanceTypePrices[instanceType] = d
-	}
+func (a *AWSPriceClient) WatchPrices(ctx context.Context, lastEventID string, filter *apis.PriceEventFilter) (*PriceWatch, error) {
+	return a.priceData.events.Watch(ctx, lastEventID, filter)
+}
+
+func (a *AWSPriceClient) putSpotPriceData(region string, priceData []types.SpotPrice) {
+	a.priceData.Update(func(w *priceDataWriter) {
+		for _, item := range priceData {
+			instanceType := string(item.InstanceType)
+			price, err := strconv.ParseFloat(*item.SpotPrice, 64)
+			if err != nil || price == 0 {
+				klog.Errorf("Failed to parse price, %v", err)
+				continue
+			}
+			osType, ok := awsOSByProductDescription(string(item.ProductDescription))
+			if !ok {
+				continue
+			}
+
+			d, ok := w.Mutable(region, instanceType)
+			if !ok {
+				continue
+			}
+			// Spot instances are only priced with shared tenancy
+			updatePlatformPrice(d, osType, apis.TenancyShared, func(p *apis.PlatformPrice) {
+				if p.SpotPricePerHour == nil {
+					p.SpotPricePerHour = make(map[string]float64)
+				}
+				p.SpotPricePerHour[*item.AvailabilityZone] = price
+			})
+		}
+	})
 }
 
 func (a *AWSPriceClient) newEC2Client(region string) (*ec2.Client, error) {
-	ak := a.globalAK
-	sk := a.globalSK
+	ak := a.config.GlobalAK
+	sk := a.config.GlobalSK
 	if strings.HasPrefix(region, "cn-") {
-		ak = a.cnAK
-		sk = a.cnSK
+		ak = a.config.CNAK
+		sk = a.config.CNSK
 	}
 	cfg, err := config.LoadDefaultConfig(context.Background(),
 		config.WithRegion(region),
@@ -153,7 +307,7 @@ func (a *AWSPriceClient) newEC2Client(region string) (*ec2.Client, error) {
 }
 
 var spotBaseFilter = []types.Filter{
-	{Name: aws.String("product-description"), Values: []string{"Linux/UNIX"}},
+	{Name: aws.String("product-description"), Values: awsProductDescriptions()},
 }
 
 func (a *AWSPriceClient) handleSpotPrice(region string, filters []types.Filter) {
@@ -245,12 +399,186 @@ func resolvePricingEndpointRegion(region string) string {
 	return pricingAPIRegion
 }
 
+// awsSpotAdvisorData is the dataset of the Spot Instance Advisor
+type awsSpotAdvisorData struct {
+	Ranges []struct {
+		Index int    `json:"index"`
+		Label string `json:"label"`
+	} `json:"ranges"`
+	// SpotAdvisor is the advice by region, operating system and instance type
+	SpotAdvisor map[string]map[string]map[string]struct {
+		// Savings is the percentage saved over the on-demand price
+		Savings int `json:"s"`
+		// Range is the index of the interruption frequency range
+		Range int `json:"r"`
+	} `json:"spot_advisor"`
+}
+
+// awsSpotAdvisorOperatingSystems maps the operating systems of the Spot Instance Advisor
+var awsSpotAdvisorOperatingSystems = map[string]string{
+	"Linux":   apis.OSLinux,
+	"Windows": apis.OSWindows,
+}
+
+func (a *AWSPriceClient) getSpotAdvisorData() (*awsSpotAdvisorData, error) {
+	resp, err := a.httpClient.Get(a.config.SpotAdvisorEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get spot advisor data: %s", resp.Status)
+	}
+	ret := &awsSpotAdvisorData{}
+	if err := json.NewDecoder(resp.Body).Decode(ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// RefreshSpotInterruptions refreshes the spot interruption frequencies from the Spot Instance Advisor
+func (a *AWSPriceClient) RefreshSpotInterruptions(region, instanceType string) {
+	data, err := a.getSpotAdvisorData()
+	if err != nil {
+		klog.Errorf("failed to get spot advisor data, %v", err)
+		return
+	}
+	ranges := make(map[int]string, len(data.Ranges))
+	for _, r := range data.Ranges {
+		ranges[r.Index] = r.Label
+	}
+
+	a.priceData.Update(func(w *priceDataWriter) {
+		for r, d := range w.Data() {
+			if region != "" && r != region {
+				continue
+			}
+			for t := range d.InstanceTypePrices {
+				if instanceType != "" && t != instanceType {
+					continue
+				}
+				ins, _ := w.Mutable(r, t)
+				for advisorOS, osType := range awsSpotAdvisorOperatingSystems {
+					// The platforms without prices are not added
+					if _, ok := ins.Platforms[apis.PlatformKey(osType, apis.TenancyShared)]; !ok &&
+						!apis.IsDefaultPlatform(osType, apis.TenancyShared) {
+						continue
+					}
+					var interruption *apis.SpotInterruption
+					if advice, ok := data.SpotAdvisor[r][advisorOS][t]; ok {
+						interruption = &apis.SpotInterruption{
+							Bucket:  advice.Range,
+							Range:   ranges[advice.Range],
+							Savings: advice.Savings,
+						}
+					}
+					updatePlatformPrice(ins, osType, apis.TenancyShared, func(p *apis.PlatformPrice) {
+						p.SpotInterruption = interruption
+					})
+				}
+			}
+		}
+	})
+	klog.Infof("All spot interruptions are refreshed")
+}
+
+// awsPartitionRegions are the regions to send the spot placement score requests to, one request returns the
+// scores of all the regions of the partition
+var awsPartitionRegions = []string{"us-east-1", "cn-north-1"}
+
+func (a *AWSPriceClient) getSpotPlacementScores(region string, target AWSSpotPlacementScoreTarget) ([]types.SpotPlacementScore, error) {
+	client, err := a.newEC2Client(region)
+	if err != nil {
+		klog.Errorf("failed to create ec2 client, %v", err)
+		return nil, err
+	}
+
+	var ret []types.SpotPlacementScore
+	paginator := ec2.NewGetSpotPlacementScoresPaginator(client, &ec2.GetSpotPlacementScoresInput{
+		TargetCapacity:         aws.Int32(target.TargetCapacity),
+		TargetCapacityUnitType: types.TargetCapacityUnitTypeUnits,
+		InstanceTypes:          []string{target.InstanceType},
+		SingleAvailabilityZone: aws.Bool(true),
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, out.SpotPlacementScores...)
+	}
+
+	return ret, nil
+}
+
+// RefreshSpotPlacementScores refreshes the spot placement scores of the configured targets, an empty instance type
+// means all the targets
+func (a *AWSPriceClient) RefreshSpotPlacementScores(instanceType string) {
+	for _, target := range a.config.SpotPlacementScoreTargets {
+		if instanceType != "" && target.InstanceType != instanceType {
+			continue
+		}
+		for _, region := range awsPartitionRegions {
+			scores, err := a.getSpotPlacementScores(region, target)
+			if err != nil {
+				klog.Errorf("failed to get spot placement scores of %s for %d instances in the partition of %s, %v",
+					target.InstanceType, target.TargetCapacity, region, err)
+				continue
+			}
+			a.putSpotPlacementScores(region, target, scores)
+		}
+	}
+}
+
+func (a *AWSPriceClient) putSpotPlacementScores(partitionRegion string, target AWSSpotPlacementScoreTarget,
+	scores []types.SpotPlacementScore) {
+	capacity := strconv.Itoa(int(target.TargetCapacity))
+	cn := strings.HasPrefix(partitionRegion, "cn-")
+	a.priceData.Update(func(w *priceDataWriter) {
+		for region := range w.Data() {
+			if strings.HasPrefix(region, "cn-") != cn {
+				continue
+			}
+			ins, ok := w.Mutable(region, target.InstanceType)
+			if !ok {
+				continue
+			}
+			// The scores are of the zone ids, they are keyed by the zone names like the spot prices
+			names := make(map[string]string, len(ins.ZoneIDs))
+			for name, id := range ins.ZoneIDs {
+				names[id] = name
+			}
+			zoneScores := map[string]int{}
+			for _, s := range scores {
+				if aws.ToString(s.Region) != region {
+					continue
+				}
+				zone := aws.ToString(s.AvailabilityZoneId)
+				if name, ok := names[zone]; ok {
+					zone = name
+				}
+				zoneScores[zone] = int(aws.ToInt32(s.Score))
+			}
+
+			if len(zoneScores) == 0 {
+				delete(ins.SpotPlacementScores, capacity)
+				continue
+			}
+			if ins.SpotPlacementScores == nil {
+				ins.SpotPlacementScores = map[string]map[string]int{}
+			}
+			ins.SpotPlacementScores[capacity] = zoneScores
+		}
+	})
+}
+
 func (a *AWSPriceClient) newPriceClient(region string) (*pricing.Client, error) {
-	ak := a.globalAK
-	sk := a.globalSK
+	ak := a.config.GlobalAK
+	sk := a.config.GlobalSK
 	if strings.HasPrefix(region, "cn-") {
-		ak = a.cnAK
-		sk = a.cnSK
+		ak = a.config.CNAK
+		sk = a.config.CNSK
 	}
 	cfg, err := config.LoadDefaultConfig(context.Background(),
 		config.WithRegion(region),
@@ -265,11 +593,11 @@ func (a *AWSPriceClient) newPriceClient(region string) (*pricing.Client, error)
 }
 
 func (a *AWSPriceClient) newSavingsPlanClient(region string) (*savingsplans.Client, error) {
-	ak := a.globalAK
-	sk := a.globalSK
+	ak := a.config.GlobalAK
+	sk := a.config.GlobalSK
 	if strings.HasPrefix(region, "cn-") {
-		ak = a.cnAK
-		sk = a.cnSK
+		ak = a.config.CNAK
+		sk = a.config.CNSK
 	}
 
 	cfg, err := config.LoadDefaultConfig(context.Background(),
@@ -284,11 +612,6 @@ func (a *AWSPriceClient) newSavingsPlanClient(region string) (*savingsplans.Clie
 }
 
 var onDemandBaseFilters = []pricingtypes.Filter{
-	{
-		Field: aws.String("tenancy"),
-		Type:  pricingtypes.FilterTypeTermMatch,
-		Value: aws.String("Shared"),
-	},
 	{
 		Field: aws.String("productFamily"),
 		Type:  pricingtypes.FilterTypeTermMatch,
@@ -304,11 +627,6 @@ var onDemandBaseFilters = []pricingtypes.Filter{
 		Type:  pricingtypes.FilterTypeTermMatch,
 		Value: aws.String("NA"),
 	},
-	{
-		Field: aws.String("operatingSystem"),
-		Type:  pricingtypes.FilterTypeTermMatch,
-		Value: aws.String("Linux"),
-	},
 	{
 		Field: aws.String("capacitystatus"),
 		Type:  pricingtypes.FilterTypeTermMatch,
@@ -321,13 +639,7 @@ var onDemandBaseFilters = []pricingtypes.Filter{
 	},
 }
 
-func (a *AWSPriceClient) handleOnDemandPrice(region string, filters []pricingtypes.Filter) {
-	zones, err := a.getAvailableZones(region)
-	if err != nil {
-		klog.Errorf("failed to get available zones, %v", err)
-		return
-	}
-
+func (a *AWSPriceClient) handleOnDemandPrice(region, osType, tenancy string, filters []pricingtypes.Filter) {
 	client, err := a.newPriceClient(resolvePricingEndpointRegion(region))
 	if err != nil {
 		return
@@ -339,6 +651,16 @@ func (a *AWSPriceClient) handleOnDemandPrice(region string, filters []pricingtyp
 			Type:  pricingtypes.FilterTypeTermMatch,
 			Value: aws.String(region),
 		},
+		{
+			Field: aws.String("operation"),
+			Type:  pricingtypes.FilterTypeTermMatch,
+			Value: aws.String(awsOperatingSystems[osType].operation),
+		},
+		{
+			Field: aws.String("tenancy"),
+			Type:  pricingtypes.FilterTypeTermMatch,
+			Value: aws.String(awsTenancies[tenancy]),
+		},
 	}
 	currentFilter = append(currentFilter, filters...)
 
@@ -363,7 +685,7 @@ func (a *AWSPriceClient) handleOnDemandPrice(region string, filters []pricingtyp
 			token = *data.NextToken
 		}
 
-		a.putOnDemandPriceData(region, zones, data.PriceList)
+		a.putOnDemandPriceData(region, osType, tenancy, data.PriceList)
 
 		if data.NextToken == nil || *data.NextToken == "" {
 			break
@@ -399,7 +721,11 @@ func (a *AWSPriceClient) RefreshOnDemandPrice(region, instanceType string) {
 			<-sem
 		}()
 
-		a.handleOnDemandPrice(region, filters)
+		for osType := range awsOperatingSystems {
+			for tenancy := range awsTenancies {
+				a.handleOnDemandPrice(region, osType, tenancy, filters)
+			}
+		}
 	}
 
 	for _, region := range list {
@@ -500,16 +826,12 @@ func (a *AWSPriceClient) handleSavingsPlanPrice(region string,
 func (a *AWSPriceClient) RefreshSavingsPlanPrice(region, instanceType string) {
 	baseFilters := []savingsplanstypes.SavingsPlanOfferingRateFilterElement{
 		{
-			Name: savingsplanstypes.SavingsPlanRateFilterAttributeProductDescription,
-			Values: []string{
-				"Linux/UNIX",
-			},
+			Name:   savingsplanstypes.SavingsPlanRateFilterAttributeProductDescription,
+			Values: awsProductDescriptions(),
 		},
 		{
-			Name: savingsplanstypes.SavingsPlanRateFilterAttributeTenancy,
-			Values: []string{
-				"shared",
-			},
+			Name:   savingsplanstypes.SavingsPlanRateFilterAttributeTenancy,
+			Values: lo.Keys(awsTenancies),
 		},
 	}
 	if instanceType != "" {
@@ -554,7 +876,8 @@ func (a *AWSPriceClient) RefreshSavingsPlanPrice(region, instanceType string) {
 	klog.Infof("All savings plan prices are refreshed")
 }
 
-func (a *AWSPriceClient) getAvailableZones(region string) ([]string, error) {
+// getAvailableZones returns the available zones of the region, mapping the zone names to the zone ids
+func (a *AWSPriceClient) getAvailableZones(region string) (map[string]string, error) {
 	client, err := a.newEC2Client(region)
 	if err != nil {
 		klog.Errorf("failed to create ec2 client, %v", err)
@@ -575,14 +898,103 @@ func (a *AWSPriceClient) getAvailableZones(region string) ([]string, error) {
 		return nil, err
 	}
 
-	var ret []string
+	ret := make(map[string]string, len(out.AvailabilityZones))
 	for _, a := range out.AvailabilityZones {
-		ret = append(ret, *a.ZoneName)
+		ret[aws.ToString(a.ZoneName)] = aws.ToString(a.ZoneId)
 	}
 
 	return ret, nil
 }
 
+// getZoneOfferings returns the zones where the instance types are offered in the region, an empty instance type
+// means all the instance types
+func (a *AWSPriceClient) getZoneOfferings(region, instanceType string) (map[string][]string, error) {
+	client, err := a.newEC2Client(region)
+	if err != nil {
+		klog.Errorf("failed to create ec2 client, %v", err)
+		return nil, err
+	}
+	in := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: types.LocationTypeAvailabilityZone,
+		MaxResults:   aws.Int32(1000),
+	}
+	if instanceType != "" {
+		in.Filters = []types.Filter{
+			{
+				Name:   aws.String("instance-type"),
+				Values: []string{instanceType},
+			},
+		}
+	}
+
+	ret := map[string][]string{}
+	for {
+		out, err := client.DescribeInstanceTypeOfferings(context.Background(), in)
+		if err != nil {
+			klog.Errorf("failed to describe instance type offerings for %s, %v", region, err)
+			return nil, err
+		}
+		for _, o := range out.InstanceTypeOfferings {
+			t := string(o.InstanceType)
+			ret[t] = append(ret[t], aws.ToString(o.Location))
+		}
+
+		if out.NextToken == nil || *out.NextToken == "" {
+			break
+		}
+		in.NextToken = out.NextToken
+	}
+
+	return ret, nil
+}
+
+func (a *AWSPriceClient) handleZoneOfferings(region, instanceType string) {
+	zones, err := a.getAvailableZones(region)
+	if err != nil {
+		return
+	}
+	offerings, err := a.getZoneOfferings(region, instanceType)
+	if err != nil {
+		return
+	}
+
+	a.putZoneOfferings(region, instanceType, zones, offerings)
+}
+
+// RefreshZoneOfferings refreshes the zones where the instance types are offered, the zones of the region are not
+// always offered for every instance type
+func (a *AWSPriceClient) RefreshZoneOfferings(region, instanceType string) {
+	list, err := a.listRegions()
+	if err != nil {
+		return
+	}
+	if region != "" {
+		list = []string{region}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 10)
+
+	handleFunc := func(region string) {
+		defer wg.Done()
+		sem <- struct{}{}
+		defer func() {
+			<-sem
+		}()
+
+		a.handleZoneOfferings(region, instanceType)
+	}
+
+	for _, region := range list {
+		klog.Infof("Start to handle region %s for zone offerings", region)
+		wg.Add(1)
+		go handleFunc(region)
+	}
+
+	wg.Wait()
+	klog.Infof("All zone offerings are refreshed")
+}
+
 func extractArch(instanceType string) (string, error) {
 	// Following logic is based on: https://docs.aws.amazon.com/zh_cn/ec2/latest/instancetypes/instance-type-names.html
 	if len(instanceType) < 3 {
@@ -605,6 +1017,59 @@ func extractMemory(memory string) (float64, error) {
 	return strconv.ParseFloat(s, 64)
 }
 
+// extractReservedBilling returns the reserved instance prices of the product, key is
+// {offering class}/{term length}/{payment option}
+func extractReservedBilling(item PriceItem, currency string) map[string]apis.AWSEC2ReservedBilling {
+	ret := map[string]apis.AWSEC2ReservedBilling{}
+	for _, term := range item.Terms.Reserved {
+		attrs := term.TermAttributes
+		hours, ok := awsReservedTermHours[attrs.LeaseContractLength]
+		if !ok {
+			continue
+		}
+		paymentOption, ok := awsReservedPaymentOptions[attrs.PurchaseOption]
+		if !ok {
+			continue
+		}
+
+		billing := apis.AWSEC2ReservedBilling{}
+		for _, v := range term.PriceDimensions {
+			price, err := strconv.ParseFloat(v.PricePerUnit[currency], 64)
+			if err != nil {
+				continue
+			}
+			switch v.Unit {
+			case "Quantity":
+				billing.UpfrontFee = price
+			case "Hrs":
+				billing.HourlyRate = price
+			}
+		}
+		billing.Rate = billing.HourlyRate + billing.UpfrontFee/hours
+		if billing.Rate == 0 {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s/%s", strings.ToLower(attrs.OfferingClass), attrs.LeaseContractLength, paymentOption)
+		ret[key] = billing
+	}
+	if len(ret) == 0 {
+		return nil
+	}
+	return ret
+}
+
+var awsReservedTermHours = map[string]float64{
+	"1yr": 365 * 24,
+	"3yr": 3 * 365 * 24,
+}
+
+var awsReservedPaymentOptions = map[string]apis.AWSEC2SPPaymentOption{
+	"All Upfront":     apis.AWSEC2SPPaymentOptionAllUpfront,
+	"Partial Upfront": apis.AWSEC2SPPaymentOptionPartialUpfront,
+	"No Upfront":      apis.AWSEC2SPPaymentOptionNoUpfront,
+}
+
 func extractInstanceType(props []savingsplanstypes.SavingsPlanOfferingRateProperty) (string, error) {
 	for _, v := range props {
 		if *v.Name == "instanceType" {
@@ -615,6 +1080,93 @@ func extractInstanceType(props []savingsplanstypes.SavingsPlanOfferingRateProper
 	return "", fmt.Errorf("failed to extract instance family")
 }
 
+// extractPlatform returns the operating system and the tenancy of the savings plan rate
+func extractPlatform(props []savingsplanstypes.SavingsPlanOfferingRateProperty) (string, string, error) {
+	var osType, tenancy string
+	for _, v := range props {
+		switch aws.ToString(v.Name) {
+		case "productDescription":
+			var ok bool
+			if osType, ok = awsOSByProductDescription(aws.ToString(v.Value)); !ok {
+				return "", "", fmt.Errorf("unsupported product description %s", aws.ToString(v.Value))
+			}
+		case "tenancy":
+			tenancy = aws.ToString(v.Value)
+		}
+	}
+	if _, ok := awsTenancies[tenancy]; osType == "" || !ok {
+		return "", "", fmt.Errorf("failed to extract platform")
+	}
+	return osType, tenancy, nil
+}
+
+type awsOperatingSystem struct {
+	operation          string
+	productDescription string
+}
+
+// awsOperatingSystems are the operation codes of the pricing API and the product descriptions of the spot price
+// and savings plan APIs of the supported operating systems
+var awsOperatingSystems = map[string]awsOperatingSystem{
+	apis.OSLinux:   {operation: "RunInstances", productDescription: "Linux/UNIX"},
+	apis.OSWindows: {operation: "RunInstances:0002", productDescription: "Windows"},
+	apis.OSRHEL:    {operation: "RunInstances:0010", productDescription: "Red Hat Enterprise Linux"},
+	apis.OSSUSE:    {operation: "RunInstances:000g", productDescription: "SUSE Linux"},
+}
+
+// awsTenancies maps the tenancies to the values of the pricing API
+var awsTenancies = map[string]string{
+	apis.TenancyShared:    "Shared",
+	apis.TenancyDedicated: "Dedicated",
+}
+
+func awsProductDescriptions() []string {
+	return lo.MapToSlice(awsOperatingSystems, func(_ string, v awsOperatingSystem) string {
+		return v.productDescription
+	})
+}
+
+func awsOSByProductDescription(description string) (string, bool) {
+	for osType, v := range awsOperatingSystems {
+		if v.productDescription == description {
+			return osType, true
+		}
+	}
+	return "", false
+}
+
+// updatePlatformPrice updates the price of the platform, the default platform is stored in the instance type
+// price itself and the others are stored in Platforms
+func updatePlatformPrice(ins *apis.InstanceTypePrice, osType, tenancy string, update func(p *apis.PlatformPrice)) {
+	if apis.IsDefaultPlatform(osType, tenancy) {
+		p := &apis.PlatformPrice{
+			OnDemandPricePerHour:  ins.OnDemandPricePerHour,
+			AWSEC2Billing:         ins.AWSEC2Billing,
+			AWSEC2ReservedBilling: ins.AWSEC2ReservedBilling,
+			SpotPricePerHour:      ins.SpotPricePerHour,
+			SpotInterruption:      ins.SpotInterruption,
+		}
+		update(p)
+		ins.OnDemandPricePerHour = p.OnDemandPricePerHour
+		ins.AWSEC2Billing = p.AWSEC2Billing
+		ins.AWSEC2ReservedBilling = p.AWSEC2ReservedBilling
+		ins.SpotPricePerHour = p.SpotPricePerHour
+		ins.SpotInterruption = p.SpotInterruption
+		return
+	}
+
+	if ins.Platforms == nil {
+		ins.Platforms = map[string]*apis.PlatformPrice{}
+	}
+	key := apis.PlatformKey(osType, tenancy)
+	p, ok := ins.Platforms[key]
+	if !ok {
+		p = &apis.PlatformPrice{}
+		ins.Platforms[key] = p
+	}
+	update(p)
+}
+
 func extractPaymentOption(op savingsplanstypes.SavingsPlanPaymentOption) apis.AWSEC2SPPaymentOption {
 	switch op {
 	case savingsplanstypes.SavingsPlanPaymentOptionAllUpfront:
@@ -627,154 +1179,338 @@ func extractPaymentOption(op savingsplanstypes.SavingsPlanPaymentOption) apis.AW
 }
 
 func (a *AWSPriceClient) putSavingsPlanPriceData(region string, rate []savingsplanstypes.SavingsPlanOfferingRate) {
-	a.dataMutex.Lock()
-	defer a.dataMutex.Unlock()
-
-	for _, r := range rate {
-		planType := r.SavingsPlanOffering.PlanType
-		termLength := fmt.Sprintf("%dyr", r.SavingsPlanOffering.DurationSeconds/(60*60*24*365))
-		paymentOption := extractPaymentOption(r.SavingsPlanOffering.PaymentOption)
-		instanceType, err := extractInstanceType(r.Properties)
-		if err != nil {
-			klog.Errorf("failed to extract instance type, %v", err)
-			continue
-		}
-		key := fmt.Sprintf("%s/%s/%s", planType, termLength, paymentOption)
+	a.priceData.Update(func(w *priceDataWriter) {
+		for _, r := range rate {
+			planType := r.SavingsPlanOffering.PlanType
+			termLength := fmt.Sprintf("%dyr", r.SavingsPlanOffering.DurationSeconds/(60*60*24*365))
+			paymentOption := extractPaymentOption(r.SavingsPlanOffering.PaymentOption)
+			instanceType, err := extractInstanceType(r.Properties)
+			if err != nil {
+				klog.Errorf("failed to extract instance type, %v", err)
+				continue
+			}
+			osType, tenancy, err := extractPlatform(r.Properties)
+			if err != nil {
+				klog.Errorf("failed to extract platform, %v", err)
+				continue
+			}
+			key := fmt.Sprintf("%s/%s/%s", planType, termLength, paymentOption)
 
-		d, ok := a.priceData[region]
-		if !ok {
-			d = &apis.RegionalInstancePrice{
-				InstanceTypePrices: map[string]*apis.InstanceTypePrice{},
+			rate, err := strconv.ParseFloat(*r.Rate, 64)
+			if err != nil {
+				klog.Errorf("failed to parse rate to float, %v", err)
+				continue
 			}
+			ins, ok := w.Mutable(region, instanceType)
+			if !ok {
+				ins = &apis.InstanceTypePrice{Currency: PriceCurrency(apis.AWSCloudProvider, region)}
+				w.Put(region, instanceType, ins)
+			}
+
+			updatePlatformPrice(ins, osType, tenancy, func(p *apis.PlatformPrice) {
+				if p.AWSEC2Billing == nil {
+					p.AWSEC2Billing = map[string]apis.AWSEC2Billing{}
+				}
+				p.AWSEC2Billing[key] = apis.AWSEC2Billing{Rate: rate}
+			})
 		}
-		ins, ok := d.InstanceTypePrices[instanceType]
-		if !ok {
-			ins = &apis.InstanceTypePrice{}
+	})
+}
+
+// getInstanceTypeInfos returns the instance types of the region from DescribeInstanceTypes, an empty instance type
+// means all the instance types
+func (a *AWSPriceClient) getInstanceTypeInfos(region, instanceType string) ([]types.InstanceTypeInfo, error) {
+	client, err := a.newEC2Client(region)
+	if err != nil {
+		klog.Errorf("failed to create ec2 client, %v", err)
+		return nil, err
+	}
+	in := &ec2.DescribeInstanceTypesInput{
+		MaxResults: aws.Int32(100),
+	}
+	if instanceType != "" {
+		in.InstanceTypes = []types.InstanceType{types.InstanceType(instanceType)}
+	}
+
+	var ret []types.InstanceTypeInfo
+	for {
+		out, err := client.DescribeInstanceTypes(context.Background(), in)
+		if err != nil {
+			klog.Errorf("failed to describe instance types for %s, %v", region, err)
+			return nil, err
 		}
-		if ins.AWSEC2Billing == nil {
-			ins.AWSEC2Billing = map[string]apis.AWSEC2Billing{}
+		ret = append(ret, out.InstanceTypes...)
+
+		if out.NextToken == nil || *out.NextToken == "" {
+			break
 		}
-		rate, err := strconv.ParseFloat(*r.Rate, 64)
+		in.NextToken = out.NextToken
+	}
+
+	return ret, nil
+}
+
+// RefreshInstanceTypeSpecs refreshes the detailed specifications and the arch of the instance types
+func (a *AWSPriceClient) RefreshInstanceTypeSpecs(region, instanceType string) {
+	list, err := a.listRegions()
+	if err != nil {
+		return
+	}
+	if region != "" {
+		list = []string{region}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 10)
+
+	handleFunc := func(region string) {
+		defer wg.Done()
+		sem <- struct{}{}
+		defer func() {
+			<-sem
+		}()
+
+		infos, err := a.getInstanceTypeInfos(region, instanceType)
 		if err != nil {
-			klog.Errorf("failed to parse rate to float, %v", err)
-			continue
+			return
 		}
+		a.putInstanceTypeSpecs(region, infos)
+	}
 
-		ins.AWSEC2Billing[key] = apis.AWSEC2Billing{Rate: rate}
-
-		d.InstanceTypePrices[instanceType] = ins
-		a.priceData[region] = d
+	for _, region := range list {
+		klog.Infof("Start to handle region %s for instance type specs", region)
+		wg.Add(1)
+		go handleFunc(region)
 	}
+
+	wg.Wait()
+	klog.Infof("All instance type specs are refreshed")
 }
 
-func (a *AWSPriceClient) putOnDemandPriceData(region string, zones []string, priceData []string) {
-	storeFunc := func(item PriceItem) {
-		a.dataMutex.Lock()
-		defer a.dataMutex.Unlock()
+func (a *AWSPriceClient) putInstanceTypeSpecs(region string, infos []types.InstanceTypeInfo) {
+	a.priceData.Update(func(w *priceDataWriter) {
+		for i := range infos {
+			ins, ok := w.Mutable(region, string(infos[i].InstanceType))
+			if !ok {
+				continue
+			}
+			if infos[i].ProcessorInfo != nil {
+				if lo.Contains(infos[i].ProcessorInfo.SupportedArchitectures, types.ArchitectureTypeArm64) {
+					ins.Arch = "arm64"
+				} else if lo.Contains(infos[i].ProcessorInfo.SupportedArchitectures, types.ArchitectureTypeX8664) {
+					ins.Arch = "amd64"
+				}
+			}
+			ins.Spec = extractEC2Spec(&infos[i])
+		}
+	})
+}
 
-		d, ok := a.priceData[region]
-		if !ok {
-			d = &apis.RegionalInstancePrice{
-				InstanceTypePrices: map[string]*apis.InstanceTypePrice{},
+// extractEC2Spec returns the detailed specification of the EC2 instance type
+func extractEC2Spec(info *types.InstanceTypeInfo) *apis.InstanceTypeSpec {
+	spec := &apis.InstanceTypeSpec{
+		Burstable:         aws.ToBool(info.BurstablePerformanceSupported),
+		Hypervisor:        string(info.Hypervisor),
+		CurrentGeneration: info.CurrentGeneration,
+	}
+	if n := info.NetworkInfo; n != nil {
+		spec.NetworkPerformance = aws.ToString(n.NetworkPerformance)
+		spec.MaxNetworkInterfaces = int(aws.ToInt32(n.MaximumNetworkInterfaces))
+		spec.IPv4AddressesPerInterface = int(aws.ToInt32(n.Ipv4AddressesPerInterface))
+		for _, c := range n.NetworkCards {
+			spec.NetworkBandwidthGbps += aws.ToFloat64(c.BaselineBandwidthInGbps)
+		}
+	}
+	if s := info.InstanceStorageInfo; s != nil {
+		spec.LocalStorage = float64(aws.ToInt64(s.TotalSizeInGB))
+		spec.LocalStorageNVMe = s.NvmeSupport == types.EphemeralNvmeSupportSupported ||
+			s.NvmeSupport == types.EphemeralNvmeSupportRequired
+		if len(s.Disks) != 0 {
+			spec.LocalStorageType = string(s.Disks[0].Type)
+		}
+	}
+	if e := info.EbsInfo; e != nil && e.EbsOptimizedInfo != nil {
+		spec.DiskBandwidthMbps = float64(aws.ToInt32(e.EbsOptimizedInfo.BaselineBandwidthInMbps))
+		spec.DiskMaxBandwidthMbps = float64(aws.ToInt32(e.EbsOptimizedInfo.MaximumBandwidthInMbps))
+	}
+	if g := info.GpuInfo; g != nil {
+		spec.GPUMemory = mibToGiB(g.TotalGpuMemoryInMiB)
+		if len(g.Gpus) != 0 {
+			spec.GPUModel = aws.ToString(g.Gpus[0].Name)
+			spec.GPUManufacturer = aws.ToString(g.Gpus[0].Manufacturer)
+		}
+	}
+	if i := info.InferenceAcceleratorInfo; i != nil {
+		for _, d := range i.Accelerators {
+			spec.Accelerators = append(spec.Accelerators, apis.Accelerator{
+				Type:         apis.AcceleratorInferentia,
+				Name:         aws.ToString(d.Name),
+				Manufacturer: aws.ToString(d.Manufacturer),
+				Count:        int(aws.ToInt32(d.Count)),
+			})
+			if d.MemoryInfo != nil {
+				spec.Accelerators[len(spec.Accelerators)-1].Memory = mibToGiB(d.MemoryInfo.SizeInMiB)
 			}
 		}
-		ins, ok := d.InstanceTypePrices[item.Product.Attributes.InstanceType]
+	}
+	if n := info.NeuronInfo; n != nil {
+		for _, d := range n.NeuronDevices {
+			// The neuron devices are Inferentia2, Trainium and Trainium2
+			t := apis.AcceleratorInferentia
+			if strings.Contains(strings.ToLower(aws.ToString(d.Name)), apis.AcceleratorTrainium) {
+				t = apis.AcceleratorTrainium
+			}
+			spec.Accelerators = append(spec.Accelerators, apis.Accelerator{
+				Type:  t,
+				Name:  aws.ToString(d.Name),
+				Count: int(aws.ToInt32(d.Count)),
+			})
+			if d.MemoryInfo != nil {
+				spec.Accelerators[len(spec.Accelerators)-1].Memory = mibToGiB(d.MemoryInfo.SizeInMiB)
+			}
+		}
+	}
+	if f := info.FpgaInfo; f != nil {
+		for _, d := range f.Fpgas {
+			spec.Accelerators = append(spec.Accelerators, apis.Accelerator{
+				Type:         apis.AcceleratorFPGA,
+				Name:         aws.ToString(d.Name),
+				Manufacturer: aws.ToString(d.Manufacturer),
+				Count:        int(aws.ToInt32(d.Count)),
+			})
+			if d.MemoryInfo != nil {
+				spec.Accelerators[len(spec.Accelerators)-1].Memory = mibToGiB(d.MemoryInfo.SizeInMiB)
+			}
+		}
+	}
+
+	return spec
+}
+
+func mibToGiB(size *int32) float64 {
+	return float64(aws.ToInt32(size)) / 1024
+}
+
+func (a *AWSPriceClient) putZoneOfferings(region, instanceType string, zones map[string]string, offerings map[string][]string) {
+	if instanceType == "" && len(offerings) == 0 {
+		return
+	}
+	a.priceData.Update(func(w *priceDataWriter) {
+		d, ok := w.Data()[region]
 		if !ok {
-			ins = &apis.InstanceTypePrice{}
+			return
+		}
+		for t := range d.InstanceTypePrices {
+			if instanceType != "" && t != instanceType {
+				continue
+			}
+			ins, _ := w.Mutable(region, t)
+			offered := offerings[t]
+			sort.Strings(offered)
+			ins.Zones = offered
+			ins.ZoneIDs = make(map[string]string, len(offered))
+			for _, zone := range offered {
+				if id, ok := zones[zone]; ok {
+					ins.ZoneIDs[zone] = id
+				}
+			}
 		}
-		ins.Zones = zones
+	})
+}
 
-		var err error
-		ins.Arch, err = extractArch(item.Product.Attributes.InstanceType)
+func (a *AWSPriceClient) putOnDemandPriceData(region, osType, tenancy string, priceData []string) {
+	storeFunc := func(w *priceDataWriter, item PriceItem) {
+		// The attributes are parsed before the price is written, so a malformed item leaves the price unchanged
+		arch, err := extractArch(item.Product.Attributes.InstanceType)
 		if err != nil {
 			return
 		}
-		ins.VCPU, err = strconv.ParseFloat(item.Product.Attributes.VCPU, 64)
+		vcpu, err := strconv.ParseFloat(item.Product.Attributes.VCPU, 64)
 		if err != nil {
 			klog.Errorf("failed to parse vcpu, %v", err)
 			return
 		}
-		ins.Memory, err = extractMemory(item.Product.Attributes.Memory)
+		memory, err := extractMemory(item.Product.Attributes.Memory)
 		if err != nil {
 			klog.Errorf("failed to parse memory, %v", err)
 			return
 		}
-
+		var gpu float64
 		if item.Product.Attributes.GPU != "" {
-			ins.GPU, err = strconv.ParseFloat(item.Product.Attributes.GPU, 64)
+			gpu, err = strconv.ParseFloat(item.Product.Attributes.GPU, 64)
 			if err != nil {
 				klog.Errorf("failed to parse gpu, %v", err)
 				return
 			}
 		}
 
-		currency := "USD"
-		if strings.HasPrefix(region, "cn-") {
-			currency = "CNY"
+		ins, existing := w.Mutable(region, item.Product.Attributes.InstanceType)
+		if !existing {
+			ins = &apis.InstanceTypePrice{}
 		}
-		for _, term := range item.Terms.OnDemand {
-			for _, v := range term.PriceDimensions {
-				price, err := strconv.ParseFloat(v.PricePerUnit[currency], 64)
-				if err != nil || price == 0 {
-					continue
+		// The arch from DescribeInstanceTypes is kept, it's more accurate than the one guessed from the name
+		if ins.Spec == nil || ins.Arch == "" {
+			ins.Arch = arch
+		}
+		ins.VCPU, ins.Memory = vcpu, memory
+		if item.Product.Attributes.GPU != "" {
+			ins.GPU = gpu
+		}
+
+		currency := PriceCurrency(apis.AWSCloudProvider, region)
+		ins.Currency = currency
+		updatePlatformPrice(ins, osType, tenancy, func(p *apis.PlatformPrice) {
+			for _, term := range item.Terms.OnDemand {
+				for _, v := range term.PriceDimensions {
+					price, err := strconv.ParseFloat(v.PricePerUnit[currency], 64)
+					if err != nil || price == 0 {
+						continue
+					}
+					p.OnDemandPricePerHour = price
 				}
-				ins.OnDemandPricePerHour = price
 			}
-		}
+			p.AWSEC2ReservedBilling = extractReservedBilling(item, currency)
+		})
 
-		d.InstanceTypePrices[item.Product.Attributes.InstanceType] = ins
-		a.priceData[region] = d
+		if !existing {
+			w.Put(region, item.Product.Attributes.InstanceType, ins)
+		}
 	}
 
-	for _, outer := range priceData {
-		var pItem PriceItem
-		err := json.Unmarshal([]byte(outer), &pItem)
-		if err != nil {
-			klog.Errorf("failed to unmarshal, %v", err)
-			continue
+	a.priceData.Update(func(w *priceDataWriter) {
+		for _, outer := range priceData {
+			var pItem PriceItem
+			err := json.Unmarshal([]byte(outer), &pItem)
+			if err != nil {
+				klog.Errorf("failed to unmarshal, %v", err)
+				continue
+			}
+			storeFunc(w, pItem)
 		}
-		storeFunc(pItem)
-	}
+	})
 }
 
-func (a *AWSPriceClient) ListRegionsInstancesPrice() map[string]*apis.RegionalInstancePrice {
-	a.dataMutex.Lock()
-	defer a.dataMutex.Unlock()
+func (a *AWSPriceClient) ListRegions() []string {
+	return sortedRegions(a.priceData.Load())
+}
 
-	ret := make(map[string]*apis.RegionalInstancePrice)
-	for k, v := range a.priceData {
-		ret[k] = v.DeepCopy()
-		// TODO: this line is used to ensure the api compatibility, we should remove this line in the future
-		ret[k].InstanceTypeEC2Price = ret[k].InstanceTypePrices
-	}
-	return ret
+func (a *AWSPriceClient) ListRegionsInstancesPrice() map[string]*apis.RegionalInstancePrice {
+	return a.priceData.Load()
 }
 
 func (a *AWSPriceClient) ListInstancesPrice(region string) *map[string]apis.RegionalInstancePrice {
-	a.dataMutex.Lock()
-	defer a.dataMutex.Unlock()
-
-	d, ok := a.priceData[region]
+	d, ok := a.priceData.Load()[region]
 	if !ok {
 		return nil
 	}
 
-	regionData := d.DeepCopy()
-	// TODO: this line is used to ensure the api compatibility, we should remove this line in the future
-	regionData.InstanceTypeEC2Price = regionData.InstanceTypePrices
-
-	ret := map[string]apis.RegionalInstancePrice{
-		region: *regionData,
+	return &map[string]apis.RegionalInstancePrice{
+		region: *d,
 	}
-
-	return &ret
 }
 
 func (a *AWSPriceClient) GetInstancePrice(region, instanceType string) *apis.InstanceTypePrice {
-	a.dataMutex.Lock()
-	defer a.dataMutex.Unlock()
-
-	regionData, ok := a.priceData[region]
+	regionData, ok := a.priceData.Load()[region]
 	if !ok {
 		return nil
 	}
@@ -0,0 +1,528 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+// newAWSTestReservedTerm returns a reserved term of the price list with the upfront fee and the hourly rate in USD
+func newAWSTestReservedTerm(length, class, option, upfront, hourly string) string {
+	return fmt.Sprintf(`{
+  "priceDimensions": {
+    "d1": {"unit": "Quantity", "pricePerUnit": {"USD": %q}},
+    "d2": {"unit": "Hrs", "pricePerUnit": {"USD": %q}}
+  },
+  "termAttributes": {"LeaseContractLength": %q, "OfferingClass": %q, "PurchaseOption": %q}
+}`, upfront, hourly, length, class, option)
+}
+
+func TestExtractReservedBilling(t *testing.T) {
+	tests := []struct {
+		name     string
+		terms    []string
+		currency string
+		want     map[string]apis.AWSEC2ReservedBilling
+	}{
+		{
+			name:  "no upfront",
+			terms: []string{newAWSTestReservedTerm("1yr", "standard", "No Upfront", "0", "0.062")},
+			want:  map[string]apis.AWSEC2ReservedBilling{"standard/1yr/no": {Rate: 0.062, HourlyRate: 0.062}},
+		},
+		{
+			// The upfront fee is amortized over the hours of the term
+			name:  "partial upfront",
+			terms: []string{newAWSTestReservedTerm("1yr", "standard", "Partial Upfront", "306", "0.035")},
+			want: map[string]apis.AWSEC2ReservedBilling{
+				"standard/1yr/partial": {Rate: 0.035 + 306.0/8760, UpfrontFee: 306, HourlyRate: 0.035},
+			},
+		},
+		{
+			name:  "all upfront",
+			terms: []string{newAWSTestReservedTerm("3yr", "convertible", "All Upfront", "1314", "0")},
+			want: map[string]apis.AWSEC2ReservedBilling{
+				"convertible/3yr/all": {Rate: 1314.0 / 26280, UpfrontFee: 1314},
+			},
+		},
+		{
+			name: "multiple terms",
+			terms: []string{
+				newAWSTestReservedTerm("1yr", "standard", "No Upfront", "0", "0.062"),
+				newAWSTestReservedTerm("3yr", "standard", "No Upfront", "0", "0.042"),
+			},
+			want: map[string]apis.AWSEC2ReservedBilling{
+				"standard/1yr/no": {Rate: 0.062, HourlyRate: 0.062},
+				"standard/3yr/no": {Rate: 0.042, HourlyRate: 0.042},
+			},
+		},
+		{
+			name: "unsupported terms",
+			terms: []string{
+				newAWSTestReservedTerm("5yr", "standard", "No Upfront", "0", "0.05"),
+				newAWSTestReservedTerm("1yr", "standard", "Heavy Utilization", "0", "0.05"),
+				newAWSTestReservedTerm("1yr", "standard", "No Upfront", "0", "0"),
+			},
+		},
+		{
+			// The prices of AWS China are in CNY
+			name:     "other currency",
+			terms:    []string{newAWSTestReservedTerm("1yr", "standard", "No Upfront", "0", "0.062")},
+			currency: "CNY",
+		},
+		{name: "no terms"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reserved := "{"
+			for i, term := range tt.terms {
+				if i > 0 {
+					reserved += ","
+				}
+				reserved += fmt.Sprintf("%q: %s", fmt.Sprintf("term%d", i), term)
+			}
+			reserved += "}"
+			var item PriceItem
+			if err := json.Unmarshal([]byte(`{"terms": {"reserved": `+reserved+`}}`), &item); err != nil {
+				t.Fatal(err)
+			}
+
+			currency := tt.currency
+			if currency == "" {
+				currency = "USD"
+			}
+			got := extractReservedBilling(item, currency)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for key, want := range tt.want {
+				b, ok := got[key]
+				if !ok || math.Abs(b.Rate-want.Rate) > 1e-9 || b.UpfrontFee != want.UpfrontFee || b.HourlyRate != want.HourlyRate {
+					t.Errorf("got %s %+v, want %+v", key, b, want)
+				}
+			}
+			if tt.want == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want nil", got)
+			}
+		})
+	}
+}
+
+// newAWSTestPriceItem returns an item of the price list with the on-demand price in USD
+func newAWSTestPriceItem(instanceType, vcpu, memory, gpu, price string) string {
+	return fmt.Sprintf(`{
+  "product": {"attributes": {"instanceType": %q, "vcpu": %q, "memory": %q, "gpu": %q}},
+  "terms": {"onDemand": {"t1": {"priceDimensions": {"d1": {"pricePerUnit": {"USD": %q}}}}}}
+}`, instanceType, vcpu, memory, gpu, price)
+}
+
+func TestPutOnDemandPriceData(t *testing.T) {
+	tests := []struct {
+		name string
+		item string
+		// want is the price of m5.large, the old one is 2 vCPUs, 8 GiB and 0.096
+		want apis.InstanceTypePrice
+	}{
+		{
+			name: "valid item",
+			item: newAWSTestPriceItem("m5.large", "4", "16 GiB", "1", "0.192"),
+			want: apis.InstanceTypePrice{Arch: "amd64", VCPU: 4, Memory: 16, GPU: 1, OnDemandPricePerHour: 0.192},
+		},
+		{
+			name: "malformed memory",
+			item: newAWSTestPriceItem("m5.large", "4", "NA", "", "0.192"),
+			want: apis.InstanceTypePrice{Arch: "amd64", VCPU: 2, Memory: 8, OnDemandPricePerHour: 0.096},
+		},
+		{
+			name: "malformed gpu",
+			item: newAWSTestPriceItem("m5.large", "4", "16 GiB", "NA", "0.192"),
+			want: apis.InstanceTypePrice{Arch: "amd64", VCPU: 2, Memory: 8, OnDemandPricePerHour: 0.096},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AWSPriceClient{priceData: newAtomicPriceData(apis.AWSCloudProvider, map[string]*apis.RegionalInstancePrice{
+				"us-east-1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+					"m5.large": {Arch: "amd64", VCPU: 2, Memory: 8, OnDemandPricePerHour: 0.096, Currency: "USD"},
+				}},
+			}, time.Time{})}
+			a.putOnDemandPriceData("us-east-1", apis.OSLinux, apis.TenancyShared, []string{
+				tt.item,
+				// The new instance type with a malformed item is not added
+				newAWSTestPriceItem("m5.xlarge", "NA", "16 GiB", "", "0.192"),
+			})
+
+			prices := a.priceData.Load()["us-east-1"].InstanceTypePrices
+			if _, ok := prices["m5.xlarge"]; ok {
+				t.Errorf("got m5.xlarge added, want it skipped")
+			}
+			got := prices["m5.large"]
+			if got.Arch != tt.want.Arch || got.VCPU != tt.want.VCPU || got.Memory != tt.want.Memory ||
+				got.GPU != tt.want.GPU || got.OnDemandPricePerHour != tt.want.OnDemandPricePerHour {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPutZoneOfferings(t *testing.T) {
+	zones := map[string]string{"us-east-1a": "use1-az1", "us-east-1b": "use1-az2"}
+	tests := []struct {
+		name         string
+		region       string
+		instanceType string
+		offerings    map[string][]string
+		// want are the zones of each instance type, nil means the zones are unchanged
+		want map[string][]string
+	}{
+		{
+			name:      "all instance types",
+			region:    "us-east-1",
+			offerings: map[string][]string{"m5.large": {"us-east-1b", "us-east-1a"}, "m6g.large": {"us-east-1a"}},
+			want:      map[string][]string{"m5.large": {"us-east-1a", "us-east-1b"}, "m6g.large": {"us-east-1a"}},
+		},
+		{
+			// The instance type without offerings is offered in no zone
+			name:      "instance type not offered",
+			region:    "us-east-1",
+			offerings: map[string][]string{"m5.large": {"us-east-1a"}},
+			want:      map[string][]string{"m5.large": {"us-east-1a"}, "m6g.large": nil},
+		},
+		{
+			name:         "single instance type",
+			region:       "us-east-1",
+			instanceType: "m6g.large",
+			offerings:    map[string][]string{"m6g.large": {"us-east-1b"}},
+			want:         map[string][]string{"m5.large": {"old"}, "m6g.large": {"us-east-1b"}},
+		},
+		{
+			// The offerings of a failed refresh are empty, the zones are kept
+			name:   "no offerings",
+			region: "us-east-1",
+			want:   map[string][]string{"m5.large": {"old"}, "m6g.large": {"old"}},
+		},
+		{
+			name:      "unknown region",
+			region:    "us-west-2",
+			offerings: map[string][]string{"m5.large": {"us-west-2a"}},
+			want:      map[string][]string{"m5.large": {"old"}, "m6g.large": {"old"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AWSPriceClient{priceData: newAtomicPriceData(apis.AWSCloudProvider, map[string]*apis.RegionalInstancePrice{
+				"us-east-1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+					"m5.large":  {Zones: []string{"old"}},
+					"m6g.large": {Zones: []string{"old"}},
+				}},
+			}, time.Time{})}
+			a.putZoneOfferings(tt.region, tt.instanceType, zones, tt.offerings)
+
+			for instanceType, want := range tt.want {
+				ins := a.priceData.Load()["us-east-1"].InstanceTypePrices[instanceType]
+				if !reflect.DeepEqual(ins.Zones, want) {
+					t.Errorf("got zones %v of %s, want %v", ins.Zones, instanceType, want)
+				}
+				if len(want) > 0 && want[0] == "old" {
+					continue
+				}
+				for _, zone := range want {
+					if ins.ZoneIDs[zone] != zones[zone] {
+						t.Errorf("got zone id %s of %s, want %s", ins.ZoneIDs[zone], zone, zones[zone])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestExtractEC2Spec(t *testing.T) {
+	tests := []struct {
+		name string
+		info types.InstanceTypeInfo
+		want *apis.InstanceTypeSpec
+	}{
+		{
+			name: "general purpose",
+			info: types.InstanceTypeInfo{
+				CurrentGeneration: aws.Bool(true),
+				Hypervisor:        types.InstanceTypeHypervisorNitro,
+				NetworkInfo: &types.NetworkInfo{
+					NetworkPerformance:        aws.String("Up to 10 Gigabit"),
+					MaximumNetworkInterfaces:  aws.Int32(3),
+					Ipv4AddressesPerInterface: aws.Int32(10),
+					NetworkCards:              []types.NetworkCardInfo{{BaselineBandwidthInGbps: aws.Float64(0.75)}},
+				},
+				EbsInfo: &types.EbsInfo{EbsOptimizedInfo: &types.EbsOptimizedInfo{
+					BaselineBandwidthInMbps: aws.Int32(650),
+					MaximumBandwidthInMbps:  aws.Int32(4750),
+				}},
+			},
+			want: &apis.InstanceTypeSpec{
+				NetworkPerformance:        "Up to 10 Gigabit",
+				NetworkBandwidthGbps:      0.75,
+				MaxNetworkInterfaces:      3,
+				IPv4AddressesPerInterface: 10,
+				DiskBandwidthMbps:         650,
+				DiskMaxBandwidthMbps:      4750,
+				Hypervisor:                "nitro",
+				CurrentGeneration:         aws.Bool(true),
+			},
+		},
+		{
+			// The bandwidth of all the network cards is summed
+			name: "network cards",
+			info: types.InstanceTypeInfo{NetworkInfo: &types.NetworkInfo{NetworkCards: []types.NetworkCardInfo{
+				{BaselineBandwidthInGbps: aws.Float64(100)},
+				{BaselineBandwidthInGbps: aws.Float64(100)},
+			}}},
+			want: &apis.InstanceTypeSpec{NetworkBandwidthGbps: 200},
+		},
+		{
+			name: "burstable",
+			info: types.InstanceTypeInfo{BurstablePerformanceSupported: aws.Bool(true)},
+			want: &apis.InstanceTypeSpec{Burstable: true},
+		},
+		{
+			name: "local storage",
+			info: types.InstanceTypeInfo{InstanceStorageInfo: &types.InstanceStorageInfo{
+				TotalSizeInGB: aws.Int64(474),
+				NvmeSupport:   types.EphemeralNvmeSupportRequired,
+				Disks:         []types.DiskInfo{{Type: types.DiskTypeSsd}},
+			}},
+			want: &apis.InstanceTypeSpec{LocalStorage: 474, LocalStorageType: "ssd", LocalStorageNVMe: true},
+		},
+		{
+			name: "gpu",
+			info: types.InstanceTypeInfo{GpuInfo: &types.GpuInfo{
+				TotalGpuMemoryInMiB: aws.Int32(16384),
+				Gpus:                []types.GpuDeviceInfo{{Name: aws.String("T4"), Manufacturer: aws.String("NVIDIA")}},
+			}},
+			want: &apis.InstanceTypeSpec{GPUModel: "T4", GPUManufacturer: "NVIDIA", GPUMemory: 16},
+		},
+		{
+			name: "accelerators",
+			info: types.InstanceTypeInfo{
+				InferenceAcceleratorInfo: &types.InferenceAcceleratorInfo{Accelerators: []types.InferenceDeviceInfo{{
+					Name: aws.String("Inferentia"), Manufacturer: aws.String("AWS"), Count: aws.Int32(1),
+					MemoryInfo: &types.InferenceDeviceMemoryInfo{SizeInMiB: aws.Int32(8192)},
+				}}},
+				NeuronInfo: &types.NeuronInfo{NeuronDevices: []types.NeuronDeviceInfo{
+					{Name: aws.String("Trainium2"), Count: aws.Int32(16), MemoryInfo: &types.NeuronDeviceMemoryInfo{SizeInMiB: aws.Int32(98304)}},
+					{Name: aws.String("Inferentia2"), Count: aws.Int32(1)},
+				}},
+				FpgaInfo: &types.FpgaInfo{Fpgas: []types.FpgaDeviceInfo{{
+					Name: aws.String("Virtex UltraScale (VU9P)"), Manufacturer: aws.String("Xilinx"), Count: aws.Int32(1),
+				}}},
+			},
+			want: &apis.InstanceTypeSpec{Accelerators: []apis.Accelerator{
+				{Type: apis.AcceleratorInferentia, Name: "Inferentia", Manufacturer: "AWS", Count: 1, Memory: 8},
+				{Type: apis.AcceleratorTrainium, Name: "Trainium2", Count: 16, Memory: 96},
+				{Type: apis.AcceleratorInferentia, Name: "Inferentia2", Count: 1},
+				{Type: apis.AcceleratorFPGA, Name: "Virtex UltraScale (VU9P)", Manufacturer: "Xilinx", Count: 1},
+			}},
+		},
+		{name: "no info", want: &apis.InstanceTypeSpec{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractEC2Spec(&tt.info); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPutInstanceTypeSpecs(t *testing.T) {
+	a := &AWSPriceClient{priceData: newAtomicPriceData(apis.AWSCloudProvider, map[string]*apis.RegionalInstancePrice{
+		"us-east-1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+			"m5.large":  {Arch: "amd64"},
+			"m6g.large": {Arch: "amd64"},
+			"m7i.large": {Arch: "amd64"},
+		}},
+	}, time.Time{})}
+	a.putInstanceTypeSpecs("us-east-1", []types.InstanceTypeInfo{
+		{
+			InstanceType:  types.InstanceTypeM5Large,
+			ProcessorInfo: &types.ProcessorInfo{SupportedArchitectures: []types.ArchitectureType{types.ArchitectureTypeX8664}},
+			Hypervisor:    types.InstanceTypeHypervisorNitro,
+		},
+		{
+			InstanceType:  types.InstanceTypeM6gLarge,
+			ProcessorInfo: &types.ProcessorInfo{SupportedArchitectures: []types.ArchitectureType{types.ArchitectureTypeArm64}},
+		},
+		// The instance types without prices are skipped
+		{InstanceType: types.InstanceTypeC5Large},
+	})
+
+	prices := a.priceData.Load()["us-east-1"].InstanceTypePrices
+	if prices["m5.large"].Arch != "amd64" || prices["m5.large"].Spec == nil || prices["m5.large"].Spec.Hypervisor != "nitro" {
+		t.Errorf("got m5.large %+v, want amd64 with the spec", prices["m5.large"])
+	}
+	if prices["m6g.large"].Arch != "arm64" || prices["m6g.large"].Spec == nil {
+		t.Errorf("got m6g.large %+v, want arm64 with the spec", prices["m6g.large"])
+	}
+	if prices["m7i.large"].Spec != nil {
+		t.Errorf("got m7i.large spec %+v, want nil", prices["m7i.large"].Spec)
+	}
+	if _, ok := prices["c5.large"]; ok {
+		t.Errorf("got c5.large, want it skipped")
+	}
+}
+
+func TestParseAWSSpotPlacementScoreTargets(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    []AWSSpotPlacementScoreTarget
+		wantErr bool
+	}{
+		{s: ""},
+		{s: "m5.large:10", want: []AWSSpotPlacementScoreTarget{{InstanceType: "m5.large", TargetCapacity: 10}}},
+		{
+			s: " m5.large:10, c5.xlarge:1 ,",
+			want: []AWSSpotPlacementScoreTarget{
+				{InstanceType: "m5.large", TargetCapacity: 10},
+				{InstanceType: "c5.xlarge", TargetCapacity: 1},
+			},
+		},
+		{s: "m5.large", wantErr: true},
+		{s: "m5.large:0", wantErr: true},
+		{s: "m5.large:ten", wantErr: true},
+		{s: "m5.large:4294967296", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := parseAWSSpotPlacementScoreTargets(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshSpotInterruptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{
+  "ranges": [{"index": 0, "label": "<5%"}, {"index": 3, "label": "15-20%"}],
+  "spot_advisor": {
+    "us-east-1": {
+      "Linux": {"m5.large": {"s": 70, "r": 0}, "c5.large": {"s": 60, "r": 3}},
+      "Windows": {"m5.large": {"s": 40, "r": 3}, "c5.large": {"s": 30, "r": 0}}
+    }
+  }
+}`)
+	}))
+	defer server.Close()
+
+	a := &AWSPriceClient{
+		config:     AWSConfig{SpotAdvisorEndpoint: server.URL},
+		httpClient: server.Client(),
+		priceData: newAtomicPriceData(apis.AWSCloudProvider, map[string]*apis.RegionalInstancePrice{
+			"us-east-1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+				"m5.large": {Platforms: map[string]*apis.PlatformPrice{"windows/shared": {OnDemandPricePerHour: 0.188}}},
+				"c5.large": {},
+				// The interruption of the instance type without advice is removed
+				"m6g.large": {SpotInterruption: &apis.SpotInterruption{Bucket: 1}},
+			}},
+		}, time.Time{}),
+	}
+	a.RefreshSpotInterruptions("", "")
+
+	prices := a.priceData.Load()["us-east-1"].InstanceTypePrices
+	tests := []struct {
+		instanceType string
+		got          *apis.SpotInterruption
+		want         *apis.SpotInterruption
+	}{
+		{instanceType: "m5.large", got: prices["m5.large"].SpotInterruption, want: &apis.SpotInterruption{Range: "<5%", Savings: 70}},
+		{
+			instanceType: "m5.large windows",
+			got:          prices["m5.large"].Platforms["windows/shared"].SpotInterruption,
+			want:         &apis.SpotInterruption{Bucket: 3, Range: "15-20%", Savings: 40},
+		},
+		{instanceType: "c5.large", got: prices["c5.large"].SpotInterruption, want: &apis.SpotInterruption{Bucket: 3, Range: "15-20%", Savings: 60}},
+		{instanceType: "m6g.large", got: prices["m6g.large"].SpotInterruption},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("got interruption %+v of %s, want %+v", tt.got, tt.instanceType, tt.want)
+		}
+	}
+	// The platforms without prices are not added
+	if _, ok := prices["c5.large"].Platforms["windows/shared"]; ok {
+		t.Errorf("got the windows platform of c5.large, want none")
+	}
+}
+
+func TestPutSpotPlacementScores(t *testing.T) {
+	newPriceData := func() map[string]*apis.RegionalInstancePrice {
+		return map[string]*apis.RegionalInstancePrice{
+			"us-east-1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+				"m5.large": {ZoneIDs: map[string]string{"us-east-1a": "use1-az4", "us-east-1b": "use1-az6"}},
+			}},
+			"us-west-2": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+				"m5.large": {SpotPlacementScores: map[string]map[string]int{"10": {"us-west-2a": 5}}},
+			}},
+			"cn-north-1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+				"m5.large": {SpotPlacementScores: map[string]map[string]int{"10": {"cn-north-1a": 7}}},
+			}},
+		}
+	}
+	target := AWSSpotPlacementScoreTarget{InstanceType: "m5.large", TargetCapacity: 10}
+	scores := []types.SpotPlacementScore{
+		{Region: aws.String("us-east-1"), AvailabilityZoneId: aws.String("use1-az4"), Score: aws.Int32(9)},
+		{Region: aws.String("us-east-1"), AvailabilityZoneId: aws.String("use1-az6"), Score: aws.Int32(3)},
+		// The zone id without a zone name is kept
+		{Region: aws.String("us-east-1"), AvailabilityZoneId: aws.String("use1-az1"), Score: aws.Int32(1)},
+	}
+
+	tests := []struct {
+		name            string
+		partitionRegion string
+		want            map[string]map[string]map[string]int
+	}{
+		{
+			// The scores of us-west-2 are removed since there are none
+			name:            "global partition",
+			partitionRegion: "us-east-1",
+			want: map[string]map[string]map[string]int{
+				"us-east-1":  {"10": {"us-east-1a": 9, "us-east-1b": 3, "use1-az1": 1}},
+				"us-west-2":  {},
+				"cn-north-1": {"10": {"cn-north-1a": 7}},
+			},
+		},
+		{
+			name:            "china partition",
+			partitionRegion: "cn-north-1",
+			want: map[string]map[string]map[string]int{
+				"us-east-1":  nil,
+				"us-west-2":  {"10": {"us-west-2a": 5}},
+				"cn-north-1": {},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AWSPriceClient{priceData: newAtomicPriceData(apis.AWSCloudProvider, newPriceData(), time.Time{})}
+			a.putSpotPlacementScores(tt.partitionRegion, target, scores)
+			for region, want := range tt.want {
+				got := a.priceData.Load()[region].InstanceTypePrices["m5.large"].SpotPlacementScores
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("got scores %v of %s, want %v", got, region, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,660 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+	"github.com/cloudpilot-ai/priceserver/pkg/storage"
+)
+
+const (
+	azureDefaultRetailPricesEndpoint = "https://prices.azure.com"
+	azureDefaultManagementEndpoint   = "https://management.azure.com"
+	azureDefaultLoginEndpoint        = "https://login.microsoftonline.com"
+
+	// The preview api version is required to get the savings plan prices
+	azureRetailPricesAPIVersion = "2023-01-01-preview"
+	azureResourceSKUsAPIVersion = "2021-07-01"
+
+	azureRetailPriceFilter = "serviceName eq 'Virtual Machines' and armRegionName eq '%s'"
+	// azureSpotPriceFilter only lists the spot prices, it's much smaller than the full catalog
+	azureSpotPriceFilter = azureRetailPriceFilter + " and priceType eq 'Consumption' and contains(skuName, 'Spot')"
+)
+
+const azureService = "vm"
+
+func init() {
+	RegisterProviderFactory(apis.AzureCloudProvider, azureService, newAzurePriceProvider)
+}
+
+// AzureConfig contains the settings of the Azure price client, the endpoints can be
+// pointed to a local HTTP stand-in of the retail prices and management APIs.
+type AzureConfig struct {
+	TenantID       string
+	ClientID       string
+	ClientSecret   string
+	SubscriptionID string
+
+	RetailPricesEndpoint string
+	ManagementEndpoint   string
+	LoginEndpoint        string
+}
+
+type AzurePriceClient struct {
+	config      AzureConfig
+	httpClient  *http.Client
+	store       storage.Store
+	spotHistory storage.SpotHistoryStore
+	tokenSource *azureTokenSource
+
+	priceData *atomicPriceData
+}
+
+func newAzurePriceProvider(opts *ProviderOptions) (PriceProvider, error) {
+	config := AzureConfig{
+		TenantID:             os.Getenv(apis.AzureTenantIDEnv),
+		ClientID:             os.Getenv(apis.AzureClientIDEnv),
+		ClientSecret:         os.Getenv(apis.AzureClientSecretEnv),
+		SubscriptionID:       os.Getenv(apis.AzureSubscriptionIDEnv),
+		RetailPricesEndpoint: os.Getenv(apis.AzureRetailPricesEndpointEnv),
+		ManagementEndpoint:   os.Getenv(apis.AzureManagementEndpointEnv),
+	}
+	if config.TenantID == "" || config.ClientID == "" || config.ClientSecret == "" {
+		return nil, fmt.Errorf("azure tenant id, client id and client secret are not set: %w", ErrMissingCredentials)
+	}
+	if config.SubscriptionID == "" {
+		return nil, fmt.Errorf("azure subscription id is not set: %w", ErrMissingCredentials)
+	}
+
+	return NewAzurePriceClient(config, opts.Store, opts.SpotHistory, opts.InitialSpotUpdate)
+}
+
+func NewAzurePriceClient(config AzureConfig, store storage.Store, spotHistory storage.SpotHistoryStore,
+	initialUpdate bool) (*AzurePriceClient, error) {
+	if config.RetailPricesEndpoint == "" {
+		config.RetailPricesEndpoint = azureDefaultRetailPricesEndpoint
+	}
+	if config.ManagementEndpoint == "" {
+		config.ManagementEndpoint = azureDefaultManagementEndpoint
+	}
+	if config.LoginEndpoint == "" {
+		config.LoginEndpoint = azureDefaultLoginEndpoint
+	}
+
+	priceData, updatedAt, err := loadPriceData(store, apis.AzureCloudProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &AzurePriceClient{
+		config:      config,
+		httpClient:  &http.Client{Timeout: time.Minute},
+		store:       store,
+		spotHistory: spotHistory,
+		priceData:   newAtomicPriceData(apis.AzureCloudProvider, priceData, updatedAt),
+	}
+	// The retail prices API is unauthenticated, the token is only required by the resource SKUs API
+	if config.ClientID != "" {
+		client.tokenSource = &azureTokenSource{
+			config:     config,
+			httpClient: client.httpClient,
+		}
+	}
+
+	if initialUpdate {
+		if err := client.RefreshPrice(); err != nil {
+			if len(client.priceData.Load()) == 0 {
+				return nil, err
+			}
+			// The failed regions keep the loaded data until the next refresh
+			klog.Errorf("Failed to refresh azure prices: %v", err)
+		}
+		persistPriceData(client.store, client)
+		recordSpotHistory(client.spotHistory, client)
+	}
+
+	return client, nil
+}
+
+func (a *AzurePriceClient) Name() string {
+	return apis.AzureCloudProvider
+}
+
+func (a *AzurePriceClient) Service() string {
+	return azureService
+}
+
+func (a *AzurePriceClient) Run(ctx context.Context) {
+	odTicker := time.NewTicker(time.Hour * 24 * 7)
+	defer odTicker.Stop()
+
+	spotTicker := time.NewTicker(time.Minute * 30)
+	defer spotTicker.Stop()
+
+	for {
+		select {
+		case <-odTicker.C:
+			if err := a.RefreshPrice(); err != nil {
+				klog.Errorf("Failed to refresh azure prices: %v", err)
+			}
+			persistPriceData(a.store, a)
+		case <-spotTicker.C:
+			if err := a.RefreshSpotPrice(); err != nil {
+				klog.Errorf("Failed to refresh azure spot prices: %v", err)
+			}
+			persistPriceData(a.store, a)
+			recordSpotHistory(a.spotHistory, a)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Refresh refreshes all the prices, the resource SKUs are listed for all regions at once,
+// so region and instance type are ignored.
+func (a *AzurePriceClient) Refresh(_, _ string) {
+	if err := a.RefreshPrice(); err != nil {
+		klog.Errorf("Failed to refresh azure prices: %v", err)
+	}
+	persistPriceData(a.store, a)
+}
+
+func (a *AzurePriceClient) Health() error {
+	if len(a.priceData.Load()) == 0 {
+		return fmt.Errorf("no price data is available for azure")
+	}
+	return nil
+}
+
+func (a *AzurePriceClient) DataVersion() (uint64, time.Time) {
+	return a.priceData.Version()
+}
+
+func (a *AzurePriceClient) PriceDelta(since uint64, region string) *apis.PriceDelta {
+	return a.priceData.Delta(since, region)
+}
+
+func (a *AzurePriceClient) WatchPrices(ctx context.Context, lastEventID string, filter *apis.PriceEventFilter) (*PriceWatch, error) {
+	return a.priceData.events.Watch(ctx, lastEventID, filter)
+}
+
+type AzureRetailPrice struct {
+	CurrencyCode    string  `json:"currencyCode"`
+	RetailPrice     float64 `json:"retailPrice"`
+	ArmRegionName   string  `json:"armRegionName"`
+	ArmSkuName      string  `json:"armSkuName"`
+	SkuName         string  `json:"skuName"`
+	ProductName     string  `json:"productName"`
+	ServiceName     string  `json:"serviceName"`
+	Type            string  `json:"type"`
+	ReservationTerm string  `json:"reservationTerm"`
+	UnitOfMeasure   string  `json:"unitOfMeasure"`
+	SavingsPlan     []struct {
+		RetailPrice float64 `json:"retailPrice"`
+		Term        string  `json:"term"`
+	} `json:"savingsPlan"`
+}
+
+type azureRetailPriceList struct {
+	Items        []AzureRetailPrice `json:"Items"`
+	NextPageLink string             `json:"NextPageLink"`
+}
+
+type AzureResourceSKU struct {
+	ResourceType string   `json:"resourceType"`
+	Name         string   `json:"name"`
+	Locations    []string `json:"locations"`
+	LocationInfo []struct {
+		Location string   `json:"location"`
+		Zones    []string `json:"zones"`
+	} `json:"locationInfo"`
+	Capabilities []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"capabilities"`
+	Restrictions []struct {
+		Type            string   `json:"type"`
+		Values          []string `json:"values"`
+		ReasonCode      string   `json:"reasonCode"`
+		RestrictionInfo struct {
+			Locations []string `json:"locations"`
+			Zones     []string `json:"zones"`
+		} `json:"restrictionInfo"`
+	} `json:"restrictions"`
+}
+
+type azureResourceSKUList struct {
+	Value    []AzureResourceSKU `json:"value"`
+	NextLink string             `json:"nextLink"`
+}
+
+func (s *AzureResourceSKU) capability(name string) string {
+	for _, c := range s.Capabilities {
+		if c.Name == name {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+func (s *AzureResourceSKU) restricted(location string) bool {
+	for _, r := range s.Restrictions {
+		if r.Type != "Location" {
+			continue
+		}
+		for _, l := range r.RestrictionInfo.Locations {
+			if strings.EqualFold(l, location) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *AzurePriceClient) doRequest(reqUrl string, auth bool, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+	if err != nil {
+		return err
+	}
+	if auth && a.tokenSource != nil {
+		token, err := a.tokenSource.Token()
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request %s failed: %s, %s", req.URL.Path, resp.Status, string(data))
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+func (a *AzurePriceClient) listResourceSKUs() ([]AzureResourceSKU, error) {
+	query := url.Values{}
+	query.Set("api-version", azureResourceSKUsAPIVersion)
+	reqUrl, err := url.JoinPath(a.config.ManagementEndpoint, "/subscriptions", a.config.SubscriptionID,
+		"/providers/Microsoft.Compute/skus")
+	if err != nil {
+		return nil, err
+	}
+	reqUrl += "?" + query.Encode()
+
+	var ret []AzureResourceSKU
+	for reqUrl != "" {
+		var data azureResourceSKUList
+		if err := a.doRequest(reqUrl, true, &data); err != nil {
+			klog.Errorf("Failed to list azure resource skus: %v", err)
+			return nil, err
+		}
+		for _, sku := range data.Value {
+			if sku.ResourceType != "virtualMachines" {
+				continue
+			}
+			ret = append(ret, sku)
+		}
+		reqUrl = data.NextLink
+	}
+
+	return ret, nil
+}
+
+// listRetailPrices lists the retail prices of the region matching the filter, %s of the filter is the region
+func (a *AzurePriceClient) listRetailPrices(region, filter string) ([]AzureRetailPrice, error) {
+	query := url.Values{}
+	query.Set("api-version", azureRetailPricesAPIVersion)
+	query.Set("$filter", fmt.Sprintf(filter, region))
+	reqUrl, err := url.JoinPath(a.config.RetailPricesEndpoint, "/api/retail/prices")
+	if err != nil {
+		return nil, err
+	}
+	reqUrl += "?" + query.Encode()
+
+	var ret []AzureRetailPrice
+	for reqUrl != "" {
+		var data azureRetailPriceList
+		if err := a.doRequest(reqUrl, false, &data); err != nil {
+			klog.Errorf("Failed to list azure retail prices in region %s: %v", region, err)
+			return nil, err
+		}
+		ret = append(ret, data.Items...)
+		reqUrl = data.NextPageLink
+	}
+
+	return ret, nil
+}
+
+func extractAzureArch(arch string) string {
+	switch strings.ToLower(arch) {
+	case "arm64":
+		return "arm64"
+	default:
+		return "amd64"
+	}
+}
+
+// extractAzureTermHours converts terms like "1 Year" or "3 Years" to the term length and the hours in the term
+func extractAzureTermHours(term string) (string, float64, error) {
+	fields := strings.Fields(term)
+	if len(fields) != 2 {
+		return "", 0, fmt.Errorf("invalid azure term %s", term)
+	}
+	years, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return "", 0, err
+	}
+	return fmt.Sprintf("%dyr", years), float64(years * 365 * 24), nil
+}
+
+func azureZoneName(region, zone string) string {
+	return fmt.Sprintf("%s-%s", region, zone)
+}
+
+func newAzureInstanceTypes(region string, skus []AzureResourceSKU) map[string]*apis.InstanceTypePrice {
+	ret := map[string]*apis.InstanceTypePrice{}
+	for i := range skus {
+		sku := &skus[i]
+		available := false
+		var zones []string
+		for _, info := range sku.LocationInfo {
+			if !strings.EqualFold(info.Location, region) {
+				continue
+			}
+			available = true
+			for _, zone := range info.Zones {
+				zones = append(zones, azureZoneName(region, zone))
+			}
+		}
+		if !available || sku.restricted(region) {
+			continue
+		}
+
+		ins := &apis.InstanceTypePrice{
+			Arch:     extractAzureArch(sku.capability("CpuArchitectureType")),
+			Zones:    zones,
+			Currency: currency.USD,
+		}
+		ins.VCPU, _ = strconv.ParseFloat(sku.capability("vCPUs"), 64)
+		ins.Memory, _ = strconv.ParseFloat(sku.capability("MemoryGB"), 64)
+		ins.GPU, _ = strconv.ParseFloat(sku.capability("GPUs"), 64)
+		ret[sku.Name] = ins
+	}
+	return ret
+}
+
+func putAzureRetailPrice(ins *apis.InstanceTypePrice, region string, item *AzureRetailPrice) {
+	// Only the Linux prices are collected
+	if strings.Contains(item.ProductName, "Windows") || strings.HasSuffix(item.SkuName, "Low Priority") {
+		return
+	}
+
+	switch item.Type {
+	case "Consumption":
+		if item.UnitOfMeasure != "1 Hour" || item.RetailPrice == 0 {
+			return
+		}
+		if strings.HasSuffix(item.SkuName, "Spot") {
+			if ins.SpotPricePerHour == nil {
+				ins.SpotPricePerHour = map[string]float64{}
+			}
+			zones := ins.Zones
+			if len(zones) == 0 {
+				zones = []string{region}
+			}
+			for _, zone := range zones {
+				ins.SpotPricePerHour[zone] = item.RetailPrice
+			}
+			return
+		}
+
+		ins.OnDemandPricePerHour = item.RetailPrice
+		for _, sp := range item.SavingsPlan {
+			term, _, err := extractAzureTermHours(sp.Term)
+			if err != nil {
+				klog.Errorf("Failed to parse azure savings plan term: %v", err)
+				continue
+			}
+			putAzureBilling(ins, fmt.Sprintf("%s/%s", apis.AzureBillingSavingsPlan, term), sp.RetailPrice)
+		}
+	case "Reservation":
+		term, hours, err := extractAzureTermHours(item.ReservationTerm)
+		if err != nil {
+			klog.Errorf("Failed to parse azure reservation term: %v", err)
+			return
+		}
+		// The retail price of reservation is the total cost of the term
+		putAzureBilling(ins, fmt.Sprintf("%s/%s", apis.AzureBillingReservation, term), item.RetailPrice/hours)
+	}
+}
+
+func putAzureBilling(ins *apis.InstanceTypePrice, key string, rate float64) {
+	if ins.AzureBilling == nil {
+		ins.AzureBilling = map[string]apis.AzureBilling{}
+	}
+	ins.AzureBilling[key] = apis.AzureBilling{Rate: rate}
+}
+
+// RefreshPrice refreshes the instance types and all their prices, the regions failed to refresh keep the previous
+// data and their errors are returned
+func (a *AzurePriceClient) RefreshPrice() error {
+	skus, err := a.listResourceSKUs()
+	if err != nil {
+		return err
+	}
+
+	regionSet := map[string]struct{}{}
+	for _, sku := range skus {
+		for _, location := range sku.Locations {
+			regionSet[strings.ToLower(location)] = struct{}{}
+		}
+	}
+	regions := make([]string, 0, len(regionSet))
+	for region := range regionSet {
+		regions = append(regions, region)
+	}
+
+	results := make([]*apis.RegionalInstancePrice, len(regions))
+	errs := make([]error, len(regions))
+	workqueue.ParallelizeUntil(context.Background(), 10, len(regions), func(i int) {
+		region := regions[i]
+		klog.Infof("Start to handle region %s for azure", region)
+		prices, err := a.listRetailPrices(region, azureRetailPriceFilter)
+		if err != nil {
+			errs[i] = fmt.Errorf("region %s: %w", region, err)
+			return
+		}
+
+		instanceTypes := newAzureInstanceTypes(region, skus)
+		for j := range prices {
+			ins, ok := instanceTypes[prices[j].ArmSkuName]
+			if !ok {
+				continue
+			}
+			putAzureRetailPrice(ins, region, &prices[j])
+		}
+		for name, ins := range instanceTypes {
+			if ins.OnDemandPricePerHour == 0 {
+				delete(instanceTypes, name)
+			}
+		}
+		results[i] = &apis.RegionalInstancePrice{InstanceTypePrices: instanceTypes}
+	})
+
+	a.priceData.Update(func(w *priceDataWriter) {
+		for i, region := range regions {
+			// Keep the previous data if the region fails to refresh
+			if results[i] == nil || len(results[i].InstanceTypePrices) == 0 {
+				continue
+			}
+			w.PutRegion(region, results[i].InstanceTypePrices)
+		}
+	})
+
+	klog.Infof("All prices are refreshed for Azure")
+	return errors.Join(errs...)
+}
+
+// RefreshSpotPrice refreshes the spot prices of the instance types with the spot retail prices only, the regions
+// failed to refresh keep the previous spot prices and their errors are returned
+func (a *AzurePriceClient) RefreshSpotPrice() error {
+	data := a.priceData.Load()
+	regions := sortedRegions(data)
+
+	results := make([]map[string]map[string]float64, len(regions))
+	errs := make([]error, len(regions))
+	workqueue.ParallelizeUntil(context.Background(), 10, len(regions), func(i int) {
+		region := regions[i]
+		prices, err := a.listRetailPrices(region, azureSpotPriceFilter)
+		if err != nil {
+			errs[i] = fmt.Errorf("region %s: %w", region, err)
+			return
+		}
+
+		spotPrices := map[string]map[string]float64{}
+		for j := range prices {
+			old, ok := data[region].InstanceTypePrices[prices[j].ArmSkuName]
+			if !ok {
+				continue
+			}
+			// The spot prices are set for the zones of the instance type
+			ins := &apis.InstanceTypePrice{Zones: old.Zones, SpotPricePerHour: spotPrices[prices[j].ArmSkuName]}
+			putAzureRetailPrice(ins, region, &prices[j])
+			if ins.SpotPricePerHour != nil {
+				spotPrices[prices[j].ArmSkuName] = ins.SpotPricePerHour
+			}
+		}
+		results[i] = spotPrices
+	})
+
+	a.priceData.Update(func(w *priceDataWriter) {
+		for i, region := range regions {
+			if results[i] == nil {
+				continue
+			}
+			for instanceType := range w.Data()[region].InstanceTypePrices {
+				spotPrices := results[i][instanceType]
+				if old, _ := w.Get(region, instanceType); maps.Equal(old.SpotPricePerHour, spotPrices) {
+					continue
+				}
+				ins, _ := w.Mutable(region, instanceType)
+				ins.SpotPricePerHour = spotPrices
+			}
+		}
+	})
+
+	klog.Infof("All spot prices are refreshed for Azure")
+	return errors.Join(errs...)
+}
+
+func (a *AzurePriceClient) ListRegions() []string {
+	return sortedRegions(a.priceData.Load())
+}
+
+func (a *AzurePriceClient) ListRegionsInstancesPrice() map[string]*apis.RegionalInstancePrice {
+	return a.priceData.Load()
+}
+
+func (a *AzurePriceClient) ListInstancesPrice(region string) *map[string]apis.RegionalInstancePrice {
+	d, ok := a.priceData.Load()[region]
+	if !ok {
+		return nil
+	}
+	return &map[string]apis.RegionalInstancePrice{
+		region: *d,
+	}
+}
+
+func (a *AzurePriceClient) GetInstancePrice(region, instanceType string) *apis.InstanceTypePrice {
+	regionData, ok := a.priceData.Load()[region]
+	if !ok {
+		return nil
+	}
+	d, ok := regionData.InstanceTypePrices[instanceType]
+	if !ok {
+		return nil
+	}
+
+	return d
+}
+
+// azureTokenSource gets the access token of the management API with the client credentials flow
+type azureTokenSource struct {
+	config     AzureConfig
+	httpClient *http.Client
+
+	mutex  sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (t *azureTokenSource) Token() (string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	// Refresh the token a minute before it expires
+	if t.token != "" && time.Now().Add(time.Minute).Before(t.expiry) {
+		return t.token, nil
+	}
+
+	tokenUrl, err := url.JoinPath(t.config.LoginEndpoint, t.config.TenantID, "/oauth2/v2.0/token")
+	if err != nil {
+		return "", err
+	}
+	resp, err := t.httpClient.PostForm(tokenUrl, url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {t.config.ClientID},
+		"client_secret": {t.config.ClientSecret},
+		"scope":         {strings.TrimSuffix(t.config.ManagementEndpoint, "/") + "/.default"},
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get azure access token: %s, %s", resp.Status, string(data))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return "", err
+	}
+	t.token = token.AccessToken
+	t.expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return t.token, nil
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+const azureTestSKUsPage1 = `{
+  "value": [
+    {"resourceType": "virtualMachines", "name": "Standard_D2s_v5", "locations": ["eastus"],
+     "locationInfo": [{"location": "eastus", "zones": ["1", "2"]}],
+     "capabilities": [{"name": "vCPUs", "value": "2"}, {"name": "MemoryGB", "value": "8"}, {"name": "CpuArchitectureType", "value": "x64"}]},
+    {"resourceType": "disks", "name": "Premium_LRS", "locations": ["eastus"], "locationInfo": [{"location": "eastus"}]}
+  ],
+  "nextLink": "%s/subscriptions/test/providers/Microsoft.Compute/skus?page=2"
+}`
+
+const azureTestSKUsPage2 = `{
+  "value": [
+    {"resourceType": "virtualMachines", "name": "Standard_D2ps_v5", "locations": ["westus"],
+     "locationInfo": [{"location": "westus"}],
+     "capabilities": [{"name": "vCPUs", "value": "2"}, {"name": "MemoryGB", "value": "8"}, {"name": "CpuArchitectureType", "value": "Arm64"}]}
+  ]
+}`
+
+const azureTestRetailPricesPage1 = `{
+  "Items": [
+    {"retailPrice": 0.096, "armRegionName": "eastus", "armSkuName": "Standard_D2s_v5", "skuName": "D2s v5",
+     "productName": "Virtual Machines Dsv5 Series", "type": "Consumption", "unitOfMeasure": "1 Hour",
+     "savingsPlan": [{"retailPrice": 0.0672, "term": "1 Year"}, {"retailPrice": 0.0468, "term": "3 Years"}]},
+    {"retailPrice": 0.188, "armRegionName": "eastus", "armSkuName": "Standard_D2s_v5", "skuName": "D2s v5",
+     "productName": "Virtual Machines Dsv5 Series Windows", "type": "Consumption", "unitOfMeasure": "1 Hour"},
+    {"retailPrice": 0.0192, "armRegionName": "eastus", "armSkuName": "Standard_D2s_v5", "skuName": "D2s v5 Spot",
+     "productName": "Virtual Machines Dsv5 Series", "type": "Consumption", "unitOfMeasure": "1 Hour"}
+  ],
+  "NextPageLink": "%s/api/retail/prices?page=2"
+}`
+
+const azureTestRetailPricesPage2 = `{
+  "Items": [
+    {"retailPrice": 508.08, "armRegionName": "eastus", "armSkuName": "Standard_D2s_v5", "skuName": "D2s v5",
+     "productName": "Virtual Machines Dsv5 Series", "type": "Reservation", "reservationTerm": "1 Year", "unitOfMeasure": "1 Hour"},
+    {"retailPrice": 981.12, "armRegionName": "eastus", "armSkuName": "Standard_D2s_v5", "skuName": "D2s v5",
+     "productName": "Virtual Machines Dsv5 Series", "type": "Reservation", "reservationTerm": "3 Years", "unitOfMeasure": "1 Hour"}
+  ]
+}`
+
+const azureTestSpotPrices = `{
+  "Items": [
+    {"retailPrice": 0.0201, "armRegionName": "eastus", "armSkuName": "Standard_D2s_v5", "skuName": "D2s v5 Spot",
+     "productName": "Virtual Machines Dsv5 Series", "type": "Consumption", "unitOfMeasure": "1 Hour"}
+  ]
+}`
+
+func TestAzureRefreshPrice(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/subscriptions/test/providers/Microsoft.Compute/skus", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, azureTestSKUsPage2)
+			return
+		}
+		fmt.Fprintf(w, azureTestSKUsPage1, server.URL)
+	})
+	var spotFilters []string
+	mux.HandleFunc("/api/retail/prices", func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("$filter")
+		switch {
+		case r.URL.Query().Get("page") == "2":
+			fmt.Fprint(w, azureTestRetailPricesPage2)
+		case strings.Contains(filter, "westus"):
+			// The errors of the regions are returned after the other regions are refreshed
+			http.Error(w, "internal error", http.StatusInternalServerError)
+		case strings.Contains(filter, "Spot"):
+			spotFilters = append(spotFilters, filter)
+			fmt.Fprint(w, azureTestSpotPrices)
+		default:
+			fmt.Fprintf(w, azureTestRetailPricesPage1, server.URL)
+		}
+	})
+
+	a, err := NewAzurePriceClient(AzureConfig{
+		SubscriptionID:       "test",
+		RetailPricesEndpoint: server.URL,
+		ManagementEndpoint:   server.URL,
+	}, nil, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = a.RefreshPrice()
+	if err == nil || !strings.Contains(err.Error(), "westus") {
+		t.Errorf("got error %v, want the error of westus", err)
+	}
+	if regions := a.ListRegions(); len(regions) != 1 || regions[0] != "eastus" {
+		t.Fatalf("got regions %v, want eastus only", regions)
+	}
+
+	d2 := a.GetInstancePrice("eastus", "Standard_D2s_v5")
+	if d2 == nil {
+		t.Fatalf("Standard_D2s_v5 is not found")
+	}
+	if d2.VCPU != 2 || d2.Memory != 8 || d2.Arch != "amd64" || len(d2.Zones) != 2 {
+		t.Errorf("got Standard_D2s_v5 %+v", d2)
+	}
+	assertPrice(t, "on-demand", d2.OnDemandPricePerHour, 0.096)
+	for _, zone := range []string{"eastus-1", "eastus-2"} {
+		assertPrice(t, "spot "+zone, d2.SpotPricePerHour[zone], 0.0192)
+	}
+	// The reservation prices are the total costs of the terms
+	tests := map[string]float64{
+		"savingsplan/1yr": 0.0672,
+		"savingsplan/3yr": 0.0468,
+		"reservation/1yr": 508.08 / (365 * 24),
+		"reservation/3yr": 981.12 / (3 * 365 * 24),
+	}
+	for key, want := range tests {
+		assertPrice(t, key, d2.AzureBilling[key].Rate, want)
+	}
+
+	if err := a.RefreshSpotPrice(); err != nil {
+		t.Fatal(err)
+	}
+	if len(spotFilters) != 1 || !strings.Contains(spotFilters[0], "priceType eq 'Consumption' and contains(skuName, 'Spot')") {
+		t.Errorf("got spot filters %v", spotFilters)
+	}
+	d2 = a.GetInstancePrice("eastus", "Standard_D2s_v5")
+	for _, zone := range []string{"eastus-1", "eastus-2"} {
+		assertPrice(t, "refreshed spot "+zone, d2.SpotPricePerHour[zone], 0.0201)
+	}
+	// The other prices are kept
+	assertPrice(t, "on-demand after spot refresh", d2.OnDemandPricePerHour, 0.096)
+	assertPrice(t, "reservation after spot refresh", d2.AzureBilling[apis.AzureBillingReservation+"/1yr"].Rate, 508.08/(365*24))
+}
+
+func TestExtractAzureTermHours(t *testing.T) {
+	tests := []struct {
+		term      string
+		wantTerm  string
+		wantHours float64
+		wantErr   bool
+	}{
+		{term: "1 Year", wantTerm: "1yr", wantHours: 8760},
+		{term: "3 Years", wantTerm: "3yr", wantHours: 26280},
+		{term: "5Years", wantErr: true},
+		{term: "one Year", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			term, hours, err := extractAzureTermHours(tt.term)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if term != tt.wantTerm || hours != tt.wantHours {
+				t.Errorf("got %s, %v, want %s, %v", term, hours, tt.wantTerm, tt.wantHours)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+const (
+	// maxPriceEvents limits the events kept for resuming the watches
+	maxPriceEvents = 50000
+	// priceWatchBufferSize is the number of events a watcher can fall behind, the watch is closed if it's exceeded
+	priceWatchBufferSize = 4096
+)
+
+// PriceWatch is a watch of the price change events of a provider
+type PriceWatch struct {
+	// Events are the kept events after the last event ID
+	Events []*apis.PriceChangeEvent
+	// Missed means some events after the last event ID are not kept
+	Missed bool
+	// Changes receives the following events, it's closed when the context is done or the watcher falls behind
+	Changes <-chan *apis.PriceChangeEvent
+}
+
+// priceEventID is the version of the price data which has the change and the index of the change in the version
+type priceEventID struct {
+	version uint64
+	index   int
+}
+
+func parsePriceEventID(id string) (priceEventID, error) {
+	version, index, ok := strings.Cut(id, "-")
+	if !ok {
+		return priceEventID{}, fmt.Errorf("invalid event id %s", id)
+	}
+	v, err := strconv.ParseUint(version, 10, 64)
+	if err != nil {
+		return priceEventID{}, fmt.Errorf("invalid event id %s", id)
+	}
+	i, err := strconv.Atoi(index)
+	if err != nil {
+		return priceEventID{}, fmt.Errorf("invalid event id %s", id)
+	}
+	return priceEventID{version: v, index: i}, nil
+}
+
+func (id priceEventID) String() string {
+	return fmt.Sprintf("%d-%d", id.version, id.index)
+}
+
+func (id priceEventID) before(other priceEventID) bool {
+	return id.version < other.version || (id.version == other.version && id.index < other.index)
+}
+
+// priceEvents keeps the recent price change events of a provider and sends the new ones to the watchers
+type priceEvents struct {
+	provider string
+
+	mutex  sync.Mutex
+	events []*apis.PriceChangeEvent
+	ids    []priceEventID
+	// dropped is the ID of the newest event which is not kept
+	dropped  priceEventID
+	watchers map[*priceWatcher]struct{}
+}
+
+type priceWatcher struct {
+	filter  *apis.PriceEventFilter
+	changes chan *apis.PriceChangeEvent
+}
+
+// newPriceEvents creates the events of the price data of the version, the events of the older versions are unknown
+func newPriceEvents(provider string, version uint64) *priceEvents {
+	return &priceEvents{
+		provider: provider,
+		dropped:  priceEventID{version: version},
+		watchers: map[*priceWatcher]struct{}{},
+	}
+}
+
+// Watch returns the kept events after the last event ID, which are followed by the new events sent to the channel.
+// Empty last event ID means only the new events are watched.
+func (e *priceEvents) Watch(ctx context.Context, lastEventID string, filter *apis.PriceEventFilter) (*PriceWatch, error) {
+	var last *priceEventID
+	if lastEventID != "" {
+		id, err := parsePriceEventID(lastEventID)
+		if err != nil {
+			return nil, err
+		}
+		last = &id
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	watch := &PriceWatch{}
+	if last != nil {
+		watch.Missed = last.before(e.dropped)
+		i := sort.Search(len(e.ids), func(i int) bool {
+			return last.before(e.ids[i])
+		})
+		for _, event := range e.events[i:] {
+			if filter.Match(event) {
+				watch.Events = append(watch.Events, event)
+			}
+		}
+	}
+
+	w := &priceWatcher{
+		filter:  filter,
+		changes: make(chan *apis.PriceChangeEvent, priceWatchBufferSize),
+	}
+	e.watchers[w] = struct{}{}
+	watch.Changes = w.changes
+	go func() {
+		<-ctx.Done()
+		e.unwatch(w)
+	}()
+	return watch, nil
+}
+
+func (e *priceEvents) unwatch(w *priceWatcher) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if _, ok := e.watchers[w]; ok {
+		delete(e.watchers, w)
+		close(w.changes)
+	}
+}
+
+// publish keeps the events of the version and sends them to the watchers
+func (e *priceEvents) publish(version uint64, events []*apis.PriceChangeEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for i, event := range events {
+		id := priceEventID{version: version, index: i}
+		event.ID = id.String()
+		event.Provider = e.provider
+		e.events = append(e.events, event)
+		e.ids = append(e.ids, id)
+	}
+	// The events are dropped in batches, so they're not copied on every publish
+	if len(e.events) > maxPriceEvents+maxPriceEvents/4 {
+		drop := len(e.events) - maxPriceEvents
+		e.dropped = e.ids[drop-1]
+		e.events = append([]*apis.PriceChangeEvent(nil), e.events[drop:]...)
+		e.ids = append([]priceEventID(nil), e.ids[drop:]...)
+	}
+
+	for w := range e.watchers {
+		for _, event := range events {
+			if !w.filter.Match(event) {
+				continue
+			}
+			select {
+			case w.changes <- event:
+			default:
+				// The watcher is too slow, it should resume from its last event
+				delete(e.watchers, w)
+				close(w.changes)
+			}
+			if _, ok := e.watchers[w]; !ok {
+				break
+			}
+		}
+	}
+}
+
+// priceCapacity is a capacity type of an instance type, the zone is only set for spot
+type priceCapacity struct {
+	capacityType string
+	zone         string
+}
+
+// capacityPrices returns the prices per hour of all the capacity types of the price, nil price has no price
+func capacityPrices(price *apis.InstanceTypePrice) map[priceCapacity]float64 {
+	ret := map[priceCapacity]float64{}
+	if price == nil {
+		return ret
+	}
+	if price.OnDemandPricePerHour > 0 {
+		ret[priceCapacity{capacityType: apis.CapacityTypeOnDemand}] = price.OnDemandPricePerHour
+	}
+	for zone, p := range price.SpotPricePerHour {
+		if p > 0 {
+			ret[priceCapacity{capacityType: apis.CapacityTypeSpot, zone: zone}] = p
+		}
+	}
+	for k, b := range price.AWSEC2Billing {
+		ret[priceCapacity{capacityType: k}] = b.Rate
+	}
+	for k, b := range price.AWSEC2ReservedBilling {
+		ret[priceCapacity{capacityType: k}] = b.Rate
+	}
+	for k, b := range price.GCPCommittedUseBilling {
+		ret[priceCapacity{capacityType: k}] = b.Rate
+	}
+	for k, b := range price.AzureBilling {
+		ret[priceCapacity{capacityType: k}] = b.Rate
+	}
+	for k, b := range price.AlibabaCloudBilling {
+		ret[priceCapacity{capacityType: k}] = b.Rate
+	}
+	for k, p := range ret {
+		if p <= 0 {
+			delete(ret, k)
+		}
+	}
+	return ret
+}
+
+// priceChangeEvents returns the events of the changed prices of the instance types, nil price means the instance
+// type is added or removed
+func priceChangeEvents(region, instanceType string, old, price *apis.InstanceTypePrice, t time.Time) []*apis.PriceChangeEvent {
+	oldPrices, prices := capacityPrices(old), capacityPrices(price)
+	capacities := map[priceCapacity]struct{}{}
+	for c := range oldPrices {
+		capacities[c] = struct{}{}
+	}
+	for c := range prices {
+		capacities[c] = struct{}{}
+	}
+	currency := ""
+	for _, p := range []*apis.InstanceTypePrice{old, price} {
+		if p != nil {
+			currency = p.Currency
+		}
+	}
+
+	var ret []*apis.PriceChangeEvent
+	for c := range capacities {
+		if oldPrices[c] == prices[c] {
+			continue
+		}
+		ret = append(ret, &apis.PriceChangeEvent{
+			Region:       region,
+			InstanceType: instanceType,
+			Zone:         c.zone,
+			CapacityType: c.capacityType,
+			OldPrice:     oldPrices[c],
+			NewPrice:     prices[c],
+			Currency:     currency,
+			Time:         t,
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].CapacityType != ret[j].CapacityType {
+			return ret[i].CapacityType < ret[j].CapacityType
+		}
+		return ret[i].Zone < ret[j].Zone
+	})
+	return ret
+}
@@ -0,0 +1,229 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+func newTestEvents(n int) []*apis.PriceChangeEvent {
+	ret := make([]*apis.PriceChangeEvent, n)
+	for i := range ret {
+		ret[i] = &apis.PriceChangeEvent{
+			Region:       "r1",
+			InstanceType: fmt.Sprintf("t%d", i%2),
+			CapacityType: apis.CapacityTypeOnDemand,
+			NewPrice:     float64(i + 1),
+		}
+	}
+	return ret
+}
+
+func eventIDs(events []*apis.PriceChangeEvent) []string {
+	var ret []string
+	for _, e := range events {
+		ret = append(ret, e.ID)
+	}
+	return ret
+}
+
+func TestPriceEventsWatchResume(t *testing.T) {
+	e := newPriceEvents(apis.GCPCloudProvider, 100)
+	e.publish(101, newTestEvents(2))
+	e.publish(102, newTestEvents(2))
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		filter      *apis.PriceEventFilter
+		wantIDs     []string
+		wantMissed  bool
+		wantErr     bool
+	}{
+		{name: "new events only", filter: &apis.PriceEventFilter{}},
+		{
+			name:        "resume in a version",
+			lastEventID: "101-0",
+			filter:      &apis.PriceEventFilter{},
+			wantIDs:     []string{"101-1", "102-0", "102-1"},
+		},
+		{
+			name:        "resume after a version",
+			lastEventID: "101-1",
+			filter:      &apis.PriceEventFilter{},
+			wantIDs:     []string{"102-0", "102-1"},
+		},
+		{
+			name:        "resume with the filter",
+			lastEventID: "101-0",
+			filter:      &apis.PriceEventFilter{InstanceTypes: []string{"t1"}},
+			wantIDs:     []string{"101-1", "102-1"},
+		},
+		{
+			name:        "resume from the latest event",
+			lastEventID: "102-1",
+			filter:      &apis.PriceEventFilter{},
+		},
+		{
+			// The events before the initial version are unknown
+			name:        "resume before the initial version",
+			lastEventID: "99-0",
+			filter:      &apis.PriceEventFilter{},
+			wantIDs:     []string{"101-0", "101-1", "102-0", "102-1"},
+			wantMissed:  true,
+		},
+		{name: "invalid id", lastEventID: "101", filter: &apis.PriceEventFilter{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			watch, err := e.Watch(ctx, tt.lastEventID, tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := eventIDs(watch.Events); !reflect.DeepEqual(got, tt.wantIDs) {
+				t.Errorf("got events %v, want %v", got, tt.wantIDs)
+			}
+			if watch.Missed != tt.wantMissed {
+				t.Errorf("got missed %v, want %v", watch.Missed, tt.wantMissed)
+			}
+		})
+	}
+}
+
+func TestPriceEventsWatchChanges(t *testing.T) {
+	e := newPriceEvents(apis.GCPCloudProvider, 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	watch, err := e.Watch(ctx, "", &apis.PriceEventFilter{InstanceTypes: []string{"t0"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e.publish(101, newTestEvents(4))
+	for _, want := range []string{"101-0", "101-2"} {
+		select {
+		case event := <-watch.Changes:
+			if event.ID != want || event.Provider != apis.GCPCloudProvider {
+				t.Errorf("got event %s of %s, want %s", event.ID, event.Provider, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event %s is not received", want)
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-watch.Changes:
+		if ok {
+			t.Errorf("got an event after the context is done")
+		}
+	case <-time.After(time.Second):
+		t.Errorf("the changes are not closed after the context is done")
+	}
+}
+
+func TestPriceEventsSlowWatcher(t *testing.T) {
+	e := newPriceEvents(apis.GCPCloudProvider, 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watch, err := e.Watch(ctx, "", &apis.PriceEventFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e.publish(101, newTestEvents(priceWatchBufferSize+1))
+	var last *apis.PriceChangeEvent
+	received := 0
+	for event := range watch.Changes {
+		last = event
+		received++
+	}
+	// The watcher resumes from its last event, the dropped event is returned then
+	if received != priceWatchBufferSize {
+		t.Fatalf("got %d events before the changes are closed, want %d", received, priceWatchBufferSize)
+	}
+	resumed, err := e.Watch(ctx, last.ID, &apis.PriceEventFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := eventIDs(resumed.Events); resumed.Missed || len(got) != 1 || got[0] != fmt.Sprintf("101-%d", priceWatchBufferSize) {
+		t.Errorf("got resumed events %v missed %v, want the dropped one", got, resumed.Missed)
+	}
+}
+
+func TestPriceEventsMissed(t *testing.T) {
+	e := newPriceEvents(apis.GCPCloudProvider, 100)
+	// The oldest events are dropped in a batch once the limit is exceeded by a quarter
+	e.publish(101, newTestEvents(maxPriceEvents))
+	e.publish(102, newTestEvents(maxPriceEvents/4+1))
+	if len(e.events) != maxPriceEvents {
+		t.Fatalf("got %d kept events, want %d", len(e.events), maxPriceEvents)
+	}
+
+	tests := []struct {
+		lastEventID string
+		wantMissed  bool
+		wantEvents  int
+	}{
+		{lastEventID: "101-0", wantMissed: true, wantEvents: maxPriceEvents},
+		{lastEventID: fmt.Sprintf("101-%d", maxPriceEvents/4), wantMissed: false, wantEvents: maxPriceEvents},
+		{lastEventID: "102-0", wantMissed: false, wantEvents: maxPriceEvents / 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lastEventID, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			watch, err := e.Watch(ctx, tt.lastEventID, &apis.PriceEventFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if watch.Missed != tt.wantMissed || len(watch.Events) != tt.wantEvents {
+				t.Errorf("got missed %v and %d events, want %v and %d", watch.Missed, len(watch.Events),
+					tt.wantMissed, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestPriceDataEvents(t *testing.T) {
+	p := newAtomicPriceData(apis.GCPCloudProvider, map[string]*apis.RegionalInstancePrice{
+		"r1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{"a": newTestPrice(1)}},
+	}, time.Time{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watch, err := p.events.Watch(ctx, "", &apis.PriceEventFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.Update(func(w *priceDataWriter) {
+		price, _ := w.Mutable("r1", "a")
+		price.SpotPricePerHour = map[string]float64{"r1-a": 0.3}
+		w.Put("r1", "b", newTestPrice(2))
+	})
+	version, _ := p.Version()
+
+	want := []apis.PriceChangeEvent{
+		{ID: fmt.Sprintf("%d-0", version), InstanceType: "a", CapacityType: apis.CapacityTypeSpot, Zone: "r1-a", NewPrice: 0.3},
+		{ID: fmt.Sprintf("%d-1", version), InstanceType: "b", CapacityType: apis.CapacityTypeOnDemand, NewPrice: 2},
+	}
+	for _, w := range want {
+		select {
+		case event := <-watch.Changes:
+			if event.ID != w.ID || event.InstanceType != w.InstanceType || event.CapacityType != w.CapacityType ||
+				event.Zone != w.Zone || event.OldPrice != 0 || event.NewPrice != w.NewPrice || event.Currency != "USD" {
+				t.Errorf("got event %+v, want %+v", event, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event %s is not received", w.ID)
+		}
+	}
+}
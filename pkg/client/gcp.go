@@ -0,0 +1,575 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+	"github.com/cloudpilot-ai/priceserver/pkg/storage"
+)
+
+const (
+	gcpDefaultCatalogEndpoint = "https://cloudbilling.googleapis.com"
+	gcpDefaultComputeEndpoint = "https://compute.googleapis.com"
+	// gcpComputeEngineServiceID is the service id of Compute Engine in the Cloud Billing Catalog
+	gcpComputeEngineServiceID = "6F81-5844-456A"
+	gcpCloudPlatformScope     = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+const gcpService = "gce"
+
+func init() {
+	RegisterProviderFactory(apis.GCPCloudProvider, gcpService, newGCPPriceProvider)
+}
+
+// GCPConfig contains the settings of the GCP price client, the endpoints can be
+// pointed to a local HTTP stand-in of the catalog and compute APIs.
+type GCPConfig struct {
+	ProjectID string
+	// CredentialsFile is the service account key file, requests are sent without credentials if it's empty
+	CredentialsFile string
+	CatalogEndpoint string
+	ComputeEndpoint string
+}
+
+type GCPPriceClient struct {
+	config      GCPConfig
+	httpClient  *http.Client
+	store       storage.Store
+	spotHistory storage.SpotHistoryStore
+	tokenSource oauth2.TokenSource
+
+	priceData *atomicPriceData
+}
+
+func newGCPPriceProvider(opts *ProviderOptions) (PriceProvider, error) {
+	config := GCPConfig{
+		ProjectID:       os.Getenv(apis.GCPProjectIDEnv),
+		CredentialsFile: os.Getenv(apis.GCPCredentialsFileEnv),
+		CatalogEndpoint: os.Getenv(apis.GCPCatalogEndpointEnv),
+		ComputeEndpoint: os.Getenv(apis.GCPComputeEndpointEnv),
+	}
+	if config.ProjectID == "" {
+		return nil, fmt.Errorf("gcp project id is not set: %w", ErrMissingCredentials)
+	}
+	if config.CredentialsFile == "" {
+		return nil, fmt.Errorf("gcp credentials file is not set: %w", ErrMissingCredentials)
+	}
+
+	return NewGCPPriceClient(config, opts.Store, opts.SpotHistory, opts.InitialSpotUpdate)
+}
+
+func NewGCPPriceClient(config GCPConfig, store storage.Store, spotHistory storage.SpotHistoryStore,
+	initialUpdate bool) (*GCPPriceClient, error) {
+	if config.CatalogEndpoint == "" {
+		config.CatalogEndpoint = gcpDefaultCatalogEndpoint
+	}
+	if config.ComputeEndpoint == "" {
+		config.ComputeEndpoint = gcpDefaultComputeEndpoint
+	}
+
+	priceData, updatedAt, err := loadPriceData(store, apis.GCPCloudProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &GCPPriceClient{
+		config:      config,
+		httpClient:  &http.Client{Timeout: time.Minute},
+		store:       store,
+		spotHistory: spotHistory,
+		priceData:   newAtomicPriceData(apis.GCPCloudProvider, priceData, updatedAt),
+	}
+	if config.CredentialsFile != "" {
+		data, err := os.ReadFile(config.CredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		// The tokens are requested with the client of the price APIs
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client.httpClient)
+		credentials, err := google.CredentialsFromJSON(ctx, data, gcpCloudPlatformScope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse gcp credentials: %w", err)
+		}
+		client.tokenSource = credentials.TokenSource
+	}
+
+	if initialUpdate {
+		if err := client.RefreshPrice(); err != nil {
+			return nil, err
+		}
+		recordSpotHistory(client.spotHistory, client)
+	}
+
+	return client, nil
+}
+
+func (g *GCPPriceClient) Name() string {
+	return apis.GCPCloudProvider
+}
+
+func (g *GCPPriceClient) Service() string {
+	return gcpService
+}
+
+func (g *GCPPriceClient) Run(ctx context.Context) {
+	// The spot prices of GCP change at most once a month, so daily refresh is enough
+	ticker := time.NewTicker(time.Hour * 24)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = g.RefreshPrice()
+			recordSpotHistory(g.spotHistory, g)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Refresh refreshes all the prices, the SKUs of GCP are shared by all regions,
+// so region and instance type are ignored.
+func (g *GCPPriceClient) Refresh(_, _ string) {
+	_ = g.RefreshPrice()
+}
+
+func (g *GCPPriceClient) Health() error {
+	if len(g.priceData.Load()) == 0 {
+		return fmt.Errorf("no price data is available for gcp")
+	}
+	return nil
+}
+
+func (g *GCPPriceClient) DataVersion() (uint64, time.Time) {
+	return g.priceData.Version()
+}
+
+func (g *GCPPriceClient) PriceDelta(since uint64, region string) *apis.PriceDelta {
+	return g.priceData.Delta(since, region)
+}
+
+func (g *GCPPriceClient) WatchPrices(ctx context.Context, lastEventID string, filter *apis.PriceEventFilter) (*PriceWatch, error) {
+	return g.priceData.events.Watch(ctx, lastEventID, filter)
+}
+
+type GCPMoney struct {
+	CurrencyCode string `json:"currencyCode"`
+	Units        string `json:"units"`
+	Nanos        int64  `json:"nanos"`
+}
+
+func (m GCPMoney) Float64() float64 {
+	units, _ := strconv.ParseInt(m.Units, 10, 64)
+	return float64(units) + float64(m.Nanos)/1e9
+}
+
+type GCPSKU struct {
+	Description string `json:"description"`
+	Category    struct {
+		ResourceFamily string `json:"resourceFamily"`
+		ResourceGroup  string `json:"resourceGroup"`
+		UsageType      string `json:"usageType"`
+	} `json:"category"`
+	ServiceRegions []string `json:"serviceRegions"`
+	PricingInfo    []struct {
+		PricingExpression struct {
+			UsageUnit   string `json:"usageUnit"`
+			TieredRates []struct {
+				StartUsageAmount float64  `json:"startUsageAmount"`
+				UnitPrice        GCPMoney `json:"unitPrice"`
+			} `json:"tieredRates"`
+		} `json:"pricingExpression"`
+	} `json:"pricingInfo"`
+}
+
+type gcpSKUList struct {
+	SKUs          []GCPSKU `json:"skus"`
+	NextPageToken string   `json:"nextPageToken"`
+}
+
+type GCPMachineType struct {
+	Name         string `json:"name"`
+	GuestCPUs    int64  `json:"guestCpus"`
+	MemoryMB     int64  `json:"memoryMb"`
+	Zone         string `json:"zone"`
+	IsSharedCPU  bool   `json:"isSharedCpu"`
+	Accelerators []struct {
+		GuestAcceleratorType  string `json:"guestAcceleratorType"`
+		GuestAcceleratorCount int64  `json:"guestAcceleratorCount"`
+	} `json:"accelerators"`
+}
+
+type gcpMachineTypeList struct {
+	Items map[string]struct {
+		MachineTypes []GCPMachineType `json:"machineTypes"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+const (
+	gcpResourceCore = "core"
+	gcpResourceRAM  = "ram"
+	gcpResourceGPU  = "gpu"
+
+	gcpUsageOnDemand    = "OnDemand"
+	gcpUsagePreemptible = "Preemptible"
+	gcpUsageCommit1Yr   = "Commit1Yr"
+	gcpUsageCommit3Yr   = "Commit3Yr"
+)
+
+// gcpSKUKey identifies the unit price of one resource of a machine family in a region
+type gcpSKUKey struct {
+	region   string
+	family   string
+	resource string
+	usage    string
+}
+
+var (
+	// gcpFamilyAliases maps the leading words of SKU descriptions to machine families
+	gcpFamilyAliases = map[string]string{
+		"compute optimized": "c2",
+		"memory-optimized":  "m1",
+		"memory optimized":  "m1",
+	}
+	// gcpSharedCoreFractions is the billed vCPU fraction of shared-core E2 machine types
+	gcpSharedCoreFractions = map[string]float64{
+		"e2-micro":  0.25,
+		"e2-small":  0.5,
+		"e2-medium": 1,
+	}
+	gcpARMFamilies = map[string]struct{}{
+		"t2a": {},
+		"c4a": {},
+	}
+	gcpSKUDescriptionPrefixes = []string{
+		"spot preemptible ",
+		"preemptible ",
+		"commitment v1: ",
+		"commitment v2: ",
+		"commitment: ",
+	}
+)
+
+// parseGCPSKUDescription extracts the machine family and resource from descriptions like
+// "N2 Instance Core running in Americas" or "Commitment v1: N2D AMD Ram in Americas for 1 Year",
+// for GPU SKUs the family is the accelerator type, e.g. nvidia-tesla-t4.
+func parseGCPSKUDescription(description string) (string, string, bool) {
+	desc := strings.ToLower(description)
+	for _, prefix := range gcpSKUDescriptionPrefixes {
+		desc = strings.TrimPrefix(desc, prefix)
+	}
+	if strings.Contains(desc, "custom") || strings.Contains(desc, "sole tenancy") ||
+		strings.Contains(desc, "extended") || strings.Contains(desc, "premium") {
+		return "", "", false
+	}
+
+	if idx := strings.Index(desc, " gpu"); idx > 0 {
+		return strings.ReplaceAll(desc[:idx], " ", "-"), gcpResourceGPU, true
+	}
+
+	var resource string
+	switch {
+	case strings.Contains(desc, " core") || strings.Contains(desc, " cpu"):
+		resource = gcpResourceCore
+	case strings.Contains(desc, " ram"):
+		resource = gcpResourceRAM
+	default:
+		return "", "", false
+	}
+
+	for alias, family := range gcpFamilyAliases {
+		if strings.HasPrefix(desc, alias) {
+			return family, resource, true
+		}
+	}
+	fields := strings.Fields(desc)
+	if len(fields) == 0 {
+		return "", "", false
+	}
+	return fields[0], resource, true
+}
+
+func gcpUnitPrice(sku *GCPSKU) float64 {
+	for _, info := range sku.PricingInfo {
+		for _, rate := range info.PricingExpression.TieredRates {
+			if price := rate.UnitPrice.Float64(); price > 0 {
+				return price
+			}
+		}
+	}
+	return 0
+}
+
+func (g *GCPPriceClient) doRequest(reqUrl string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+	if err != nil {
+		return err
+	}
+	if g.tokenSource != nil {
+		token, err := g.tokenSource.Token()
+		if err != nil {
+			return err
+		}
+		token.SetAuthHeader(req)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request %s failed: %s, %s", req.URL.Path, resp.Status, string(data))
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+func (g *GCPPriceClient) listSKUs() ([]GCPSKU, error) {
+	var ret []GCPSKU
+	token := ""
+	for {
+		query := url.Values{}
+		query.Set("currencyCode", "USD")
+		query.Set("pageSize", "5000")
+		if token != "" {
+			query.Set("pageToken", token)
+		}
+		reqUrl, err := url.JoinPath(g.config.CatalogEndpoint, "/v1/services", gcpComputeEngineServiceID, "skus")
+		if err != nil {
+			return nil, err
+		}
+
+		var data gcpSKUList
+		if err := g.doRequest(reqUrl+"?"+query.Encode(), &data); err != nil {
+			klog.Errorf("Failed to list gcp skus: %v", err)
+			return nil, err
+		}
+		for _, sku := range data.SKUs {
+			if sku.Category.ResourceFamily != "Compute" {
+				continue
+			}
+			ret = append(ret, sku)
+		}
+
+		if data.NextPageToken == "" {
+			break
+		}
+		token = data.NextPageToken
+	}
+
+	return ret, nil
+}
+
+func (g *GCPPriceClient) listMachineTypes() ([]GCPMachineType, error) {
+	var ret []GCPMachineType
+	token := ""
+	for {
+		query := url.Values{}
+		if token != "" {
+			query.Set("pageToken", token)
+		}
+		reqUrl, err := url.JoinPath(g.config.ComputeEndpoint, "/compute/v1/projects", g.config.ProjectID,
+			"aggregated/machineTypes")
+		if err != nil {
+			return nil, err
+		}
+
+		var data gcpMachineTypeList
+		if err := g.doRequest(reqUrl+"?"+query.Encode(), &data); err != nil {
+			klog.Errorf("Failed to list gcp machine types: %v", err)
+			return nil, err
+		}
+		for _, item := range data.Items {
+			ret = append(ret, item.MachineTypes...)
+		}
+
+		if data.NextPageToken == "" {
+			break
+		}
+		token = data.NextPageToken
+	}
+
+	return ret, nil
+}
+
+func gcpZoneToRegion(zone string) string {
+	idx := strings.LastIndex(zone, "-")
+	if idx <= 0 {
+		return zone
+	}
+	return zone[:idx]
+}
+
+func gcpMachineFamily(machineType string) string {
+	return strings.Split(machineType, "-")[0]
+}
+
+// composeGCPPrice composes the hourly price of a machine type from the per-vCPU, per-GiB and per-GPU prices
+func composeGCPPrice(unitPrices map[gcpSKUKey]float64, region, usage string, mt *GCPMachineType) (float64, bool) {
+	family := gcpMachineFamily(mt.Name)
+	corePrice, ok := unitPrices[gcpSKUKey{region: region, family: family, resource: gcpResourceCore, usage: usage}]
+	if !ok {
+		return 0, false
+	}
+	ramPrice, ok := unitPrices[gcpSKUKey{region: region, family: family, resource: gcpResourceRAM, usage: usage}]
+	if !ok {
+		return 0, false
+	}
+
+	cores := float64(mt.GuestCPUs)
+	if fraction, ok := gcpSharedCoreFractions[mt.Name]; ok {
+		cores = fraction
+	}
+	price := cores*corePrice + float64(mt.MemoryMB)/1024*ramPrice
+
+	for _, acc := range mt.Accelerators {
+		gpuPrice, ok := unitPrices[gcpSKUKey{region: region, family: acc.GuestAcceleratorType, resource: gcpResourceGPU, usage: usage}]
+		if !ok {
+			return 0, false
+		}
+		price += float64(acc.GuestAcceleratorCount) * gpuPrice
+	}
+
+	return price, true
+}
+
+func (g *GCPPriceClient) RefreshPrice() error {
+	skus, err := g.listSKUs()
+	if err != nil {
+		return err
+	}
+	machineTypes, err := g.listMachineTypes()
+	if err != nil {
+		return err
+	}
+
+	unitPrices := map[gcpSKUKey]float64{}
+	for i := range skus {
+		family, resource, ok := parseGCPSKUDescription(skus[i].Description)
+		if !ok {
+			continue
+		}
+		price := gcpUnitPrice(&skus[i])
+		if price == 0 {
+			continue
+		}
+		for _, region := range skus[i].ServiceRegions {
+			unitPrices[gcpSKUKey{region: region, family: family, resource: resource, usage: skus[i].Category.UsageType}] = price
+		}
+	}
+
+	priceData := map[string]*apis.RegionalInstancePrice{}
+	for i := range machineTypes {
+		mt := &machineTypes[i]
+		region := gcpZoneToRegion(mt.Zone)
+
+		d, ok := priceData[region]
+		if !ok {
+			d = &apis.RegionalInstancePrice{
+				InstanceTypePrices: map[string]*apis.InstanceTypePrice{},
+			}
+			priceData[region] = d
+		}
+		// The spot price is the same in all zones of a region, it's stored by zone to keep consistent with other providers
+		spotPrice, hasSpotPrice := composeGCPPrice(unitPrices, region, gcpUsagePreemptible, mt)
+		if ins, ok := d.InstanceTypePrices[mt.Name]; ok {
+			ins.Zones = append(ins.Zones, mt.Zone)
+			if hasSpotPrice {
+				ins.SpotPricePerHour[mt.Zone] = spotPrice
+			}
+			continue
+		}
+
+		onDemandPrice, ok := composeGCPPrice(unitPrices, region, gcpUsageOnDemand, mt)
+		if !ok {
+			continue
+		}
+		ins := &apis.InstanceTypePrice{
+			Arch:                 "amd64",
+			Currency:             currency.USD,
+			VCPU:                 float64(mt.GuestCPUs),
+			Memory:               float64(mt.MemoryMB) / 1024,
+			Zones:                []string{mt.Zone},
+			OnDemandPricePerHour: onDemandPrice,
+		}
+		if _, ok := gcpARMFamilies[gcpMachineFamily(mt.Name)]; ok {
+			ins.Arch = "arm64"
+		}
+		for _, acc := range mt.Accelerators {
+			ins.GPU += float64(acc.GuestAcceleratorCount)
+		}
+		if hasSpotPrice {
+			ins.SpotPricePerHour = map[string]float64{mt.Zone: spotPrice}
+		}
+		for usage, term := range map[string]string{gcpUsageCommit1Yr: "1yr", gcpUsageCommit3Yr: "3yr"} {
+			rate, ok := composeGCPPrice(unitPrices, region, usage, mt)
+			if !ok {
+				continue
+			}
+			if ins.GCPCommittedUseBilling == nil {
+				ins.GCPCommittedUseBilling = map[string]apis.GCPCommittedUseBilling{}
+			}
+			ins.GCPCommittedUseBilling[term] = apis.GCPCommittedUseBilling{Rate: rate}
+		}
+
+		d.InstanceTypePrices[mt.Name] = ins
+	}
+
+	g.priceData.Store(priceData)
+	persistPriceData(g.store, g)
+
+	klog.Infof("All prices are refreshed for GCP")
+	return nil
+}
+
+func (g *GCPPriceClient) ListRegions() []string {
+	return sortedRegions(g.priceData.Load())
+}
+
+func (g *GCPPriceClient) ListRegionsInstancesPrice() map[string]*apis.RegionalInstancePrice {
+	return g.priceData.Load()
+}
+
+func (g *GCPPriceClient) ListInstancesPrice(region string) *map[string]apis.RegionalInstancePrice {
+	d, ok := g.priceData.Load()[region]
+	if !ok {
+		return nil
+	}
+	return &map[string]apis.RegionalInstancePrice{
+		region: *d,
+	}
+}
+
+func (g *GCPPriceClient) GetInstancePrice(region, instanceType string) *apis.InstanceTypePrice {
+	regionData, ok := g.priceData.Load()[region]
+	if !ok {
+		return nil
+	}
+	d, ok := regionData.InstanceTypePrices[instanceType]
+	if !ok {
+		return nil
+	}
+
+	return d
+}
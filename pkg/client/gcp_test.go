@@ -0,0 +1,172 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const gcpTestSKUsPage1 = `{
+  "skus": [
+    {"description": "N2 Instance Core running in Americas", "category": {"resourceFamily": "Compute", "usageType": "OnDemand"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "0", "nanos": 31611000}}]}}]},
+    {"description": "N2 Instance Ram running in Americas", "category": {"resourceFamily": "Compute", "usageType": "OnDemand"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "0", "nanos": 4237000}}]}}]},
+    {"description": "Spot Preemptible N2 Instance Core running in Americas", "category": {"resourceFamily": "Compute", "usageType": "Preemptible"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "0", "nanos": 7000000}}]}}]},
+    {"description": "Spot Preemptible N2 Instance Ram running in Americas", "category": {"resourceFamily": "Compute", "usageType": "Preemptible"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "0", "nanos": 1000000}}]}}]},
+    {"description": "Commitment v1: N2 Cpu in Americas for 1 Year", "category": {"resourceFamily": "Compute", "usageType": "Commit1Yr"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "0", "nanos": 19915000}}]}}]},
+    {"description": "Commitment v1: N2 Ram in Americas for 1 Year", "category": {"resourceFamily": "Compute", "usageType": "Commit1Yr"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "0", "nanos": 2669000}}]}}]},
+    {"description": "Commitment v1: N2 Cpu in Americas for 3 Year", "category": {"resourceFamily": "Compute", "usageType": "Commit3Yr"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "0", "nanos": 14225000}}]}}]},
+    {"description": "Commitment v1: N2 Ram in Americas for 3 Year", "category": {"resourceFamily": "Compute", "usageType": "Commit3Yr"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "0", "nanos": 1907000}}]}}]}
+  ],
+  "nextPageToken": "page2"
+}`
+
+const gcpTestSKUsPage2 = `{
+  "skus": [
+    {"description": "N1 Predefined Instance Core running in Americas", "category": {"resourceFamily": "Compute", "usageType": "OnDemand"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "0", "nanos": 31611000}}]}}]},
+    {"description": "N1 Predefined Instance Ram running in Americas", "category": {"resourceFamily": "Compute", "usageType": "OnDemand"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "0", "nanos": 4237000}}]}}]},
+    {"description": "Nvidia Tesla T4 GPU running in Americas", "category": {"resourceFamily": "Compute", "usageType": "OnDemand"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "0", "nanos": 350000000}}]}}]},
+    {"description": "Custom N2 Instance Core running in Americas", "category": {"resourceFamily": "Compute", "usageType": "OnDemand"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "1", "nanos": 0}}]}}]},
+    {"description": "N2 Instance Core running in Americas", "category": {"resourceFamily": "Storage", "usageType": "OnDemand"},
+     "serviceRegions": ["us-central1"], "pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "1", "nanos": 0}}]}}]}
+  ]
+}`
+
+const gcpTestMachineTypesPage1 = `{
+  "items": {
+    "zones/us-central1-a": {"machineTypes": [
+      {"name": "n2-standard-2", "guestCpus": 2, "memoryMb": 8192, "zone": "us-central1-a"},
+      {"name": "n1-standard-4", "guestCpus": 4, "memoryMb": 15360, "zone": "us-central1-a",
+       "accelerators": [{"guestAcceleratorType": "nvidia-tesla-t4", "guestAcceleratorCount": 1}]}
+    ]}
+  },
+  "nextPageToken": "page2"
+}`
+
+const gcpTestMachineTypesPage2 = `{
+  "items": {
+    "zones/us-central1-b": {"machineTypes": [
+      {"name": "n2-standard-2", "guestCpus": 2, "memoryMb": 8192, "zone": "us-central1-b"},
+      {"name": "e2-micro", "guestCpus": 2, "memoryMb": 1024, "zone": "us-central1-b", "isSharedCpu": true}
+    ]}
+  }
+}`
+
+func TestParseGCPSKUDescription(t *testing.T) {
+	tests := []struct {
+		description  string
+		wantFamily   string
+		wantResource string
+		wantOK       bool
+	}{
+		{description: "N2 Instance Core running in Americas", wantFamily: "n2", wantResource: gcpResourceCore, wantOK: true},
+		{description: "N2D AMD Instance Ram running in Americas", wantFamily: "n2d", wantResource: gcpResourceRAM, wantOK: true},
+		{description: "Spot Preemptible E2 Instance Core running in Americas", wantFamily: "e2", wantResource: gcpResourceCore, wantOK: true},
+		{description: "Commitment v1: N2D AMD Ram in Americas for 1 Year", wantFamily: "n2d", wantResource: gcpResourceRAM, wantOK: true},
+		{description: "Compute optimized Core running in Americas", wantFamily: "c2", wantResource: gcpResourceCore, wantOK: true},
+		{description: "Memory-optimized Instance Ram running in Americas", wantFamily: "m1", wantResource: gcpResourceRAM, wantOK: true},
+		{description: "Nvidia Tesla T4 GPU running in Americas", wantFamily: "nvidia-tesla-t4", wantResource: gcpResourceGPU, wantOK: true},
+		{description: "Custom N2 Instance Core running in Americas"},
+		{description: "N2 Sole Tenancy Instance Core running in Americas"},
+		{description: "N2 Extended Instance Ram running in Americas"},
+		{description: "Network Egress via Carrier Peering"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			family, resource, ok := parseGCPSKUDescription(tt.description)
+			if family != tt.wantFamily || resource != tt.wantResource || ok != tt.wantOK {
+				t.Errorf("got %q, %q, %v, want %q, %q, %v", family, resource, ok, tt.wantFamily, tt.wantResource, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGCPRefreshPrice(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	pages := func(page1, page2 string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("pageToken") == "page2" {
+				fmt.Fprint(w, page2)
+				return
+			}
+			fmt.Fprint(w, page1)
+		}
+	}
+	mux.HandleFunc("/v1/services/"+gcpComputeEngineServiceID+"/skus", pages(gcpTestSKUsPage1, gcpTestSKUsPage2))
+	mux.HandleFunc("/compute/v1/projects/test/aggregated/machineTypes", pages(gcpTestMachineTypesPage1, gcpTestMachineTypesPage2))
+
+	g, err := NewGCPPriceClient(GCPConfig{
+		ProjectID:       "test",
+		CatalogEndpoint: server.URL,
+		ComputeEndpoint: server.URL,
+	}, nil, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prices := g.ListRegionsInstancesPrice()["us-central1"].InstanceTypePrices
+	if _, ok := prices["e2-micro"]; ok {
+		t.Errorf("e2-micro without the unit prices should be skipped")
+	}
+
+	n2 := prices["n2-standard-2"]
+	if n2 == nil {
+		t.Fatalf("n2-standard-2 is not found")
+	}
+	if len(n2.Zones) != 2 || n2.VCPU != 2 || n2.Memory != 8 || n2.Arch != "amd64" {
+		t.Errorf("got n2-standard-2 %+v", n2)
+	}
+	// The prices are composed of 2 vCPUs and 8 GiB
+	assertPrice(t, "on-demand", n2.OnDemandPricePerHour, 2*0.031611+8*0.004237)
+	for _, zone := range []string{"us-central1-a", "us-central1-b"} {
+		assertPrice(t, "spot "+zone, n2.SpotPricePerHour[zone], 2*0.007+8*0.001)
+	}
+	assertPrice(t, "1yr", n2.GCPCommittedUseBilling["1yr"].Rate, 2*0.019915+8*0.002669)
+	assertPrice(t, "3yr", n2.GCPCommittedUseBilling["3yr"].Rate, 2*0.014225+8*0.001907)
+
+	n1 := prices["n1-standard-4"]
+	if n1 == nil {
+		t.Fatalf("n1-standard-4 is not found")
+	}
+	if n1.GPU != 1 || len(n1.SpotPricePerHour) != 0 || len(n1.GCPCommittedUseBilling) != 0 {
+		t.Errorf("got n1-standard-4 %+v", n1)
+	}
+	assertPrice(t, "on-demand with GPU", n1.OnDemandPricePerHour, 4*0.031611+15*0.004237+0.35)
+}
+
+func assertPrice(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("got %s price %v, want %v", name, got, want)
+	}
+}
+
+func TestGCPRefreshPriceError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if _, err := NewGCPPriceClient(GCPConfig{
+		ProjectID:       "test",
+		CatalogEndpoint: server.URL,
+		ComputeEndpoint: server.URL,
+	}, nil, nil, true); err == nil {
+		t.Errorf("got no error, want the error of the catalog API")
+	}
+}
@@ -0,0 +1,511 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/storage"
+)
+
+const (
+	huaweiCloudIAMEndpoint = "https://iam.myhuaweicloud.com"
+	huaweiCloudBSSEndpoint = "https://bss.myhuaweicloud.com"
+	// huaweiCloudECSEndpointFormat is formatted with the region
+	huaweiCloudECSEndpointFormat = "https://ecs.%s.myhuaweicloud.com"
+
+	huaweiCloudSignAlgorithm = "SDK-HMAC-SHA256"
+	huaweiCloudDateFormat    = "20060102T150405Z"
+	// huaweiCloudMaxRatingProducts is the max number of products in one rating request
+	huaweiCloudMaxRatingProducts = 100
+	// huaweiCloudMeasureHour is the measure id of hour in the BSS API
+	huaweiCloudMeasureHour = 4
+)
+
+const huaweiCloudService = "ecs"
+
+func init() {
+	RegisterProviderFactory(apis.HuaweiCloudProvider, huaweiCloudService, newHuaweiCloudPriceProvider)
+}
+
+// HuaweiCloudConfig contains the settings of the HuaweiCloud price client, all requests are
+// sent to Endpoint with the original path if it's set, it's used for a local HTTP stand-in.
+type HuaweiCloudConfig struct {
+	AKSKPool []AKSKPair
+	Endpoint string
+}
+
+type HuaweiCloudPriceClient struct {
+	config     HuaweiCloudConfig
+	httpClient *http.Client
+	store      storage.Store
+
+	// projects is the project id of each region
+	projects map[string]string
+
+	priceData *atomicPriceData
+}
+
+func newHuaweiCloudPriceProvider(opts *ProviderOptions) (PriceProvider, error) {
+	akskPool := ExtractAKSKPool(apis.HuaweiCloudAKSKPoolEnv)
+	if len(akskPool) == 0 {
+		return nil, fmt.Errorf("huawei cloud access key and secret key pool is not set: %w", ErrMissingCredentials)
+	}
+
+	return NewHuaweiCloudPriceClient(HuaweiCloudConfig{
+		AKSKPool: akskPool,
+		Endpoint: os.Getenv(apis.HuaweiCloudEndpointEnv),
+	}, opts.Store, opts.InitialSpotUpdate)
+}
+
+func NewHuaweiCloudPriceClient(config HuaweiCloudConfig, store storage.Store, initialUpdate bool) (*HuaweiCloudPriceClient, error) {
+	if len(config.AKSKPool) == 0 {
+		return nil, fmt.Errorf("huawei cloud access key and secret key pool is empty")
+	}
+
+	priceData, updatedAt, err := loadPriceData(store, apis.HuaweiCloudProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &HuaweiCloudPriceClient{
+		config:     config,
+		httpClient: &http.Client{Timeout: time.Minute},
+		store:      store,
+		projects:   map[string]string{},
+		priceData:  newAtomicPriceData(apis.HuaweiCloudProvider, priceData, updatedAt),
+	}
+	if err := client.initialProjects(); err != nil {
+		return nil, err
+	}
+
+	if initialUpdate {
+		client.RefreshOnDemandPrice("")
+	}
+
+	return client, nil
+}
+
+func (h *HuaweiCloudPriceClient) Name() string {
+	return apis.HuaweiCloudProvider
+}
+
+func (h *HuaweiCloudPriceClient) Service() string {
+	return huaweiCloudService
+}
+
+func (h *HuaweiCloudPriceClient) Run(ctx context.Context) {
+	// HuaweiCloud doesn't provide a public spot price API, only the on-demand prices are refreshed
+	odTicker := time.NewTicker(time.Hour * 24 * 7)
+	defer odTicker.Stop()
+
+	for {
+		select {
+		case <-odTicker.C:
+			h.RefreshOnDemandPrice("")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Refresh refreshes the prices of the given region, empty means all regions,
+// the instance type is ignored since all flavors of a region are rated at once.
+func (h *HuaweiCloudPriceClient) Refresh(region, _ string) {
+	h.RefreshOnDemandPrice(region)
+}
+
+func (h *HuaweiCloudPriceClient) Health() error {
+	if len(h.priceData.Load()) == 0 {
+		return fmt.Errorf("no price data is available for huaweicloud")
+	}
+	return nil
+}
+
+func (h *HuaweiCloudPriceClient) DataVersion() (uint64, time.Time) {
+	return h.priceData.Version()
+}
+
+func (h *HuaweiCloudPriceClient) PriceDelta(since uint64, region string) *apis.PriceDelta {
+	return h.priceData.Delta(since, region)
+}
+
+func (h *HuaweiCloudPriceClient) WatchPrices(ctx context.Context, lastEventID string, filter *apis.PriceEventFilter) (*PriceWatch, error) {
+	return h.priceData.events.Watch(ctx, lastEventID, filter)
+}
+
+func (h *HuaweiCloudPriceClient) resolveURL(base, path string) (*url.URL, error) {
+	if h.config.Endpoint != "" {
+		base = h.config.Endpoint
+	}
+	return url.Parse(strings.TrimSuffix(base, "/") + path)
+}
+
+func huaweiCloudCanonicalURI(path string) string {
+	segments := strings.Split(path, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	uri := strings.Join(segments, "/")
+	if !strings.HasSuffix(uri, "/") {
+		uri += "/"
+	}
+	return uri
+}
+
+func huaweiCloudCanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// call sends the request signed with SDK-HMAC-SHA256, see https://support.huaweicloud.com/intl/en-us/devg-apisign/api-sign-algorithm.html
+func (h *HuaweiCloudPriceClient) call(method string, reqUrl *url.URL, request, response interface{}) error {
+	var payload []byte
+	if request != nil {
+		var err error
+		payload, err = json.Marshal(request)
+		if err != nil {
+			return err
+		}
+	}
+
+	date := time.Now().UTC().Format(huaweiCloudDateFormat)
+	contentType := "application/json"
+	signedHeaders := "content-type;host;x-sdk-date"
+	canonicalRequest := strings.Join([]string{
+		method,
+		huaweiCloudCanonicalURI(reqUrl.Path),
+		huaweiCloudCanonicalQuery(reqUrl.Query()),
+		fmt.Sprintf("content-type:%s\nhost:%s\nx-sdk-date:%s\n", contentType, reqUrl.Host, date),
+		signedHeaders,
+		sha256Hex(payload),
+	}, "\n")
+	stringToSign := strings.Join([]string{
+		huaweiCloudSignAlgorithm,
+		date,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	ak, sk := pickAKSK(h.config.AKSKPool)
+	signature := hex.EncodeToString(hmacSHA256([]byte(sk), stringToSign))
+
+	req, err := http.NewRequest(method, reqUrl.String(), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Host", reqUrl.Host)
+	req.Header.Set("X-Sdk-Date", date)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Access=%s, SignedHeaders=%s, Signature=%s",
+		huaweiCloudSignAlgorithm, ak, signedHeaders, signature))
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request %s failed: %s, %s", reqUrl.Path, resp.Status, string(data))
+	}
+
+	return json.Unmarshal(data, response)
+}
+
+func (h *HuaweiCloudPriceClient) initialProjects() error {
+	reqUrl, err := h.resolveURL(huaweiCloudIAMEndpoint, "/v3/projects")
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Projects []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"projects"`
+	}
+	if err := h.call(http.MethodGet, reqUrl, nil, &resp); err != nil {
+		klog.Errorf("Failed to list huaweicloud projects: %v", err)
+		return err
+	}
+
+	for _, p := range resp.Projects {
+		// The default project of each region is named with the region id, sub projects are named like {region}_{name}
+		if strings.Contains(p.Name, "_") || p.Name == "MOS" {
+			continue
+		}
+		h.projects[p.Name] = p.ID
+	}
+
+	return nil
+}
+
+type HuaweiCloudFlavor struct {
+	ID           string            `json:"id"`
+	VCPUs        string            `json:"vcpus"`
+	RAM          int64             `json:"ram"`
+	OSExtraSpecs map[string]string `json:"os_extra_specs"`
+}
+
+func (h *HuaweiCloudPriceClient) listFlavors(region string) ([]HuaweiCloudFlavor, error) {
+	reqUrl, err := h.resolveURL(fmt.Sprintf(huaweiCloudECSEndpointFormat, region),
+		fmt.Sprintf("/v1/%s/cloudservers/flavors", h.projects[region]))
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Flavors []HuaweiCloudFlavor `json:"flavors"`
+	}
+	if err := h.call(http.MethodGet, reqUrl, nil, &resp); err != nil {
+		klog.Errorf("Failed to list huaweicloud flavors in region %s: %v", region, err)
+		return nil, err
+	}
+
+	return resp.Flavors, nil
+}
+
+type huaweiCloudProductInfo struct {
+	ID               string `json:"id"`
+	CloudServiceType string `json:"cloud_service_type"`
+	ResourceType     string `json:"resource_type"`
+	ResourceSpec     string `json:"resource_spec"`
+	Region           string `json:"region"`
+	UsageFactor      string `json:"usage_factor"`
+	UsageValue       int    `json:"usage_value"`
+	UsageMeasureID   int    `json:"usage_measure_id"`
+	SubscriptionNum  int    `json:"subscription_num"`
+}
+
+// rateOnDemandPrices returns the hourly list price of the given flavors by the BSS rating API
+func (h *HuaweiCloudPriceClient) rateOnDemandPrices(region string, flavors []string) (map[string]float64, error) {
+	reqUrl, err := h.resolveURL(huaweiCloudBSSEndpoint, "/v2/bills/ratings/on-demand-resources")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := map[string]float64{}
+	for start := 0; start < len(flavors); start += huaweiCloudMaxRatingProducts {
+		end := start + huaweiCloudMaxRatingProducts
+		if end > len(flavors) {
+			end = len(flavors)
+		}
+
+		var products []huaweiCloudProductInfo
+		for i, flavor := range flavors[start:end] {
+			products = append(products, huaweiCloudProductInfo{
+				ID:               strconv.Itoa(i),
+				CloudServiceType: "hws.service.type.ec2",
+				ResourceType:     "hws.resource.type.vm",
+				ResourceSpec:     flavor + ".linux",
+				Region:           region,
+				UsageFactor:      "Duration",
+				UsageValue:       1,
+				UsageMeasureID:   huaweiCloudMeasureHour,
+				SubscriptionNum:  1,
+			})
+		}
+		req := map[string]interface{}{
+			"project_id":    h.projects[region],
+			"product_infos": products,
+		}
+		var resp struct {
+			ProductRatingResults []struct {
+				ID                    string  `json:"id"`
+				OfficialWebsiteAmount float64 `json:"official_website_amount"`
+			} `json:"product_rating_results"`
+		}
+		if err := h.call(http.MethodPost, reqUrl, req, &resp); err != nil {
+			klog.Errorf("Failed to rate huaweicloud flavors in region %s: %v", region, err)
+			return nil, err
+		}
+
+		for _, r := range resp.ProductRatingResults {
+			i, err := strconv.Atoi(r.ID)
+			if err != nil || i >= end-start {
+				continue
+			}
+			ret[flavors[start+i]] = r.OfficialWebsiteAmount
+		}
+	}
+
+	return ret, nil
+}
+
+func extractHuaweiCloudArch(arch string) string {
+	switch strings.ToLower(arch) {
+	case "arm64", "arm":
+		return "arm64"
+	default:
+		return "amd64"
+	}
+}
+
+// extractHuaweiCloudZones parses the available zones from cond:operation:az like "cn-north-4a(normal),cn-north-4b(sellout)"
+func extractHuaweiCloudZones(azSpec string) []string {
+	var ret []string
+	for _, item := range strings.Split(azSpec, ",") {
+		item = strings.TrimSpace(item)
+		idx := strings.Index(item, "(")
+		if idx <= 0 {
+			continue
+		}
+		status := strings.TrimSuffix(item[idx+1:], ")")
+		if status != "normal" && status != "promotion" {
+			continue
+		}
+		ret = append(ret, item[:idx])
+	}
+	return ret
+}
+
+// extractHuaweiCloudGPU parses the GPU number from pci_passthrough:alias like "nvidia-t4:1"
+func extractHuaweiCloudGPU(alias string) float64 {
+	var ret float64
+	for _, item := range strings.Split(alias, ",") {
+		parts := strings.Split(item, ":")
+		if len(parts) != 2 {
+			continue
+		}
+		n, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			continue
+		}
+		ret += n
+	}
+	return ret
+}
+
+func (h *HuaweiCloudPriceClient) refreshRegionOnDemandPrice(region string) {
+	flavors, err := h.listFlavors(region)
+	if err != nil {
+		return
+	}
+
+	instanceTypes := map[string]*apis.InstanceTypePrice{}
+	for _, f := range flavors {
+		if f.OSExtraSpecs["cond:operation:status"] == "abandon" {
+			continue
+		}
+		vcpu, err := strconv.ParseFloat(f.VCPUs, 64)
+		if err != nil {
+			continue
+		}
+		instanceTypes[f.ID] = &apis.InstanceTypePrice{
+			Arch:     extractHuaweiCloudArch(f.OSExtraSpecs["ecs:instance_architecture"]),
+			VCPU:     vcpu,
+			Memory:   float64(f.RAM) / 1024,
+			GPU:      extractHuaweiCloudGPU(f.OSExtraSpecs["pci_passthrough:alias"]),
+			Currency: PriceCurrency(apis.HuaweiCloudProvider, region),
+			Zones:    extractHuaweiCloudZones(f.OSExtraSpecs["cond:operation:az"]),
+		}
+	}
+
+	names := make([]string, 0, len(instanceTypes))
+	for name := range instanceTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	prices, err := h.rateOnDemandPrices(region, names)
+	if err != nil {
+		return
+	}
+	for name, ins := range instanceTypes {
+		price, ok := prices[name]
+		if !ok || price == 0 {
+			delete(instanceTypes, name)
+			continue
+		}
+		ins.OnDemandPricePerHour = price
+	}
+	if len(instanceTypes) == 0 {
+		return
+	}
+
+	h.priceData.Update(func(w *priceDataWriter) {
+		w.PutRegion(region, instanceTypes)
+	})
+}
+
+func (h *HuaweiCloudPriceClient) RefreshOnDemandPrice(region string) {
+	regions := make([]string, 0, len(h.projects))
+	for r := range h.projects {
+		regions = append(regions, r)
+	}
+	if region != "" {
+		if _, ok := h.projects[region]; !ok {
+			klog.Warningf("Region %s is not supported by huaweicloud", region)
+			return
+		}
+		regions = []string{region}
+	}
+
+	workqueue.ParallelizeUntil(context.Background(), 10, len(regions), func(i int) {
+		klog.Infof("Start to handle region %s for huaweicloud on-demand", regions[i])
+		h.refreshRegionOnDemandPrice(regions[i])
+	})
+
+	persistPriceData(h.store, h)
+
+	klog.Infof("All on-demand prices are refreshed for HuaweiCloud")
+}
+
+func (h *HuaweiCloudPriceClient) ListRegions() []string {
+	return sortedRegions(h.priceData.Load())
+}
+
+func (h *HuaweiCloudPriceClient) ListRegionsInstancesPrice() map[string]*apis.RegionalInstancePrice {
+	return h.priceData.Load()
+}
+
+func (h *HuaweiCloudPriceClient) ListInstancesPrice(region string) *map[string]apis.RegionalInstancePrice {
+	d, ok := h.priceData.Load()[region]
+	if !ok {
+		return nil
+	}
+	return &map[string]apis.RegionalInstancePrice{
+		region: *d,
+	}
+}
+
+func (h *HuaweiCloudPriceClient) GetInstancePrice(region, instanceType string) *apis.InstanceTypePrice {
+	regionData, ok := h.priceData.Load()[region]
+	if !ok {
+		return nil
+	}
+	d, ok := regionData.InstanceTypePrices[instanceType]
+	if !ok {
+		return nil
+	}
+
+	return d
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const huaweiCloudTestProjects = `{"projects": [
+  {"id": "p-north-4", "name": "cn-north-4"},
+  {"id": "p-north-4-sub", "name": "cn-north-4_test"},
+  {"id": "p-mos", "name": "MOS"}
+]}`
+
+const huaweiCloudTestFlavors = `{"flavors": [
+  {"id": "s6.large.2", "vcpus": "2", "ram": 4096, "os_extra_specs": {
+    "cond:operation:az": "cn-north-4a(normal),cn-north-4b(sellout),cn-north-4c(promotion)"}},
+  {"id": "kc1.large.2", "vcpus": "2", "ram": 4096, "os_extra_specs": {
+    "ecs:instance_architecture": "arm64", "cond:operation:az": "cn-north-4a(normal)"}},
+  {"id": "pi2.2xlarge.4", "vcpus": "8", "ram": 32768, "os_extra_specs": {
+    "pci_passthrough:alias": "nvidia-t4:1", "cond:operation:az": "cn-north-4a(normal)"}},
+  {"id": "s3.large.2", "vcpus": "2", "ram": 4096, "os_extra_specs": {"cond:operation:status": "abandon"}},
+  {"id": "unrated.large.2", "vcpus": "2", "ram": 4096}
+]}`
+
+// huaweiCloudTestAmounts are the hourly prices of the rated flavors
+var huaweiCloudTestAmounts = map[string]float64{
+	"s6.large.2.linux":    0.38,
+	"kc1.large.2.linux":   0.33,
+	"pi2.2xlarge.4.linux": 5.5,
+}
+
+// verifyHuaweiCloudSignature checks the SDK-HMAC-SHA256 signature of the request independently of the client
+func verifyHuaweiCloudSignature(r *http.Request, body []byte, ak, sk string) error {
+	uri := r.URL.EscapedPath()
+	if !strings.HasSuffix(uri, "/") {
+		uri += "/"
+	}
+	query := r.URL.Query()
+	var params []string
+	for k, values := range query {
+		for _, v := range values {
+			params = append(params, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	sort.Strings(params)
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		uri,
+		strings.Join(params, "&"),
+		"content-type:" + r.Header.Get("Content-Type") + "\nhost:" + r.Host + "\nx-sdk-date:" + r.Header.Get("X-Sdk-Date") + "\n",
+		"content-type;host;x-sdk-date",
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "SDK-HMAC-SHA256\n" + r.Header.Get("X-Sdk-Date") + "\n" + hex.EncodeToString(requestHash[:])
+
+	h := hmac.New(sha256.New, []byte(sk))
+	h.Write([]byte(stringToSign))
+	want := fmt.Sprintf("SDK-HMAC-SHA256 Access=%s, SignedHeaders=content-type;host;x-sdk-date, Signature=%s",
+		ak, hex.EncodeToString(h.Sum(nil)))
+	if got := r.Header.Get("Authorization"); got != want {
+		return fmt.Errorf("got authorization %q, want %q", got, want)
+	}
+	return nil
+}
+
+func TestHuaweiCloudRefreshOnDemandPrice(t *testing.T) {
+	var mutex sync.Mutex
+	var ratedSpecs []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/projects", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, huaweiCloudTestProjects)
+	})
+	mux.HandleFunc("/v1/p-north-4/cloudservers/flavors", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, huaweiCloudTestFlavors)
+	})
+	mux.HandleFunc("/v2/bills/ratings/on-demand-resources", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ProjectID    string                   `json:"project_id"`
+			ProductInfos []huaweiCloudProductInfo `json:"product_infos"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ProjectID != "p-north-4" {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		type result struct {
+			ID                    string  `json:"id"`
+			OfficialWebsiteAmount float64 `json:"official_website_amount"`
+		}
+		var results []result
+		for _, p := range req.ProductInfos {
+			mutex.Lock()
+			ratedSpecs = append(ratedSpecs, p.ResourceSpec)
+			mutex.Unlock()
+			if amount, ok := huaweiCloudTestAmounts[p.ResourceSpec]; ok {
+				results = append(results, result{ID: p.ID, OfficialWebsiteAmount: amount})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"product_rating_results": results})
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := verifyHuaweiCloudSignature(r, body, "ak", "sk"); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		mux.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	h, err := NewHuaweiCloudPriceClient(HuaweiCloudConfig{
+		AKSKPool: []AKSKPair{{AK: "ak", SK: "sk"}},
+		Endpoint: server.URL,
+	}, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.projects) != 1 || h.projects["cn-north-4"] != "p-north-4" {
+		t.Errorf("got projects %v, want the default project of cn-north-4 only", h.projects)
+	}
+	// The abandoned flavors are not rated
+	if len(ratedSpecs) != 4 {
+		t.Errorf("got rated specs %v", ratedSpecs)
+	}
+
+	prices := h.ListRegionsInstancesPrice()["cn-north-4"].InstanceTypePrices
+	if len(prices) != 3 {
+		t.Errorf("got %d instance types, want the rated ones", len(prices))
+	}
+	s6 := prices["s6.large.2"]
+	if s6 == nil {
+		t.Fatalf("s6.large.2 is not found")
+	}
+	if s6.OnDemandPricePerHour != 0.38 || s6.VCPU != 2 || s6.Memory != 4 || s6.Arch != "amd64" {
+		t.Errorf("got s6.large.2 %+v", s6)
+	}
+	if len(s6.Zones) != 2 || s6.Zones[0] != "cn-north-4a" || s6.Zones[1] != "cn-north-4c" {
+		t.Errorf("got zones %v, want the normal and promotion zones", s6.Zones)
+	}
+	if kc1 := prices["kc1.large.2"]; kc1 == nil || kc1.Arch != "arm64" {
+		t.Errorf("got kc1.large.2 %+v, want arm64", kc1)
+	}
+	if pi2 := prices["pi2.2xlarge.4"]; pi2 == nil || pi2.GPU != 1 {
+		t.Errorf("got pi2.2xlarge.4 %+v, want 1 GPU", pi2)
+	}
+}
+
+func TestHuaweiCloudSignatureFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := verifyHuaweiCloudSignature(r, body, "ak", "sk"); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, huaweiCloudTestProjects)
+	}))
+	defer server.Close()
+
+	if _, err := NewHuaweiCloudPriceClient(HuaweiCloudConfig{
+		AKSKPool: []AKSKPair{{AK: "ak", SK: "wrong"}},
+		Endpoint: server.URL,
+	}, nil, false); err == nil {
+		t.Errorf("got no error, want the signature failure")
+	}
+}
+
+func TestHuaweiCloudCanonicalQuery(t *testing.T) {
+	tests := []struct {
+		query url.Values
+		want  string
+	}{
+		{query: url.Values{}, want: ""},
+		{query: url.Values{"b": {"2"}, "a": {"1"}}, want: "a=1&b=2"},
+		{query: url.Values{"a": {"y", "x"}}, want: "a=x&a=y"},
+		{query: url.Values{"k k": {"v/v"}}, want: "k+k=v%2Fv"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := huaweiCloudCanonicalQuery(tt.query); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,444 @@
+package client
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+// maxPriceDataChanges limits the changes kept for the deltas, the changes of the oldest versions are dropped first
+const maxPriceDataChanges = 200000
+
+// atomicPriceData holds the price data of a provider as immutable snapshots. Readers load the current snapshot
+// without locking or copying, writers build the next snapshot with a copy-on-write writer and publish it with an
+// atomic pointer swap. The regions and the prices of a published snapshot must never be modified.
+type atomicPriceData struct {
+	// writeMutex serializes the writers, so the concurrent refreshes don't lose the updates of each other
+	writeMutex sync.Mutex
+	current    atomic.Pointer[priceSnapshot]
+	// events are the changes of the prices, they're published after the snapshot
+	events *priceEvents
+	// ec2Compatible means the legacy field InstanceTypeEC2Price is set for the api compatibility
+	ec2Compatible bool
+}
+
+// priceSnapshot is a published version of the price data
+type priceSnapshot struct {
+	data map[string]*apis.RegionalInstancePrice
+	// version is increased whenever the data is changed, it's at least the unix milliseconds of the publish time, so
+	// it's still increasing after the server restarts
+	version   uint64
+	updatedAt time.Time
+
+	// changes are the instance types changed after changesSince in the ascending order of the version, they're only
+	// appended so the older snapshots share them
+	changes      []priceDataChange
+	changesSince uint64
+}
+
+// priceDataChange means the price of the instance type is changed, added or removed in the version
+type priceDataChange struct {
+	version      uint64
+	region       string
+	instanceType string
+}
+
+// newAtomicPriceData creates the price data of the provider with the initial snapshot, updatedAt is the time when the
+// data was saved, zero means now
+func newAtomicPriceData(provider string, data map[string]*apis.RegionalInstancePrice, updatedAt time.Time) *atomicPriceData {
+	if data == nil {
+		data = map[string]*apis.RegionalInstancePrice{}
+	}
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+	version := nextPriceDataVersion(0)
+	p := &atomicPriceData{
+		events:        newPriceEvents(provider, version),
+		ec2Compatible: provider == apis.AWSCloudProvider,
+	}
+	p.setEC2Price(data, nil)
+	p.current.Store(&priceSnapshot{data: data, version: version, updatedAt: updatedAt, changesSince: version})
+	return p
+}
+
+func nextPriceDataVersion(version uint64) uint64 {
+	return max(version+1, uint64(time.Now().UnixMilli()))
+}
+
+// Load returns the current snapshot without copying, it must not be modified
+func (p *atomicPriceData) Load() map[string]*apis.RegionalInstancePrice {
+	return p.current.Load().data
+}
+
+// Version returns the version of the current snapshot and the time when it was published
+func (p *atomicPriceData) Version() (uint64, time.Time) {
+	snapshot := p.current.Load()
+	return snapshot.version, snapshot.updatedAt
+}
+
+// Delta returns the prices changed since the version and the removed instance types and regions of the current
+// snapshot, all the price data is returned if the changes since the version are not kept. Empty region means all.
+func (p *atomicPriceData) Delta(since uint64, region string) *apis.PriceDelta {
+	snapshot := p.current.Load()
+	delta := &apis.PriceDelta{
+		Version: snapshot.version,
+		Regions: map[string]*apis.RegionalInstancePrice{},
+	}
+	if since < snapshot.changesSince || since > snapshot.version {
+		delta.Reset = true
+		for r, d := range snapshot.data {
+			if region == "" || r == region {
+				delta.Regions[r] = &apis.RegionalInstancePrice{InstanceTypePrices: d.InstanceTypePrices}
+			}
+		}
+		return delta
+	}
+
+	i := sort.Search(len(snapshot.changes), func(i int) bool {
+		return snapshot.changes[i].version > since
+	})
+	changed := map[string]map[string]struct{}{}
+	for _, c := range snapshot.changes[i:] {
+		if region != "" && c.region != region {
+			continue
+		}
+		if changed[c.region] == nil {
+			changed[c.region] = map[string]struct{}{}
+		}
+		changed[c.region][c.instanceType] = struct{}{}
+	}
+	for r, instanceTypes := range changed {
+		d, ok := snapshot.data[r]
+		if !ok {
+			delta.RemovedRegions = append(delta.RemovedRegions, r)
+			continue
+		}
+		for instanceType := range instanceTypes {
+			price, ok := d.InstanceTypePrices[instanceType]
+			if !ok {
+				if delta.RemovedInstanceTypes == nil {
+					delta.RemovedInstanceTypes = map[string][]string{}
+				}
+				delta.RemovedInstanceTypes[r] = append(delta.RemovedInstanceTypes[r], instanceType)
+				continue
+			}
+			if delta.Regions[r] == nil {
+				delta.Regions[r] = &apis.RegionalInstancePrice{InstanceTypePrices: map[string]*apis.InstanceTypePrice{}}
+			}
+			delta.Regions[r].InstanceTypePrices[instanceType] = price
+		}
+		sort.Strings(delta.RemovedInstanceTypes[r])
+	}
+	sort.Strings(delta.RemovedRegions)
+	return delta
+}
+
+// Store publishes the data as the new snapshot, the data is owned by the snapshot after it's stored
+func (p *atomicPriceData) Store(data map[string]*apis.RegionalInstancePrice) {
+	p.writeMutex.Lock()
+	defer p.writeMutex.Unlock()
+
+	old := p.current.Load().data
+	regions := map[string]map[string]struct{}{}
+	for region := range old {
+		regions[region] = nil
+	}
+	for region := range data {
+		regions[region] = nil
+	}
+	p.setEC2Price(data, nil)
+	p.publish(data, diffPriceData(old, data, regions))
+}
+
+// Update calls update with a writer of the current snapshot and publishes the result, the snapshot is not changed
+// if no price is changed
+func (p *atomicPriceData) Update(update func(w *priceDataWriter)) {
+	p.writeMutex.Lock()
+	defer p.writeMutex.Unlock()
+
+	old := p.current.Load().data
+	w := &priceDataWriter{
+		data:          old,
+		copiedRegions: map[string]map[string]struct{}{},
+	}
+	update(w)
+	if !w.copied {
+		return
+	}
+	regions := map[string]map[string]struct{}{}
+	for region, instanceTypes := range w.copiedRegions {
+		regions[region] = instanceTypes
+	}
+	// All the instance types of the replaced regions are compared
+	for region := range w.replacedRegions {
+		regions[region] = nil
+	}
+	p.setEC2Price(w.data, w.copiedRegions)
+	p.publish(w.data, diffPriceData(old, w.data, regions))
+}
+
+// setEC2Price sets or clears the legacy field InstanceTypeEC2Price of the regions before they're published, nil
+// regions means all the regions
+func (p *atomicPriceData) setEC2Price(data map[string]*apis.RegionalInstancePrice, regions map[string]map[string]struct{}) {
+	for region, d := range data {
+		if regions != nil {
+			if _, ok := regions[region]; !ok {
+				continue
+			}
+		}
+		// TODO: this line is used to ensure the api compatibility, we should remove this line in the future
+		if p.ec2Compatible {
+			d.InstanceTypeEC2Price = d.InstanceTypePrices
+		} else {
+			d.InstanceTypeEC2Price = nil
+		}
+	}
+}
+
+// publish stores the next snapshot if any instance type is changed, the write mutex must be held
+func (p *atomicPriceData) publish(data map[string]*apis.RegionalInstancePrice, changed []apis.RegionTypeKey) {
+	if len(changed) == 0 {
+		return
+	}
+	current := p.current.Load()
+	next := &priceSnapshot{
+		data:         data,
+		version:      nextPriceDataVersion(current.version),
+		updatedAt:    time.Now(),
+		changes:      current.changes,
+		changesSince: current.changesSince,
+	}
+	for _, key := range changed {
+		next.changes = append(next.changes, priceDataChange{
+			version:      next.version,
+			region:       key.Region,
+			instanceType: key.InstanceType,
+		})
+	}
+	if len(next.changes) > maxPriceDataChanges {
+		// The versions are dropped as a whole, so the changes after changesSince are complete
+		next.changesSince = next.changes[len(next.changes)-maxPriceDataChanges-1].version
+		i := sort.Search(len(next.changes), func(i int) bool {
+			return next.changes[i].version > next.changesSince
+		})
+		next.changes = append([]priceDataChange(nil), next.changes[i:]...)
+	}
+	p.current.Store(next)
+
+	sort.Slice(changed, func(i, j int) bool {
+		if changed[i].Region != changed[j].Region {
+			return changed[i].Region < changed[j].Region
+		}
+		return changed[i].InstanceType < changed[j].InstanceType
+	})
+	var events []*apis.PriceChangeEvent
+	for _, key := range changed {
+		events = append(events, priceChangeEvents(key.Region, key.InstanceType,
+			getPrice(current.data, key), getPrice(data, key), next.updatedAt)...)
+	}
+	p.events.publish(next.version, events)
+}
+
+func getPrice(data map[string]*apis.RegionalInstancePrice, key apis.RegionTypeKey) *apis.InstanceTypePrice {
+	if d, ok := data[key.Region]; ok {
+		return d.InstanceTypePrices[key.InstanceType]
+	}
+	return nil
+}
+
+// diffPriceData returns the instance types whose prices are different in the regions, nil instance types of a region
+// means all the instance types of the region are compared
+func diffPriceData(old, data map[string]*apis.RegionalInstancePrice,
+	regions map[string]map[string]struct{}) []apis.RegionTypeKey {
+	var ret []apis.RegionTypeKey
+	for region, instanceTypes := range regions {
+		var oldPrices, prices map[string]*apis.InstanceTypePrice
+		if d, ok := old[region]; ok {
+			oldPrices = d.InstanceTypePrices
+		}
+		if d, ok := data[region]; ok {
+			prices = d.InstanceTypePrices
+		}
+		if instanceTypes == nil {
+			instanceTypes = map[string]struct{}{}
+			for instanceType := range oldPrices {
+				instanceTypes[instanceType] = struct{}{}
+			}
+			for instanceType := range prices {
+				instanceTypes[instanceType] = struct{}{}
+			}
+		}
+		for instanceType := range instanceTypes {
+			oldPrice, oldOK := oldPrices[instanceType]
+			price, ok := prices[instanceType]
+			if oldOK == ok && (!ok || samePrice(oldPrice, price)) {
+				continue
+			}
+			ret = append(ret, apis.RegionTypeKey{Region: region, InstanceType: instanceType})
+		}
+	}
+	return ret
+}
+
+// samePrice compares the prices field by field, the nil and the empty slices and maps are the same since they're
+// encoded the same. The new fields of InstanceTypePrice must be compared here.
+func samePrice(a, b *apis.InstanceTypePrice) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.Arch == b.Arch && a.VCPU == b.VCPU && a.Memory == b.Memory && a.GPU == b.GPU &&
+		a.OnDemandPricePerHour == b.OnDemandPricePerHour && a.Currency == b.Currency &&
+		slices.Equal(a.Zones, b.Zones) &&
+		maps.Equal(a.ZoneIDs, b.ZoneIDs) &&
+		maps.Equal(a.ZoneAvailability, b.ZoneAvailability) &&
+		sameSpec(a.Spec, b.Spec) &&
+		maps.Equal(a.AWSEC2Billing, b.AWSEC2Billing) &&
+		maps.Equal(a.AWSEC2ReservedBilling, b.AWSEC2ReservedBilling) &&
+		maps.Equal(a.GCPCommittedUseBilling, b.GCPCommittedUseBilling) &&
+		maps.Equal(a.AzureBilling, b.AzureBilling) &&
+		maps.Equal(a.AlibabaCloudBilling, b.AlibabaCloudBilling) &&
+		maps.Equal(a.SpotPricePerHour, b.SpotPricePerHour) &&
+		samePointee(a.SpotInterruption, b.SpotInterruption) &&
+		maps.EqualFunc(a.SpotPlacementScores, b.SpotPlacementScores, func(x, y map[string]int) bool {
+			return maps.Equal(x, y)
+		}) &&
+		maps.EqualFunc(a.Platforms, b.Platforms, samePlatformPrice) &&
+		maps.EqualFunc(a.SitePrices, b.SitePrices, samePointee[apis.SitePrice])
+}
+
+func samePlatformPrice(a, b *apis.PlatformPrice) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.OnDemandPricePerHour == b.OnDemandPricePerHour &&
+		maps.Equal(a.AWSEC2Billing, b.AWSEC2Billing) &&
+		maps.Equal(a.AWSEC2ReservedBilling, b.AWSEC2ReservedBilling) &&
+		maps.Equal(a.SpotPricePerHour, b.SpotPricePerHour) &&
+		samePointee(a.SpotInterruption, b.SpotInterruption)
+}
+
+func sameSpec(a, b *apis.InstanceTypeSpec) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if !samePointee(a.CurrentGeneration, b.CurrentGeneration) || !slices.Equal(a.Accelerators, b.Accelerators) {
+		return false
+	}
+	x, y := *a, *b
+	x.CurrentGeneration, y.CurrentGeneration = nil, nil
+	x.Accelerators, y.Accelerators = nil, nil
+	return reflect.DeepEqual(x, y)
+}
+
+// samePointee returns true if both are nil or they point to the same value
+func samePointee[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// priceDataWriter changes a copy of the snapshot, only the changed regions and prices are copied
+type priceDataWriter struct {
+	data   map[string]*apis.RegionalInstancePrice
+	copied bool
+	// copiedRegions are the regions copied by the writer and their copied prices
+	copiedRegions map[string]map[string]struct{}
+	// replacedRegions are the regions whose prices are all replaced
+	replacedRegions map[string]struct{}
+}
+
+// Data returns the price data being written, it must only be changed through the writer
+func (w *priceDataWriter) Data() map[string]*apis.RegionalInstancePrice {
+	return w.data
+}
+
+// Get returns the price of the instance type for reading
+func (w *priceDataWriter) Get(region, instanceType string) (*apis.InstanceTypePrice, bool) {
+	d, ok := w.data[region]
+	if !ok {
+		return nil, false
+	}
+	price, ok := d.InstanceTypePrices[instanceType]
+	return price, ok
+}
+
+// Mutable returns a copy of the price of the instance type owned by the writer, which can be modified
+func (w *priceDataWriter) Mutable(region, instanceType string) (*apis.InstanceTypePrice, bool) {
+	price, ok := w.Get(region, instanceType)
+	if !ok {
+		return nil, false
+	}
+	d := w.region(region)
+	if _, ok := w.copiedRegions[region][instanceType]; ok {
+		return price, true
+	}
+	price = price.DeepCopy()
+	d.InstanceTypePrices[instanceType] = price
+	w.copiedRegions[region][instanceType] = struct{}{}
+	return price, true
+}
+
+// Put sets the price of the instance type, the region is added if it doesn't exist. The price is owned by the
+// snapshot after it's put.
+func (w *priceDataWriter) Put(region, instanceType string, price *apis.InstanceTypePrice) {
+	d := w.region(region)
+	d.InstanceTypePrices[instanceType] = price
+	w.copiedRegions[region][instanceType] = struct{}{}
+}
+
+// PutRegion replaces all the prices of the region, the prices are owned by the snapshot after they're put
+func (w *priceDataWriter) PutRegion(region string, prices map[string]*apis.InstanceTypePrice) {
+	w.copyData()
+	w.data[region] = &apis.RegionalInstancePrice{InstanceTypePrices: prices}
+	copied := make(map[string]struct{}, len(prices))
+	for instanceType := range prices {
+		copied[instanceType] = struct{}{}
+	}
+	w.copiedRegions[region] = copied
+	if w.replacedRegions == nil {
+		w.replacedRegions = map[string]struct{}{}
+	}
+	w.replacedRegions[region] = struct{}{}
+}
+
+// region returns the copy of the region owned by the writer, the region is added if it doesn't exist
+func (w *priceDataWriter) region(region string) *apis.RegionalInstancePrice {
+	if _, ok := w.copiedRegions[region]; ok {
+		return w.data[region]
+	}
+	w.copyData()
+	d := &apis.RegionalInstancePrice{InstanceTypePrices: map[string]*apis.InstanceTypePrice{}}
+	if old, ok := w.data[region]; ok {
+		d.InstanceTypePrices = make(map[string]*apis.InstanceTypePrice, len(old.InstanceTypePrices))
+		for instanceType, price := range old.InstanceTypePrices {
+			d.InstanceTypePrices[instanceType] = price
+		}
+	}
+	w.data[region] = d
+	w.copiedRegions[region] = map[string]struct{}{}
+	return d
+}
+
+// copyData copies the regions of the snapshot once, so the published snapshot isn't changed
+func (w *priceDataWriter) copyData() {
+	if w.copied {
+		return
+	}
+	data := make(map[string]*apis.RegionalInstancePrice, len(w.data)+1)
+	for region, d := range w.data {
+		data[region] = d
+	}
+	w.data = data
+	w.copied = true
+}
@@ -0,0 +1,273 @@
+package client
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+func newTestPrice(onDemand float64) *apis.InstanceTypePrice {
+	return &apis.InstanceTypePrice{Arch: "amd64", VCPU: 2, Memory: 8, Currency: "USD", OnDemandPricePerHour: onDemand}
+}
+
+func TestAtomicPriceDataUpdate(t *testing.T) {
+	p := newAtomicPriceData(apis.GCPCloudProvider, map[string]*apis.RegionalInstancePrice{
+		"r1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{"a": newTestPrice(1), "b": newTestPrice(2)}},
+		"r2": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{"c": newTestPrice(3)}},
+	}, time.Time{})
+	old := p.Load()
+
+	// The snapshot is not published if nothing is written
+	p.Update(func(w *priceDataWriter) {
+		if price, ok := w.Get("r1", "a"); !ok || price.OnDemandPricePerHour != 1 {
+			t.Errorf("got price %v, %v of a, want 1", price, ok)
+		}
+		if _, ok := w.Mutable("r3", "a"); ok {
+			t.Errorf("got the price of a in r3, want none")
+		}
+	})
+	if data := p.Load(); len(data) != 2 || data["r1"] != old["r1"] {
+		t.Errorf("got a new snapshot %v, want the old one", data)
+	}
+
+	p.Update(func(w *priceDataWriter) {
+		price, _ := w.Mutable("r1", "a")
+		price.OnDemandPricePerHour = 1.5
+		// The price is copied once by the writer
+		if again, _ := w.Mutable("r1", "a"); again != price {
+			t.Errorf("got another copy of a, want the same one")
+		}
+		w.Put("r3", "d", newTestPrice(4))
+	})
+	data := p.Load()
+	if old["r1"].InstanceTypePrices["a"].OnDemandPricePerHour != 1 || len(old) != 2 {
+		t.Errorf("got the old snapshot changed, want it unchanged")
+	}
+	if data["r1"].InstanceTypePrices["a"].OnDemandPricePerHour != 1.5 || data["r3"].InstanceTypePrices["d"].OnDemandPricePerHour != 4 {
+		t.Errorf("got %v, want the written prices", data)
+	}
+	// The prices and the regions not written are shared with the old snapshot
+	if data["r1"].InstanceTypePrices["b"] != old["r1"].InstanceTypePrices["b"] || data["r2"] != old["r2"] {
+		t.Errorf("got the unchanged prices copied, want them shared")
+	}
+
+	p.Update(func(w *priceDataWriter) {
+		w.PutRegion("r1", map[string]*apis.InstanceTypePrice{"e": newTestPrice(5)})
+	})
+	if prices := p.Load()["r1"].InstanceTypePrices; len(prices) != 1 || prices["e"] == nil {
+		t.Errorf("got the prices %v of r1, want only e", prices)
+	}
+	if len(data["r1"].InstanceTypePrices) != 2 {
+		t.Errorf("got the previous snapshot changed, want it unchanged")
+	}
+}
+
+func TestAtomicPriceDataEC2Price(t *testing.T) {
+	for _, provider := range []string{apis.GCPCloudProvider, apis.AWSCloudProvider} {
+		ec2Compatible := provider == apis.AWSCloudProvider
+		p := newAtomicPriceData(provider, map[string]*apis.RegionalInstancePrice{
+			"r1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{"a": newTestPrice(1)}},
+		}, time.Time{})
+		p.Update(func(w *priceDataWriter) {
+			w.Put("r2", "b", newTestPrice(2))
+		})
+		p.Update(func(w *priceDataWriter) {
+			w.PutRegion("r3", map[string]*apis.InstanceTypePrice{"c": newTestPrice(3)})
+		})
+		// The legacy field is set for all the published regions of AWS
+		for region, d := range p.Load() {
+			if (d.InstanceTypeEC2Price != nil) != ec2Compatible {
+				t.Errorf("got the legacy field %v of %s %s, want it set %v", d.InstanceTypeEC2Price, provider, region, ec2Compatible)
+			}
+		}
+	}
+}
+
+func TestAtomicPriceDataVersion(t *testing.T) {
+	savedAt := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	p := newAtomicPriceData(apis.GCPCloudProvider, nil, savedAt)
+	v0, updatedAt := p.Version()
+	if !updatedAt.Equal(savedAt) {
+		t.Errorf("got updated at %v, want the saved time", updatedAt)
+	}
+
+	p.Update(func(w *priceDataWriter) {})
+	if v, _ := p.Version(); v != v0 {
+		t.Errorf("got version %d after nothing is written, want %d", v, v0)
+	}
+	p.Update(func(w *priceDataWriter) {
+		w.Put("r1", "a", newTestPrice(1))
+	})
+	v1, updatedAt := p.Version()
+	if v1 <= v0 || !updatedAt.After(savedAt) {
+		t.Errorf("got version %d at %v, want a newer version than %d after the saved time", v1, updatedAt, v0)
+	}
+	p.Store(map[string]*apis.RegionalInstancePrice{})
+	if v, _ := p.Version(); v <= v1 {
+		t.Errorf("got version %d after the data is stored, want a newer version than %d", v, v1)
+	}
+}
+
+// deltaPrices returns the on-demand prices of the delta by region and instance type
+func deltaPrices(delta *apis.PriceDelta) map[string]map[string]float64 {
+	ret := map[string]map[string]float64{}
+	for region, d := range delta.Regions {
+		ret[region] = map[string]float64{}
+		for instanceType, price := range d.InstanceTypePrices {
+			ret[region][instanceType] = price.OnDemandPricePerHour
+		}
+	}
+	return ret
+}
+
+func TestPriceDataDelta(t *testing.T) {
+	p := newAtomicPriceData(apis.GCPCloudProvider, map[string]*apis.RegionalInstancePrice{
+		"r1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{"a": newTestPrice(1), "b": newTestPrice(2)}},
+		"r2": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{"c": newTestPrice(3)}},
+	}, time.Time{})
+	v0, _ := p.Version()
+
+	// The unchanged prices don't publish a new version
+	p.Update(func(w *priceDataWriter) {
+		price, _ := w.Mutable("r1", "a")
+		price.OnDemandPricePerHour = 1
+	})
+	if v, _ := p.Version(); v != v0 {
+		t.Errorf("got version %d after the unchanged update, want %d", v, v0)
+	}
+
+	p.Update(func(w *priceDataWriter) {
+		w.PutRegion("r1", map[string]*apis.InstanceTypePrice{"a": newTestPrice(1.5)})
+	})
+	v1, _ := p.Version()
+	p.Store(map[string]*apis.RegionalInstancePrice{
+		"r1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{"a": newTestPrice(1.5)}},
+	})
+	v2, _ := p.Version()
+	if v0 >= v1 || v1 >= v2 {
+		t.Fatalf("got versions %d, %d, %d, want them increasing", v0, v1, v2)
+	}
+
+	tests := []struct {
+		name                 string
+		since                uint64
+		region               string
+		wantReset            bool
+		wantPrices           map[string]map[string]float64
+		wantRemovedRegions   []string
+		wantRemovedInstances map[string][]string
+	}{
+		{
+			name:                 "all changes",
+			since:                v0,
+			wantPrices:           map[string]map[string]float64{"r1": {"a": 1.5}},
+			wantRemovedRegions:   []string{"r2"},
+			wantRemovedInstances: map[string][]string{"r1": {"b"}},
+		},
+		{
+			name:                 "changes of a region",
+			since:                v0,
+			region:               "r1",
+			wantPrices:           map[string]map[string]float64{"r1": {"a": 1.5}},
+			wantRemovedInstances: map[string][]string{"r1": {"b"}},
+		},
+		{
+			name:               "removed region",
+			since:              v0,
+			region:             "r2",
+			wantPrices:         map[string]map[string]float64{},
+			wantRemovedRegions: []string{"r2"},
+		},
+		{
+			name:               "changes since the second version",
+			since:              v1,
+			wantPrices:         map[string]map[string]float64{},
+			wantRemovedRegions: []string{"r2"},
+		},
+		{
+			name:       "no changes",
+			since:      v2,
+			wantPrices: map[string]map[string]float64{},
+		},
+		{
+			// The changes before the initial version are unknown
+			name:       "before the initial version",
+			since:      v0 - 1,
+			wantReset:  true,
+			wantPrices: map[string]map[string]float64{"r1": {"a": 1.5}},
+		},
+		{
+			// A version of the future is from another server, e.g. before the restart
+			name:       "future version",
+			since:      v2 + 1,
+			wantReset:  true,
+			wantPrices: map[string]map[string]float64{"r1": {"a": 1.5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta := p.Delta(tt.since, tt.region)
+			if delta.Version != v2 || delta.Reset != tt.wantReset {
+				t.Errorf("got version %d reset %v, want %d %v", delta.Version, delta.Reset, v2, tt.wantReset)
+			}
+			if got := deltaPrices(delta); !reflect.DeepEqual(got, tt.wantPrices) {
+				t.Errorf("got prices %v, want %v", got, tt.wantPrices)
+			}
+			if !reflect.DeepEqual(delta.RemovedRegions, tt.wantRemovedRegions) {
+				t.Errorf("got removed regions %v, want %v", delta.RemovedRegions, tt.wantRemovedRegions)
+			}
+			if !reflect.DeepEqual(delta.RemovedInstanceTypes, tt.wantRemovedInstances) {
+				t.Errorf("got removed instance types %v, want %v", delta.RemovedInstanceTypes, tt.wantRemovedInstances)
+			}
+			// The legacy field is only set for AWS
+			for _, d := range delta.Regions {
+				if d.InstanceTypeEC2Price != nil {
+					t.Errorf("got the legacy field in the delta")
+				}
+			}
+		})
+	}
+}
+
+func TestPriceDataDeltaRollover(t *testing.T) {
+	const n = maxPriceDataChanges / 2
+	newData := func(price float64) map[string]*apis.RegionalInstancePrice {
+		prices := make(map[string]*apis.InstanceTypePrice, n)
+		for i := 0; i < n; i++ {
+			prices[fmt.Sprintf("t%d", i)] = newTestPrice(price)
+		}
+		return map[string]*apis.RegionalInstancePrice{"r1": {InstanceTypePrices: prices}}
+	}
+
+	p := newAtomicPriceData(apis.GCPCloudProvider, nil, time.Time{})
+	v0, _ := p.Version()
+	p.Store(newData(1))
+	v1, _ := p.Version()
+	p.Store(newData(2))
+	v2, _ := p.Version()
+	// All the changes are kept until the limit is exceeded
+	if delta := p.Delta(v0, ""); delta.Reset {
+		t.Fatalf("got reset delta since %d before the limit is exceeded", v0)
+	}
+
+	p.Update(func(w *priceDataWriter) {
+		price, _ := w.Mutable("r1", "t0")
+		price.OnDemandPricePerHour = 3
+	})
+	v3, _ := p.Version()
+
+	// The changes of the oldest version are dropped as a whole
+	if delta := p.Delta(v0, ""); !delta.Reset || len(delta.Regions["r1"].InstanceTypePrices) != n {
+		t.Errorf("got delta since %d without reset, want all the prices", v0)
+	}
+	if delta := p.Delta(v1, ""); delta.Reset || len(delta.Regions["r1"].InstanceTypePrices) != n {
+		t.Errorf("got delta since %d reset %v, want the changes of %d and %d", v1, delta.Reset, v2, v3)
+	}
+	delta := p.Delta(v2, "")
+	if got := deltaPrices(delta); delta.Reset || !reflect.DeepEqual(got, map[string]map[string]float64{"r1": {"t0": 3}}) {
+		t.Errorf("got delta since %d reset %v prices %v, want the change of %d", v2, delta.Reset, got, v3)
+	}
+}
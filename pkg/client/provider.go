@@ -0,0 +1,365 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+	"github.com/cloudpilot-ai/priceserver/pkg/storage"
+)
+
+// PriceProvider is the common interface implemented by every cloud price source
+type PriceProvider interface {
+	// Name returns the provider name used in the API path, e.g. aws
+	Name() string
+	// Service returns the compute service name of the provider, e.g. ec2, it's kept for the legacy API path
+	Service() string
+	// Run is used to refresh the price data periodically until ctx is done
+	Run(ctx context.Context)
+	// Refresh is used to refresh the price data manually, empty region or instance type means all
+	Refresh(region, instanceType string)
+	// Health returns nil if the provider is able to serve price data
+	Health() error
+	// ListRegions returns the regions which have price data
+	ListRegions() []string
+	// ListRegionsInstancesPrice returns the price data of all regions, it's shared with the provider and must not be
+	// modified
+	ListRegionsInstancesPrice() map[string]*apis.RegionalInstancePrice
+	// ListInstancesPrice returns the price data of the specified region, the prices are shared with the provider and
+	// must not be modified
+	ListInstancesPrice(region string) *map[string]apis.RegionalInstancePrice
+	// GetInstancePrice returns the price data of the specified instance type, it must not be modified
+	GetInstancePrice(region, instanceType string) *apis.InstanceTypePrice
+}
+
+// VersionedProvider is implemented by providers which version their price data, it's used to cache the responses
+type VersionedProvider interface {
+	// DataVersion returns the version of the price data, which is increased whenever the price data is changed, and
+	// the time when it was changed. The version keeps increasing after the server restarts. It should be called
+	// before loading the price data, so the loaded data is not older than the version, and called again after the
+	// data is used to check whether the data is still of the version.
+	DataVersion() (uint64, time.Time)
+}
+
+// DeltaProvider is implemented by providers which keep the recent changes of their price data
+type DeltaProvider interface {
+	VersionedProvider
+	// PriceDelta returns the prices changed since the version and the removed instance types and regions, all the
+	// price data is returned if the changes since the version are not kept. Empty region means all regions.
+	PriceDelta(since uint64, region string) *apis.PriceDelta
+}
+
+// PriceWatcher is implemented by providers which publish the changes of their prices
+type PriceWatcher interface {
+	// WatchPrices watches the price change events matching the filter until ctx is done, the kept events after the
+	// last event ID are returned first. Empty last event ID means only the new events are watched.
+	WatchPrices(ctx context.Context, lastEventID string, filter *apis.PriceEventFilter) (*PriceWatch, error)
+}
+
+// PriceCrossChecker is implemented by providers which cross-check the prices of two sources
+type PriceCrossChecker interface {
+	// CrossCheckReport returns the report of the latest refresh, nil means no report is available
+	CrossCheckReport() *apis.PriceCrossCheckReport
+}
+
+// ProviderOptions contains the options shared by all provider factories
+type ProviderOptions struct {
+	// InitialSpotUpdate indicates whether to refresh the spot price when the provider is created
+	InitialSpotUpdate bool
+	// Offline indicates all providers serve snapshots without calling cloud APIs
+	Offline bool
+	// Store persists the refreshed price data, the builtin data is used if it's nil or has no data
+	Store storage.Store
+	// SpotHistory records the refreshed spot prices, no history is kept if it's nil
+	SpotHistory storage.SpotHistoryStore
+}
+
+// ProviderFactory creates a provider, the credentials should be resolved by the factory itself,
+// ErrMissingCredentials should be wrapped if they are not set.
+type ProviderFactory func(opts *ProviderOptions) (PriceProvider, error)
+
+// ErrMissingCredentials means the provider is served read-only from the snapshot
+var ErrMissingCredentials = errors.New("credentials are not set")
+
+type providerRegistration struct {
+	service string
+	factory ProviderFactory
+}
+
+var (
+	factoryMutex sync.RWMutex
+	factories    = map[string]providerRegistration{}
+)
+
+// RegisterProviderFactory registers a provider factory, it's expected to be called in init()
+func RegisterProviderFactory(name, service string, factory ProviderFactory) {
+	factoryMutex.Lock()
+	defer factoryMutex.Unlock()
+
+	if _, ok := factories[name]; ok {
+		panic(fmt.Sprintf("price provider %s is registered twice", name))
+	}
+	factories[name] = providerRegistration{service: service, factory: factory}
+}
+
+// RegisteredProviders returns the sorted names of all registered provider factories
+func RegisteredProviders() []string {
+	factoryMutex.RLock()
+	defer factoryMutex.RUnlock()
+
+	ret := make([]string, 0, len(factories))
+	for name := range factories {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Registry holds the running providers indexed by name
+type Registry struct {
+	names       []string
+	providers   map[string]PriceProvider
+	spotHistory storage.SpotHistoryStore
+}
+
+func NewRegistry(providers ...PriceProvider) *Registry {
+	r := &Registry{
+		providers: map[string]PriceProvider{},
+	}
+	for _, p := range providers {
+		if _, ok := r.providers[p.Name()]; ok {
+			continue
+		}
+		r.names = append(r.names, p.Name())
+		r.providers[p.Name()] = p
+	}
+	sort.Strings(r.names)
+	return r
+}
+
+// NewRegistryFromFactories creates the named providers in parallel with the registered factories,
+// the providers without credentials are served read-only from the snapshots.
+func NewRegistryFromFactories(ctx context.Context, names []string, opts *ProviderOptions) (*Registry, error) {
+	factoryMutex.RLock()
+	defer factoryMutex.RUnlock()
+
+	for _, name := range names {
+		if _, ok := factories[name]; !ok {
+			return nil, fmt.Errorf("unsupported price provider: %s", name)
+		}
+	}
+
+	providers := make([]PriceProvider, len(names))
+	eg, _ := errgroup.WithContext(ctx)
+	for i, name := range names {
+		i, name, registration := i, name, factories[name]
+		eg.Go(func() error {
+			klog.Infof("Start to initialize price provider %s", name)
+			if !opts.Offline {
+				provider, err := registration.factory(opts)
+				if err == nil {
+					providers[i] = provider
+					return nil
+				}
+				if !errors.Is(err, ErrMissingCredentials) {
+					return fmt.Errorf("failed to initialize price provider %s: %w", name, err)
+				}
+				klog.Warningf("Price provider %s is served read-only: %v", name, err)
+			}
+
+			snapshot, err := NewSnapshotPriceClient(name, registration.service, opts.Store)
+			if err != nil {
+				klog.Warningf("Skip price provider %s: %v", name, err)
+				return nil
+			}
+			providers[i] = snapshot
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	providers = lo.Compact(providers)
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no price provider is available")
+	}
+	r := NewRegistry(providers...)
+	r.spotHistory = opts.SpotHistory
+	return r, nil
+}
+
+// Get returns the provider with the given name
+func (r *Registry) Get(name string) (PriceProvider, bool) {
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// SpotHistory returns the spot price history shared by the providers, it may be nil
+func (r *Registry) SpotHistory() storage.SpotHistoryStore {
+	return r.spotHistory
+}
+
+// List returns the providers sorted by name
+func (r *Registry) List() []PriceProvider {
+	ret := make([]PriceProvider, 0, len(r.names))
+	for _, name := range r.names {
+		ret = append(ret, r.providers[name])
+	}
+	return ret
+}
+
+// Run runs all the providers until ctx is done
+func (r *Registry) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, p := range r.List() {
+		wg.Add(1)
+		go func(p PriceProvider) {
+			defer wg.Done()
+			p.Run(ctx)
+		}(p)
+	}
+	wg.Wait()
+}
+
+// PriceCurrency returns the currency of the prices of the provider in the region
+func PriceCurrency(provider, region string) string {
+	switch provider {
+	case apis.AWSCloudProvider:
+		// The regions of AWS China are billed in CNY
+		if strings.HasPrefix(region, "cn-") {
+			return currency.CNY
+		}
+		return currency.USD
+	case apis.AlibabaCloudProvider, apis.TencentCloudProvider, apis.HuaweiCloudProvider:
+		// The prices are from the China sites
+		return currency.CNY
+	default:
+		return currency.USD
+	}
+}
+
+func sortedRegions(priceData map[string]*apis.RegionalInstancePrice) []string {
+	ret := make([]string, 0, len(priceData))
+	for region := range priceData {
+		ret = append(ret, region)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func builtinDataFile(name string) string {
+	return fmt.Sprintf("builtin-data/%s_price.json", name)
+}
+
+// loadPriceData loads the newest persisted price data of the provider and falls back to the builtin data,
+// an empty map is returned if neither exists.
+func loadPriceData(store storage.Store, name string) (map[string]*apis.RegionalInstancePrice, time.Time, error) {
+	if store != nil {
+		data, updatedAt, err := store.Load(name)
+		if err == nil {
+			klog.Infof("Load persisted price data of %s saved at %v", name, updatedAt)
+			return normalizePriceData(name, data), updatedAt, nil
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			klog.Errorf("Failed to load persisted price data of %s: %v", name, err)
+		}
+	}
+
+	ret := map[string]*apis.RegionalInstancePrice{}
+	data, err := file.ReadFile(builtinDataFile(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ret, time.Time{}, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, time.Time{}, err
+	}
+	klog.Infof("Load builtin price data of %s", name)
+	return normalizePriceData(name, ret), time.Time{}, nil
+}
+
+func normalizePriceData(name string, data map[string]*apis.RegionalInstancePrice) map[string]*apis.RegionalInstancePrice {
+	for region, d := range data {
+		// The data may be pulled from the legacy API which only has the compatible field
+		if d.InstanceTypePrices == nil {
+			d.InstanceTypePrices = d.InstanceTypeEC2Price
+		}
+		if d.InstanceTypePrices == nil {
+			d.InstanceTypePrices = map[string]*apis.InstanceTypePrice{}
+		}
+		d.InstanceTypeEC2Price = nil
+		// The data saved before the currency is added
+		for _, price := range d.InstanceTypePrices {
+			if price.Currency == "" {
+				price.Currency = PriceCurrency(name, region)
+			}
+		}
+	}
+	return data
+}
+
+// persistPriceData saves the current price data of the provider if the store is set
+func persistPriceData(store storage.Store, provider PriceProvider) {
+	if store == nil {
+		return
+	}
+
+	data := provider.ListRegionsInstancesPrice()
+	if len(data) == 0 {
+		return
+	}
+	// The data is shared with the snapshot, so the legacy field is dropped from the copies of the regions
+	saved := make(map[string]*apis.RegionalInstancePrice, len(data))
+	for region, d := range data {
+		saved[region] = &apis.RegionalInstancePrice{InstanceTypePrices: d.InstanceTypePrices}
+	}
+	if err := store.Save(provider.Name(), saved); err != nil {
+		klog.Errorf("Failed to persist price data of %s: %v", provider.Name(), err)
+		return
+	}
+	klog.V(4).Infof("Price data of %s is persisted", provider.Name())
+}
+
+// recordSpotHistory appends the current spot prices of the provider to the history if it's set, it's only called
+// after the scheduled spot refreshes so the points are evenly sampled
+func recordSpotHistory(history storage.SpotHistoryStore, provider PriceProvider) {
+	if history == nil {
+		return
+	}
+
+	var samples []storage.SpotPriceSample
+	for region, d := range provider.ListRegionsInstancesPrice() {
+		for instanceType, price := range d.InstanceTypePrices {
+			for zone, spotPrice := range price.SpotPricePerHour {
+				samples = append(samples, storage.SpotPriceSample{
+					Region:       region,
+					InstanceType: instanceType,
+					Zone:         zone,
+					Price:        spotPrice,
+				})
+			}
+		}
+	}
+	if len(samples) == 0 {
+		return
+	}
+	if err := history.Append(provider.Name(), time.Now(), samples); err != nil {
+		klog.Errorf("Failed to record spot price history of %s: %v", provider.Name(), err)
+	}
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/storage"
+)
+
+// ReadOnlyProvider is implemented by providers serving a snapshot which is never refreshed
+type ReadOnlyProvider interface {
+	// SnapshotTime returns the time when the snapshot was taken, zero means unknown
+	SnapshotTime() time.Time
+}
+
+// SnapshotPriceClient serves the price data of a snapshot without calling any cloud API
+type SnapshotPriceClient struct {
+	name         string
+	service      string
+	snapshotTime time.Time
+
+	// priceData is never updated after the client is created
+	priceData *atomicPriceData
+}
+
+// NewSnapshotPriceClient loads the newest persisted price data of the provider from the store,
+// the builtin data is used if the store is nil or has no data.
+func NewSnapshotPriceClient(name, service string, store storage.Store) (*SnapshotPriceClient, error) {
+	priceData, snapshotTime, err := loadPriceData(store, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(priceData) == 0 {
+		return nil, fmt.Errorf("no snapshot is available for %s", name)
+	}
+
+	return &SnapshotPriceClient{
+		name:         name,
+		service:      service,
+		snapshotTime: snapshotTime,
+		priceData:    newAtomicPriceData(name, priceData, snapshotTime),
+	}, nil
+}
+
+func (s *SnapshotPriceClient) Name() string {
+	return s.name
+}
+
+func (s *SnapshotPriceClient) Service() string {
+	return s.service
+}
+
+func (s *SnapshotPriceClient) SnapshotTime() time.Time {
+	return s.snapshotTime
+}
+
+func (s *SnapshotPriceClient) Run(ctx context.Context) {
+	<-ctx.Done()
+}
+
+func (s *SnapshotPriceClient) Refresh(_, _ string) {
+	klog.V(4).Infof("Price provider %s is read-only, skip refreshing", s.name)
+}
+
+func (s *SnapshotPriceClient) Health() error {
+	if len(s.priceData.Load()) == 0 {
+		return fmt.Errorf("no price data is available for %s", s.name)
+	}
+	return nil
+}
+
+func (s *SnapshotPriceClient) DataVersion() (uint64, time.Time) {
+	return s.priceData.Version()
+}
+
+func (s *SnapshotPriceClient) PriceDelta(since uint64, region string) *apis.PriceDelta {
+	return s.priceData.Delta(since, region)
+}
+
+func (s *SnapshotPriceClient) WatchPrices(ctx context.Context, lastEventID string, filter *apis.PriceEventFilter) (*PriceWatch, error) {
+	return s.priceData.events.Watch(ctx, lastEventID, filter)
+}
+
+func (s *SnapshotPriceClient) ListRegions() []string {
+	return sortedRegions(s.priceData.Load())
+}
+
+func (s *SnapshotPriceClient) ListRegionsInstancesPrice() map[string]*apis.RegionalInstancePrice {
+	return s.priceData.Load()
+}
+
+func (s *SnapshotPriceClient) ListInstancesPrice(region string) *map[string]apis.RegionalInstancePrice {
+	d, ok := s.priceData.Load()[region]
+	if !ok {
+		return nil
+	}
+	return &map[string]apis.RegionalInstancePrice{
+		region: *d,
+	}
+}
+
+func (s *SnapshotPriceClient) GetInstancePrice(region, instanceType string) *apis.InstanceTypePrice {
+	regionData, ok := s.priceData.Load()[region]
+	if !ok {
+		return nil
+	}
+	d, ok := regionData.InstanceTypePrices[instanceType]
+	if !ok {
+		return nil
+	}
+
+	return d
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/storage"
+)
+
+const snapshotTestData = `{"us-east-1": {"instanceTypePrices": {"m5.large": {"arch": "amd64", "vcpu": 2, "memory": 8,
+  "onDemandPricePerHour": 0.096}}}}`
+
+// snapshotTestLegacyData is pulled from the legacy API which only has the compatible field
+const snapshotTestLegacyData = `{"us-east-1": {"instanceTypeEC2Price": {"m5.large": {"arch": "amd64", "vcpu": 2, "memory": 8,
+  "onDemandPricePerHour": 0.096}}}}`
+
+// newSnapshotTestStore returns a file store with the data of the provider saved at modTime
+func newSnapshotTestStore(t *testing.T, provider, data string, modTime time.Time) storage.Store {
+	t.Helper()
+	dir := t.TempDir()
+	store, err := storage.NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data == "" {
+		return store
+	}
+	path := filepath.Join(dir, provider+"_price.json")
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestNewSnapshotPriceClient(t *testing.T) {
+	modTime := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		provider     string
+		data         string
+		noStore      bool
+		wantErr      bool
+		wantBuiltin  bool
+		wantSnapshot time.Time
+	}{
+		{name: "persisted data", provider: apis.AWSCloudProvider, data: snapshotTestData, wantSnapshot: modTime},
+		{name: "legacy data", provider: apis.AWSCloudProvider, data: snapshotTestLegacyData, wantSnapshot: modTime},
+		{name: "builtin without store", provider: apis.AlibabaCloudProvider, noStore: true, wantBuiltin: true},
+		{name: "builtin without persisted data", provider: apis.AlibabaCloudProvider, wantBuiltin: true},
+		{name: "builtin with invalid data", provider: apis.AlibabaCloudProvider, data: "{", wantBuiltin: true},
+		{name: "no snapshot", provider: apis.GCPCloudProvider, wantErr: true},
+		{name: "invalid data without builtin", provider: apis.AWSCloudProvider, data: "{", wantErr: true},
+		{name: "empty data", provider: apis.AWSCloudProvider, data: "{}", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newSnapshotTestStore(t, tt.provider, tt.data, modTime)
+			if tt.noStore {
+				store = nil
+			}
+
+			s, err := NewSnapshotPriceClient(tt.provider, "", store)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !s.SnapshotTime().Equal(tt.wantSnapshot) {
+				t.Errorf("got snapshot time %v, want %v", s.SnapshotTime(), tt.wantSnapshot)
+			}
+			if err := s.Health(); err != nil {
+				t.Errorf("got unhealthy snapshot: %v", err)
+			}
+			if tt.wantBuiltin {
+				return
+			}
+			price := s.GetInstancePrice("us-east-1", "m5.large")
+			if price == nil || price.OnDemandPricePerHour != 0.096 {
+				t.Errorf("got m5.large %+v, want the price of the snapshot", price)
+			}
+		})
+	}
+}
+
+func TestSnapshotPriceClientLegacyField(t *testing.T) {
+	for _, provider := range []string{apis.AWSCloudProvider, apis.GCPCloudProvider} {
+		s, err := NewSnapshotPriceClient(provider, "", newSnapshotTestStore(t, provider, snapshotTestLegacyData, time.Now()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The compatible field is only returned for AWS
+		d := s.ListRegionsInstancesPrice()["us-east-1"]
+		if got := d.InstanceTypeEC2Price != nil; got != (provider == apis.AWSCloudProvider) {
+			t.Errorf("got the legacy field %v for %s", got, provider)
+		}
+		regional := (*s.ListInstancesPrice("us-east-1"))["us-east-1"]
+		if got := regional.InstanceTypeEC2Price != nil; got != (provider == apis.AWSCloudProvider) {
+			t.Errorf("got the legacy field %v of the region for %s", got, provider)
+		}
+		if s.ListInstancesPrice("us-west-2") != nil {
+			t.Errorf("got the prices of an unknown region for %s", provider)
+		}
+	}
+}
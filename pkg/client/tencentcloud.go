@@ -0,0 +1,442 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/storage"
+)
+
+const (
+	tencentCloudDefaultEndpoint = "https://cvm.tencentcloudapi.com"
+	tencentCloudCVMService      = "cvm"
+	tencentCloudCVMVersion      = "2017-03-12"
+
+	tencentCloudChargeTypePostPaid = "POSTPAID_BY_HOUR"
+	tencentCloudChargeTypeSpot     = "SPOTPAID"
+)
+
+const tencentCloudService = "cvm"
+
+func init() {
+	RegisterProviderFactory(apis.TencentCloudProvider, tencentCloudService, newTencentCloudPriceProvider)
+}
+
+// TencentCloudConfig contains the settings of the TencentCloud price client, the endpoint
+// can be pointed to a local HTTP stand-in of the CVM API.
+type TencentCloudConfig struct {
+	AKSKPool []AKSKPair
+	Endpoint string
+}
+
+type TencentCloudPriceClient struct {
+	config      TencentCloudConfig
+	httpClient  *http.Client
+	store       storage.Store
+	spotHistory storage.SpotHistoryStore
+
+	regionList []string
+
+	priceData *atomicPriceData
+}
+
+func newTencentCloudPriceProvider(opts *ProviderOptions) (PriceProvider, error) {
+	akskPool := ExtractAKSKPool(apis.TencentCloudAKSKPoolEnv)
+	if len(akskPool) == 0 {
+		return nil, fmt.Errorf("tencent cloud access key and secret key pool is not set: %w", ErrMissingCredentials)
+	}
+
+	return NewTencentCloudPriceClient(TencentCloudConfig{
+		AKSKPool: akskPool,
+		Endpoint: os.Getenv(apis.TencentCloudEndpointEnv),
+	}, opts.Store, opts.SpotHistory, opts.InitialSpotUpdate)
+}
+
+func NewTencentCloudPriceClient(config TencentCloudConfig, store storage.Store, spotHistory storage.SpotHistoryStore,
+	initialUpdate bool) (*TencentCloudPriceClient, error) {
+	if len(config.AKSKPool) == 0 {
+		return nil, fmt.Errorf("tencent cloud access key and secret key pool is empty")
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = tencentCloudDefaultEndpoint
+	}
+
+	priceData, updatedAt, err := loadPriceData(store, apis.TencentCloudProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &TencentCloudPriceClient{
+		config:      config,
+		httpClient:  &http.Client{Timeout: time.Minute},
+		store:       store,
+		spotHistory: spotHistory,
+		priceData:   newAtomicPriceData(apis.TencentCloudProvider, priceData, updatedAt),
+	}
+	if err := client.initialRegions(); err != nil {
+		return nil, err
+	}
+
+	if initialUpdate {
+		client.RefreshPrice()
+		recordSpotHistory(client.spotHistory, client)
+	}
+
+	return client, nil
+}
+
+func (t *TencentCloudPriceClient) Name() string {
+	return apis.TencentCloudProvider
+}
+
+func (t *TencentCloudPriceClient) Service() string {
+	return tencentCloudService
+}
+
+func (t *TencentCloudPriceClient) Run(ctx context.Context) {
+	// DescribeZoneInstanceConfigInfos returns both the postpaid and spot prices,
+	// so all prices are refreshed with the spot interval.
+	ticker := time.NewTicker(time.Minute * 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.RefreshPrice()
+			recordSpotHistory(t.spotHistory, t)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Refresh refreshes the prices of the given region, empty means all regions,
+// the instance type is ignored since all types of a region are returned at once.
+func (t *TencentCloudPriceClient) Refresh(region, _ string) {
+	if region == "" {
+		t.RefreshPrice()
+		return
+	}
+	t.refreshRegionPrice(region)
+	persistPriceData(t.store, t)
+}
+
+func (t *TencentCloudPriceClient) Health() error {
+	if len(t.priceData.Load()) == 0 {
+		return fmt.Errorf("no price data is available for tencentcloud")
+	}
+	return nil
+}
+
+func (t *TencentCloudPriceClient) DataVersion() (uint64, time.Time) {
+	return t.priceData.Version()
+}
+
+func (t *TencentCloudPriceClient) PriceDelta(since uint64, region string) *apis.PriceDelta {
+	return t.priceData.Delta(since, region)
+}
+
+func (t *TencentCloudPriceClient) WatchPrices(ctx context.Context, lastEventID string, filter *apis.PriceEventFilter) (*PriceWatch, error) {
+	return t.priceData.events.Watch(ctx, lastEventID, filter)
+}
+
+type tencentCloudError struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+}
+
+type tencentCloudResponse struct {
+	Response json.RawMessage `json:"Response"`
+}
+
+func sha256Hex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// call sends the request signed with TC3-HMAC-SHA256, see https://www.tencentcloud.com/document/api/213/33224
+func (t *TencentCloudPriceClient) call(action, region string, request, response interface{}) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	endpoint, err := url.Parse(t.config.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	date := now.Format("2006-01-02")
+	contentType := "application/json; charset=utf-8"
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		"/",
+		"",
+		fmt.Sprintf("content-type:%s\nhost:%s\nx-tc-action:%s\n", contentType, endpoint.Host, strings.ToLower(action)),
+		"content-type;host;x-tc-action",
+		sha256Hex(payload),
+	}, "\n")
+	credentialScope := fmt.Sprintf("%s/%s/tc3_request", date, tencentCloudCVMService)
+	stringToSign := strings.Join([]string{
+		"TC3-HMAC-SHA256",
+		timestamp,
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	ak, sk := pickAKSK(t.config.AKSKPool)
+	secretDate := hmacSHA256([]byte("TC3"+sk), date)
+	secretService := hmacSHA256(secretDate, tencentCloudCVMService)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+
+	req, err := http.NewRequest(http.MethodPost, endpoint.String(), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Host", endpoint.Host)
+	req.Header.Set("X-TC-Action", action)
+	req.Header.Set("X-TC-Timestamp", timestamp)
+	req.Header.Set("X-TC-Version", tencentCloudCVMVersion)
+	if region != "" {
+		req.Header.Set("X-TC-Region", region)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=content-type;host;x-tc-action, Signature=%s",
+		ak, credentialScope, signature))
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request %s failed: %s, %s", action, resp.Status, string(data))
+	}
+
+	var wrapper tencentCloudResponse
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return err
+	}
+	var apiErr struct {
+		Error *tencentCloudError `json:"Error"`
+	}
+	if err := json.Unmarshal(wrapper.Response, &apiErr); err != nil {
+		return err
+	}
+	if apiErr.Error != nil {
+		return fmt.Errorf("request %s failed: %s, %s", action, apiErr.Error.Code, apiErr.Error.Message)
+	}
+
+	return json.Unmarshal(wrapper.Response, response)
+}
+
+type tencentCloudFilter struct {
+	Name   string   `json:"Name"`
+	Values []string `json:"Values"`
+}
+
+type TencentCloudInstanceTypeQuota struct {
+	Zone               string  `json:"Zone"`
+	InstanceType       string  `json:"InstanceType"`
+	InstanceChargeType string  `json:"InstanceChargeType"`
+	Cpu                int64   `json:"Cpu"`
+	Memory             int64   `json:"Memory"`
+	Gpu                int64   `json:"Gpu"`
+	GpuCount           float64 `json:"GpuCount"`
+	CpuType            string  `json:"CpuType"`
+	Status             string  `json:"Status"`
+	Price              struct {
+		UnitPrice         float64 `json:"UnitPrice"`
+		UnitPriceDiscount float64 `json:"UnitPriceDiscount"`
+		ChargeUnit        string  `json:"ChargeUnit"`
+	} `json:"Price"`
+}
+
+func (t *TencentCloudPriceClient) initialRegions() error {
+	var resp struct {
+		RegionSet []struct {
+			Region      string `json:"Region"`
+			RegionState string `json:"RegionState"`
+		} `json:"RegionSet"`
+	}
+	if err := t.call("DescribeRegions", "", struct{}{}, &resp); err != nil {
+		klog.Errorf("Failed to list tencentcloud regions: %v", err)
+		return err
+	}
+
+	for _, region := range resp.RegionSet {
+		if region.RegionState != "AVAILABLE" {
+			continue
+		}
+		t.regionList = append(t.regionList, region.Region)
+	}
+
+	return nil
+}
+
+func extractTencentCloudArch(cpuType string) string {
+	cpuType = strings.ToLower(cpuType)
+	for _, arm := range []string{"ampere", "kunpeng", "arm", "yitian"} {
+		if strings.Contains(cpuType, arm) {
+			return "arm64"
+		}
+	}
+	return "amd64"
+}
+
+func (t *TencentCloudPriceClient) listInstanceTypeQuotas(region string) ([]TencentCloudInstanceTypeQuota, error) {
+	req := struct {
+		Filters []tencentCloudFilter `json:"Filters"`
+	}{
+		Filters: []tencentCloudFilter{
+			{
+				Name:   "instance-charge-type",
+				Values: []string{tencentCloudChargeTypePostPaid, tencentCloudChargeTypeSpot},
+			},
+		},
+	}
+	var resp struct {
+		InstanceTypeQuotaSet []TencentCloudInstanceTypeQuota `json:"InstanceTypeQuotaSet"`
+	}
+	if err := t.call("DescribeZoneInstanceConfigInfos", region, req, &resp); err != nil {
+		klog.Errorf("Failed to list tencentcloud instance types in region %s: %v", region, err)
+		return nil, err
+	}
+
+	return resp.InstanceTypeQuotaSet, nil
+}
+
+func newTencentCloudInstanceTypes(region string, quotas []TencentCloudInstanceTypeQuota) map[string]*apis.InstanceTypePrice {
+	ret := map[string]*apis.InstanceTypePrice{}
+	for _, q := range quotas {
+		ins, ok := ret[q.InstanceType]
+		if !ok {
+			ins = &apis.InstanceTypePrice{
+				Arch:     extractTencentCloudArch(q.CpuType),
+				VCPU:     float64(q.Cpu),
+				Memory:   float64(q.Memory),
+				GPU:      q.GpuCount,
+				Currency: PriceCurrency(apis.TencentCloudProvider, region),
+			}
+			if ins.GPU == 0 {
+				ins.GPU = float64(q.Gpu)
+			}
+			ret[q.InstanceType] = ins
+		}
+
+		switch q.InstanceChargeType {
+		case tencentCloudChargeTypePostPaid:
+			if q.Price.UnitPrice == 0 {
+				continue
+			}
+			// The list price is the same in all zones
+			ins.OnDemandPricePerHour = q.Price.UnitPrice
+			if q.Status == "SELL" {
+				ins.Zones = append(ins.Zones, q.Zone)
+			}
+		case tencentCloudChargeTypeSpot:
+			// The discounted price of the spot charge type is the spot price
+			if q.Price.UnitPriceDiscount == 0 {
+				continue
+			}
+			if ins.SpotPricePerHour == nil {
+				ins.SpotPricePerHour = map[string]float64{}
+			}
+			ins.SpotPricePerHour[q.Zone] = q.Price.UnitPriceDiscount
+		}
+	}
+
+	for name, ins := range ret {
+		if ins.OnDemandPricePerHour == 0 {
+			delete(ret, name)
+		}
+	}
+	return ret
+}
+
+func (t *TencentCloudPriceClient) refreshRegionPrice(region string) {
+	quotas, err := t.listInstanceTypeQuotas(region)
+	if err != nil {
+		return
+	}
+	instanceTypes := newTencentCloudInstanceTypes(region, quotas)
+	if len(instanceTypes) == 0 {
+		return
+	}
+
+	t.priceData.Update(func(w *priceDataWriter) {
+		w.PutRegion(region, instanceTypes)
+	})
+}
+
+func (t *TencentCloudPriceClient) RefreshPrice() {
+	workqueue.ParallelizeUntil(context.Background(), 10, len(t.regionList), func(i int) {
+		klog.Infof("Start to handle region %s for tencentcloud", t.regionList[i])
+		t.refreshRegionPrice(t.regionList[i])
+	})
+
+	persistPriceData(t.store, t)
+
+	klog.Infof("All prices are refreshed for TencentCloud")
+}
+
+func (t *TencentCloudPriceClient) ListRegions() []string {
+	return sortedRegions(t.priceData.Load())
+}
+
+func (t *TencentCloudPriceClient) ListRegionsInstancesPrice() map[string]*apis.RegionalInstancePrice {
+	return t.priceData.Load()
+}
+
+func (t *TencentCloudPriceClient) ListInstancesPrice(region string) *map[string]apis.RegionalInstancePrice {
+	d, ok := t.priceData.Load()[region]
+	if !ok {
+		return nil
+	}
+	return &map[string]apis.RegionalInstancePrice{
+		region: *d,
+	}
+}
+
+func (t *TencentCloudPriceClient) GetInstancePrice(region, instanceType string) *apis.InstanceTypePrice {
+	regionData, ok := t.priceData.Load()[region]
+	if !ok {
+		return nil
+	}
+	d, ok := regionData.InstanceTypePrices[instanceType]
+	if !ok {
+		return nil
+	}
+
+	return d
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const tencentCloudTestRegions = `{"Response": {"RegionSet": [
+  {"Region": "ap-guangzhou", "RegionState": "AVAILABLE"},
+  {"Region": "ap-shanghai", "RegionState": "AVAILABLE"},
+  {"Region": "ap-closed", "RegionState": "UNAVAILABLE"}
+]}}`
+
+const tencentCloudTestQuotas = `{"Response": {"InstanceTypeQuotaSet": [
+  {"Zone": "ap-guangzhou-3", "InstanceType": "S5.MEDIUM2", "InstanceChargeType": "POSTPAID_BY_HOUR", "Cpu": 2, "Memory": 2,
+   "CpuType": "Intel Xeon Cascade Lake", "Status": "SELL", "Price": {"UnitPrice": 0.23}},
+  {"Zone": "ap-guangzhou-4", "InstanceType": "S5.MEDIUM2", "InstanceChargeType": "POSTPAID_BY_HOUR", "Cpu": 2, "Memory": 2,
+   "CpuType": "Intel Xeon Cascade Lake", "Status": "SOLD_OUT", "Price": {"UnitPrice": 0.23}},
+  {"Zone": "ap-guangzhou-3", "InstanceType": "S5.MEDIUM2", "InstanceChargeType": "SPOTPAID", "Cpu": 2, "Memory": 2,
+   "CpuType": "Intel Xeon Cascade Lake", "Status": "SELL", "Price": {"UnitPrice": 0.23, "UnitPriceDiscount": 0.046}},
+  {"Zone": "ap-guangzhou-3", "InstanceType": "SR1.MEDIUM4", "InstanceChargeType": "POSTPAID_BY_HOUR", "Cpu": 2, "Memory": 4,
+   "CpuType": "Ampere Altra", "Status": "SELL", "Price": {"UnitPrice": 0.2}},
+  {"Zone": "ap-guangzhou-3", "InstanceType": "GN7.2XLARGE32", "InstanceChargeType": "POSTPAID_BY_HOUR", "Cpu": 8, "Memory": 32,
+   "Gpu": 1, "GpuCount": 0.5, "CpuType": "Intel Xeon Cascade Lake", "Status": "SELL", "Price": {"UnitPrice": 4.5}},
+  {"Zone": "ap-guangzhou-3", "InstanceType": "S6.SPOTONLY", "InstanceChargeType": "SPOTPAID", "Cpu": 2, "Memory": 2,
+   "CpuType": "Intel Xeon Ice Lake", "Status": "SELL", "Price": {"UnitPriceDiscount": 0.05}}
+]}}`
+
+// verifyTC3Signature checks the TC3-HMAC-SHA256 signature of the request independently of the client
+func verifyTC3Signature(r *http.Request, body []byte, ak, sk string) error {
+	timestamp, err := strconv.ParseInt(r.Header.Get("X-TC-Timestamp"), 10, 64)
+	if err != nil {
+		return err
+	}
+	date := time.Unix(timestamp, 0).UTC().Format("2006-01-02")
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := r.Method + "\n/\n\n" +
+		"content-type:" + r.Header.Get("Content-Type") + "\n" +
+		"host:" + r.Host + "\n" +
+		"x-tc-action:" + strings.ToLower(r.Header.Get("X-TC-Action")) + "\n\n" +
+		"content-type;host;x-tc-action\n" +
+		hex.EncodeToString(bodyHash[:])
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := date + "/cvm/tc3_request"
+	stringToSign := "TC3-HMAC-SHA256\n" + r.Header.Get("X-TC-Timestamp") + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("TC3" + sk)
+	for _, data := range []string{date, "cvm", "tc3_request", stringToSign} {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(data))
+		key = h.Sum(nil)
+	}
+	want := fmt.Sprintf("TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=content-type;host;x-tc-action, Signature=%s",
+		ak, scope, hex.EncodeToString(key))
+	if got := r.Header.Get("Authorization"); got != want {
+		return fmt.Errorf("got authorization %q, want %q", got, want)
+	}
+	return nil
+}
+
+func TestTencentCloudRefreshPrice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := verifyTC3Signature(r, body, "ak", "sk"); err != nil {
+			fmt.Fprintf(w, `{"Response": {"Error": {"Code": "AuthFailure.SignatureFailure", "Message": %q}}}`, err.Error())
+			return
+		}
+		switch action := r.Header.Get("X-TC-Action"); action {
+		case "DescribeRegions":
+			fmt.Fprint(w, tencentCloudTestRegions)
+		case "DescribeZoneInstanceConfigInfos":
+			if r.Header.Get("X-TC-Region") != "ap-guangzhou" {
+				fmt.Fprint(w, `{"Response": {"Error": {"Code": "InternalError", "Message": "internal error"}}}`)
+				return
+			}
+			fmt.Fprint(w, tencentCloudTestQuotas)
+		default:
+			t.Errorf("unexpected action %s", action)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewTencentCloudPriceClient(TencentCloudConfig{
+		AKSKPool: []AKSKPair{{AK: "ak", SK: "sk"}},
+		Endpoint: server.URL,
+	}, nil, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.regionList) != 2 {
+		t.Errorf("got regions %v, want the available ones", c.regionList)
+	}
+	c.RefreshPrice()
+
+	if regions := c.ListRegions(); len(regions) != 1 || regions[0] != "ap-guangzhou" {
+		t.Fatalf("got regions %v, want ap-guangzhou only", regions)
+	}
+	prices := c.ListRegionsInstancesPrice()["ap-guangzhou"].InstanceTypePrices
+	if _, ok := prices["S6.SPOTONLY"]; ok {
+		t.Errorf("the instance type without the postpaid price should be skipped")
+	}
+
+	s5 := prices["S5.MEDIUM2"]
+	if s5 == nil {
+		t.Fatalf("S5.MEDIUM2 is not found")
+	}
+	if s5.OnDemandPricePerHour != 0.23 || s5.SpotPricePerHour["ap-guangzhou-3"] != 0.046 || s5.Arch != "amd64" {
+		t.Errorf("got S5.MEDIUM2 %+v", s5)
+	}
+	if len(s5.Zones) != 1 || s5.Zones[0] != "ap-guangzhou-3" {
+		t.Errorf("got zones %v, want the selling zone only", s5.Zones)
+	}
+	if sr1 := prices["SR1.MEDIUM4"]; sr1 == nil || sr1.Arch != "arm64" {
+		t.Errorf("got SR1.MEDIUM4 %+v, want arm64", sr1)
+	}
+	// GpuCount is preferred since it's fractional for the vGPU instance types
+	if gn7 := prices["GN7.2XLARGE32"]; gn7 == nil || gn7.GPU != 0.5 {
+		t.Errorf("got GN7.2XLARGE32 %+v, want 0.5 GPU", gn7)
+	}
+}
+
+func TestTencentCloudSignatureFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := verifyTC3Signature(r, body, "ak", "sk"); err != nil {
+			fmt.Fprintf(w, `{"Response": {"Error": {"Code": "AuthFailure.SignatureFailure", "Message": %q}}}`, err.Error())
+			return
+		}
+		fmt.Fprint(w, tencentCloudTestRegions)
+	}))
+	defer server.Close()
+
+	_, err := NewTencentCloudPriceClient(TencentCloudConfig{
+		AKSKPool: []AKSKPair{{AK: "ak", SK: "wrong"}},
+		Endpoint: server.URL,
+	}, nil, nil, false)
+	if err == nil || !strings.Contains(err.Error(), "AuthFailure.SignatureFailure") {
+		t.Errorf("got error %v, want the signature failure", err)
+	}
+}
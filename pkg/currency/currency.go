@@ -0,0 +1,143 @@
+package currency
+
+import (
+	"fmt"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+const (
+	USD = "USD"
+	CNY = "CNY"
+	EUR = "EUR"
+)
+
+// Rates is the amount of each currency equal to 1 USD
+type Rates map[string]float64
+
+// DefaultRates are used to compare the prices in different currencies, they're not meant for billing
+var DefaultRates = Rates{
+	USD: 1,
+	CNY: 7.2,
+	EUR: 0.92,
+}
+
+// Convert converts the amount from a currency to another
+func (r Rates) Convert(amount float64, from, to string) (float64, error) {
+	if from == to {
+		return amount, nil
+	}
+	fromRate, ok := r[from]
+	if !ok || fromRate <= 0 {
+		return 0, fmt.Errorf("unknown currency %s", from)
+	}
+	toRate, ok := r[to]
+	if !ok || toRate <= 0 {
+		return 0, fmt.Errorf("unknown currency %s", to)
+	}
+	return amount / fromRate * toRate, nil
+}
+
+// ConvertPrice converts an amount of p to the currency, from is used if the currency of p is not set
+func (r Rates) ConvertPrice(p *apis.InstanceTypePrice, amount float64, from, to string) (float64, error) {
+	if p.Currency != "" {
+		from = p.Currency
+	}
+	return r.Convert(amount, from, to)
+}
+
+// ConvertInstanceTypePrice converts all the prices of p to the currency in place, from is used
+// if the currency of p is not set
+func (r Rates) ConvertInstanceTypePrice(p *apis.InstanceTypePrice, from, to string) error {
+	// The site prices have their own currency
+	for _, sitePrice := range p.SitePrices {
+		v, err := r.Convert(sitePrice.OnDemandPricePerHour, sitePrice.Currency, to)
+		if err != nil {
+			return err
+		}
+		sitePrice.OnDemandPricePerHour, sitePrice.Currency = v, to
+	}
+
+	if p.Currency != "" {
+		from = p.Currency
+	}
+	if from == to {
+		p.Currency = to
+		return nil
+	}
+
+	convert := func(v float64) (float64, error) {
+		return r.Convert(v, from, to)
+	}
+	err := convertPlatformPrice(&apis.PlatformPrice{
+		AWSEC2Billing:         p.AWSEC2Billing,
+		AWSEC2ReservedBilling: p.AWSEC2ReservedBilling,
+		SpotPricePerHour:      p.SpotPricePerHour,
+	}, convert)
+	if err != nil {
+		return err
+	}
+	if p.OnDemandPricePerHour, err = convert(p.OnDemandPricePerHour); err != nil {
+		return err
+	}
+	for _, platform := range p.Platforms {
+		if err = convertPlatformPrice(platform, convert); err != nil {
+			return err
+		}
+	}
+	for k, v := range p.GCPCommittedUseBilling {
+		if v.Rate, err = convert(v.Rate); err != nil {
+			return err
+		}
+		p.GCPCommittedUseBilling[k] = v
+	}
+	for k, v := range p.AzureBilling {
+		if v.Rate, err = convert(v.Rate); err != nil {
+			return err
+		}
+		p.AzureBilling[k] = v
+	}
+	for k, v := range p.AlibabaCloudBilling {
+		if v.Rate, err = convert(v.Rate); err != nil {
+			return err
+		}
+		if v.UpfrontFee, err = convert(v.UpfrontFee); err != nil {
+			return err
+		}
+		p.AlibabaCloudBilling[k] = v
+	}
+	p.Currency = to
+	return nil
+}
+
+// convertPlatformPrice converts the prices in place, the maps are shared with the instance type price
+func convertPlatformPrice(p *apis.PlatformPrice, convert func(float64) (float64, error)) error {
+	var err error
+	if p.OnDemandPricePerHour, err = convert(p.OnDemandPricePerHour); err != nil {
+		return err
+	}
+	for k, v := range p.SpotPricePerHour {
+		if p.SpotPricePerHour[k], err = convert(v); err != nil {
+			return err
+		}
+	}
+	for k, v := range p.AWSEC2Billing {
+		if v.Rate, err = convert(v.Rate); err != nil {
+			return err
+		}
+		p.AWSEC2Billing[k] = v
+	}
+	for k, v := range p.AWSEC2ReservedBilling {
+		if v.Rate, err = convert(v.Rate); err != nil {
+			return err
+		}
+		if v.UpfrontFee, err = convert(v.UpfrontFee); err != nil {
+			return err
+		}
+		if v.HourlyRate, err = convert(v.HourlyRate); err != nil {
+			return err
+		}
+		p.AWSEC2ReservedBilling[k] = v
+	}
+	return nil
+}
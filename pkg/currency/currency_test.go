@@ -0,0 +1,144 @@
+package currency
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+var testRates = Rates{USD: 1, CNY: 8, EUR: 0.5}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		from, to string
+		want     float64
+		wantErr  bool
+	}{
+		{amount: 2, from: USD, to: CNY, want: 16},
+		{amount: 16, from: CNY, to: EUR, want: 1},
+		{amount: 3, from: "JPY", to: "JPY", want: 3},
+		{amount: 1, from: "JPY", to: USD, wantErr: true},
+		{amount: 1, from: USD, to: "JPY", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"-"+tt.to, func(t *testing.T) {
+			got, err := testRates.Convert(tt.amount, tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertPrice(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		from     string
+		want     float64
+	}{
+		{name: "currency of the price", currency: USD, from: CNY, want: 8},
+		{name: "currency not set", from: CNY, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testRates.ConvertPrice(&apis.InstanceTypePrice{Currency: tt.currency}, 1, tt.from, CNY)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newTestInstanceTypePrice(currency string) *apis.InstanceTypePrice {
+	return &apis.InstanceTypePrice{
+		Currency:             currency,
+		OnDemandPricePerHour: 1,
+		SpotPricePerHour:     map[string]float64{"z1": 0.5},
+		AWSEC2Billing:        map[string]apis.AWSEC2Billing{"ComputeSavingsPlans/1yr/no": {Rate: 0.75}},
+		AWSEC2ReservedBilling: map[string]apis.AWSEC2ReservedBilling{
+			"standard/1yr/partial": {Rate: 0.625, UpfrontFee: 2190, HourlyRate: 0.375},
+		},
+		GCPCommittedUseBilling: map[string]apis.GCPCommittedUseBilling{"1yr": {Rate: 0.625}},
+		AzureBilling:           map[string]apis.AzureBilling{"reservation/1yr": {Rate: 0.5}},
+		AlibabaCloudBilling:    map[string]apis.AlibabaCloudBilling{"subscription/1yr": {Rate: 0.75, UpfrontFee: 6570}},
+		Platforms: map[string]*apis.PlatformPrice{
+			"windows/shared": {
+				OnDemandPricePerHour:  2,
+				SpotPricePerHour:      map[string]float64{"z1": 1},
+				AWSEC2Billing:         map[string]apis.AWSEC2Billing{"EC2InstanceSavingsPlans/1yr/no": {Rate: 1.5}},
+				AWSEC2ReservedBilling: map[string]apis.AWSEC2ReservedBilling{"standard/1yr/no": {Rate: 1.25, HourlyRate: 1.25}},
+			},
+		},
+		SitePrices: map[string]*apis.SitePrice{apis.SiteIntl: {OnDemandPricePerHour: 0.25, Currency: USD}},
+	}
+}
+
+func TestConvertInstanceTypePrice(t *testing.T) {
+	tests := []struct {
+		name string
+		// currency is the currency of the price, from is the currency of the provider in the region
+		currency, from, to string
+		// factor converts the prices in the currency of the price to the target currency
+		factor float64
+	}{
+		{name: "currency of the price differs", currency: USD, from: CNY, to: CNY, factor: 8},
+		{name: "currency of the price", currency: CNY, from: CNY, to: EUR, factor: 0.0625},
+		{name: "currency not set", from: CNY, to: USD, factor: 0.125},
+		{name: "same currency", currency: USD, from: USD, to: USD, factor: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestInstanceTypePrice(tt.currency)
+			if err := testRates.ConvertInstanceTypePrice(p, tt.from, tt.to); err != nil {
+				t.Fatal(err)
+			}
+
+			want := newTestInstanceTypePrice(tt.to)
+			want.OnDemandPricePerHour *= tt.factor
+			want.SpotPricePerHour["z1"] *= tt.factor
+			want.AWSEC2Billing["ComputeSavingsPlans/1yr/no"] = apis.AWSEC2Billing{Rate: 0.75 * tt.factor}
+			want.AWSEC2ReservedBilling["standard/1yr/partial"] = apis.AWSEC2ReservedBilling{
+				Rate:       0.625 * tt.factor,
+				UpfrontFee: 2190 * tt.factor,
+				HourlyRate: 0.375 * tt.factor,
+			}
+			want.GCPCommittedUseBilling["1yr"] = apis.GCPCommittedUseBilling{Rate: 0.625 * tt.factor}
+			want.AzureBilling["reservation/1yr"] = apis.AzureBilling{Rate: 0.5 * tt.factor}
+			want.AlibabaCloudBilling["subscription/1yr"] = apis.AlibabaCloudBilling{Rate: 0.75 * tt.factor, UpfrontFee: 6570 * tt.factor}
+			windows := want.Platforms["windows/shared"]
+			windows.OnDemandPricePerHour *= tt.factor
+			windows.SpotPricePerHour["z1"] *= tt.factor
+			windows.AWSEC2Billing["EC2InstanceSavingsPlans/1yr/no"] = apis.AWSEC2Billing{Rate: 1.5 * tt.factor}
+			windows.AWSEC2ReservedBilling["standard/1yr/no"] = apis.AWSEC2ReservedBilling{
+				Rate:       1.25 * tt.factor,
+				HourlyRate: 1.25 * tt.factor,
+			}
+			// The site prices are converted from their own currency
+			sitePrice, err := testRates.Convert(0.25, USD, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want.SitePrices[apis.SiteIntl] = &apis.SitePrice{OnDemandPricePerHour: sitePrice, Currency: tt.to}
+			if !reflect.DeepEqual(p, want) {
+				t.Errorf("got %+v, want %+v", p, want)
+			}
+		})
+	}
+}
+
+func TestConvertInstanceTypePriceUnknownCurrency(t *testing.T) {
+	p := newTestInstanceTypePrice("JPY")
+	if err := testRates.ConvertInstanceTypePrice(p, CNY, USD); err == nil {
+		t.Errorf("got no error, want the unknown currency")
+	}
+}
@@ -0,0 +1,171 @@
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// RateSource provides the exchange rates
+type RateSource interface {
+	// Rates returns the current exchange rates, the returned map must not be modified
+	Rates() Rates
+}
+
+// StaticRateSource serves the default rates overridden by the rates in a config file
+type StaticRateSource struct {
+	rates Rates
+}
+
+// NewStaticRateSource loads the rates from a JSON file, e.g. {"CNY": 7.1, "EUR": 0.9}, which are the amount of
+// each currency equal to 1 USD. The default rates are used if file is empty.
+func NewStaticRateSource(file string) (*StaticRateSource, error) {
+	rates := Rates{}
+	for k, v := range DefaultRates {
+		rates[k] = v
+	}
+	if file == "" {
+		return &StaticRateSource{rates: rates}, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	fileRates, err := parseRates(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rates file %s: %w", file, err)
+	}
+	for k, v := range fileRates {
+		rates[k] = v
+	}
+	return &StaticRateSource{rates: rates}, nil
+}
+
+func (s *StaticRateSource) Rates() Rates {
+	return s.rates
+}
+
+// FetchedRateSource fetches the rate table periodically, the rates of fallback are used for the currencies
+// which are not fetched.
+type FetchedRateSource struct {
+	url        string
+	interval   time.Duration
+	fallback   RateSource
+	httpClient *http.Client
+
+	mutex sync.RWMutex
+	rates Rates
+}
+
+func NewFetchedRateSource(url string, interval time.Duration, fallback RateSource) *FetchedRateSource {
+	return &FetchedRateSource{
+		url:        url,
+		interval:   interval,
+		fallback:   fallback,
+		httpClient: &http.Client{Timeout: time.Minute},
+	}
+}
+
+func (f *FetchedRateSource) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := f.Refresh(); err != nil {
+			klog.Errorf("Failed to refresh exchange rates: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (f *FetchedRateSource) Refresh() error {
+	resp, err := f.httpClient.Get(f.url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(data))
+	}
+	fetched, err := parseRates(data)
+	if err != nil {
+		return err
+	}
+
+	rates := Rates{}
+	for k, v := range f.fallback.Rates() {
+		rates[k] = v
+	}
+	for k, v := range fetched {
+		rates[k] = v
+	}
+
+	f.mutex.Lock()
+	f.rates = rates
+	f.mutex.Unlock()
+	klog.Infof("Exchange rates are refreshed, %d currencies", len(fetched))
+	return nil
+}
+
+func (f *FetchedRateSource) Rates() Rates {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if f.rates == nil {
+		return f.fallback.Rates()
+	}
+	return f.rates
+}
+
+// parseRates accepts a flat table or a table in the rates field, which is the format of most exchange rate APIs.
+// The rates are rebased to USD if the table has another base.
+func parseRates(data []byte) (Rates, error) {
+	var wrapped struct {
+		Rates Rates `json:"rates"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err == nil && len(wrapped.Rates) != 0 {
+		return rebase(wrapped.Rates)
+	}
+
+	rates := Rates{}
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, err
+	}
+	return rebase(rates)
+}
+
+func rebase(rates Rates) (Rates, error) {
+	for k, v := range rates {
+		if v <= 0 {
+			return nil, fmt.Errorf("invalid rate of %s: %v", k, v)
+		}
+	}
+
+	usd, ok := rates[USD]
+	if !ok || usd == 1 {
+		rates[USD] = 1
+		return rates, nil
+	}
+	ret := Rates{}
+	for k, v := range rates {
+		ret[k] = v / usd
+	}
+	return ret, nil
+}
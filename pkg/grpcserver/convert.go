@@ -0,0 +1,157 @@
+package grpcserver
+
+import (
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/apis/pricepb"
+)
+
+func toRegionalInstancePrice(data *apis.RegionalInstancePrice) *pricepb.RegionalInstancePrice {
+	return &pricepb.RegionalInstancePrice{
+		InstanceTypePrices: lo.MapValues(data.InstanceTypePrices, func(p *apis.InstanceTypePrice, _ string) *pricepb.InstanceTypePrice {
+			return toInstanceTypePrice(p)
+		}),
+	}
+}
+
+func toInstanceTypePrice(p *apis.InstanceTypePrice) *pricepb.InstanceTypePrice {
+	if p == nil {
+		return nil
+	}
+	return &pricepb.InstanceTypePrice{
+		Arch:                 p.Arch,
+		Vcpu:                 p.VCPU,
+		Memory:               p.Memory,
+		Gpu:                  p.GPU,
+		Zones:                p.Zones,
+		OnDemandPricePerHour: p.OnDemandPricePerHour,
+		ZoneIds:              p.ZoneIDs,
+		ZoneAvailability: lo.MapValues(p.ZoneAvailability, func(a apis.ZoneAvailability, _ string) *pricepb.ZoneAvailability {
+			return &pricepb.ZoneAvailability{Available: a.Available, StockStatus: a.StockStatus}
+		}),
+		Spec:                  toInstanceTypeSpec(p.Spec),
+		Currency:              p.Currency,
+		AwsEc2Billing:         toAWSEC2Billing(p.AWSEC2Billing),
+		AwsEc2ReservedBilling: toAWSEC2ReservedBilling(p.AWSEC2ReservedBilling),
+		GcpCommittedUseBilling: lo.MapValues(p.GCPCommittedUseBilling, func(b apis.GCPCommittedUseBilling, _ string) *pricepb.GCPCommittedUseBilling {
+			return &pricepb.GCPCommittedUseBilling{Rate: b.Rate}
+		}),
+		AzureBilling: lo.MapValues(p.AzureBilling, func(b apis.AzureBilling, _ string) *pricepb.AzureBilling {
+			return &pricepb.AzureBilling{Rate: b.Rate}
+		}),
+		AlibabaCloudBilling: lo.MapValues(p.AlibabaCloudBilling, func(b apis.AlibabaCloudBilling, _ string) *pricepb.AlibabaCloudBilling {
+			return &pricepb.AlibabaCloudBilling{Rate: b.Rate, UpfrontFee: b.UpfrontFee}
+		}),
+		SpotPricePerHour: p.SpotPricePerHour,
+		SpotInterruption: toSpotInterruption(p.SpotInterruption),
+		SpotPlacementScores: lo.MapValues(p.SpotPlacementScores, func(scores map[string]int, _ string) *pricepb.SpotPlacementScores {
+			return &pricepb.SpotPlacementScores{
+				Zones: lo.MapValues(scores, func(score int, _ string) int32 { return int32(score) }),
+			}
+		}),
+		Platforms: lo.MapValues(omitNil(p.Platforms), func(pp *apis.PlatformPrice, _ string) *pricepb.PlatformPrice {
+			return toPlatformPrice(pp)
+		}),
+		SitePrices: lo.MapValues(omitNil(p.SitePrices), func(sp *apis.SitePrice, _ string) *pricepb.SitePrice {
+			return &pricepb.SitePrice{OnDemandPricePerHour: sp.OnDemandPricePerHour, Currency: sp.Currency}
+		}),
+	}
+}
+
+// omitNil omits the nil values, since the message values of the protobuf maps must not be nil
+func omitNil[V any](m map[string]*V) map[string]*V {
+	return lo.OmitBy(m, func(_ string, v *V) bool { return v == nil })
+}
+
+func toInstanceTypeSpec(s *apis.InstanceTypeSpec) *pricepb.InstanceTypeSpec {
+	if s == nil {
+		return nil
+	}
+	return &pricepb.InstanceTypeSpec{
+		NetworkPerformance:        s.NetworkPerformance,
+		NetworkBandwidthGbps:      s.NetworkBandwidthGbps,
+		MaxNetworkInterfaces:      int32(s.MaxNetworkInterfaces),
+		Ipv4AddressesPerInterface: int32(s.IPv4AddressesPerInterface),
+		LocalStorage:              s.LocalStorage,
+		LocalStorageType:          s.LocalStorageType,
+		LocalStorageNvme:          s.LocalStorageNVMe,
+		DiskBandwidthMbps:         s.DiskBandwidthMbps,
+		DiskMaxBandwidthMbps:      s.DiskMaxBandwidthMbps,
+		Burstable:                 s.Burstable,
+		Hypervisor:                s.Hypervisor,
+		CurrentGeneration:         s.CurrentGeneration,
+		GpuModel:                  s.GPUModel,
+		GpuManufacturer:           s.GPUManufacturer,
+		GpuMemory:                 s.GPUMemory,
+		Accelerators: lo.Map(s.Accelerators, func(a apis.Accelerator, _ int) *pricepb.Accelerator {
+			return &pricepb.Accelerator{
+				Type:         a.Type,
+				Name:         a.Name,
+				Manufacturer: a.Manufacturer,
+				Count:        int32(a.Count),
+				Memory:       a.Memory,
+			}
+		}),
+	}
+}
+
+func toPlatformPrice(p *apis.PlatformPrice) *pricepb.PlatformPrice {
+	if p == nil {
+		return nil
+	}
+	return &pricepb.PlatformPrice{
+		OnDemandPricePerHour:  p.OnDemandPricePerHour,
+		AwsEc2Billing:         toAWSEC2Billing(p.AWSEC2Billing),
+		AwsEc2ReservedBilling: toAWSEC2ReservedBilling(p.AWSEC2ReservedBilling),
+		SpotPricePerHour:      p.SpotPricePerHour,
+		SpotInterruption:      toSpotInterruption(p.SpotInterruption),
+	}
+}
+
+func toAWSEC2Billing(billing map[string]apis.AWSEC2Billing) map[string]*pricepb.AWSEC2Billing {
+	return lo.MapValues(billing, func(b apis.AWSEC2Billing, _ string) *pricepb.AWSEC2Billing {
+		return &pricepb.AWSEC2Billing{Rate: b.Rate}
+	})
+}
+
+func toAWSEC2ReservedBilling(billing map[string]apis.AWSEC2ReservedBilling) map[string]*pricepb.AWSEC2ReservedBilling {
+	return lo.MapValues(billing, func(b apis.AWSEC2ReservedBilling, _ string) *pricepb.AWSEC2ReservedBilling {
+		return &pricepb.AWSEC2ReservedBilling{Rate: b.Rate, UpfrontFee: b.UpfrontFee, HourlyRate: b.HourlyRate}
+	})
+}
+
+func toSpotInterruption(s *apis.SpotInterruption) *pricepb.SpotInterruption {
+	if s == nil {
+		return nil
+	}
+	return &pricepb.SpotInterruption{Bucket: int32(s.Bucket), Range: s.Range, Savings: int32(s.Savings)}
+}
+
+func toInstanceSearchItem(item *apis.InstanceSearchItem) *pricepb.InstanceSearchItem {
+	return &pricepb.InstanceSearchItem{
+		Provider:          item.Provider,
+		Region:            item.Region,
+		InstanceType:      item.InstanceType,
+		Price:             item.Price,
+		PricePerVcpu:      item.PricePerVCPU,
+		PricePerGib:       item.PricePerGiB,
+		InstanceTypePrice: toInstanceTypePrice(item.InstanceTypePrice),
+	}
+}
+
+func toPriceChangeEvent(e *apis.PriceChangeEvent) *pricepb.PriceChangeEvent {
+	return &pricepb.PriceChangeEvent{
+		Id:           e.ID,
+		Provider:     e.Provider,
+		Region:       e.Region,
+		InstanceType: e.InstanceType,
+		Zone:         e.Zone,
+		CapacityType: e.CapacityType,
+		OldPrice:     e.OldPrice,
+		NewPrice:     e.NewPrice,
+		Currency:     e.Currency,
+		Time:         timestamppb.New(e.Time),
+	}
+}
@@ -0,0 +1,112 @@
+package grpcserver
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/apis/pricepb"
+)
+
+func TestToInstanceTypePrice(t *testing.T) {
+	currentGeneration := true
+	tests := []struct {
+		name  string
+		price *apis.InstanceTypePrice
+		want  *pricepb.InstanceTypePrice
+	}{
+		{name: "nil"},
+		{
+			name: "all prices",
+			price: &apis.InstanceTypePrice{
+				Arch:                 "amd64",
+				VCPU:                 2,
+				Memory:               8,
+				Zones:                []string{"us-east-1a"},
+				OnDemandPricePerHour: 0.096,
+				ZoneIDs:              map[string]string{"us-east-1a": "use1-az4"},
+				ZoneAvailability:     map[string]apis.ZoneAvailability{"us-east-1a": {Available: true, StockStatus: "WithStock"}},
+				Spec: &apis.InstanceTypeSpec{
+					MaxNetworkInterfaces: 3,
+					CurrentGeneration:    &currentGeneration,
+					Accelerators:         []apis.Accelerator{{Type: apis.AcceleratorInferentia, Name: "Inferentia", Count: 1, Memory: 8}},
+				},
+				Currency:               "USD",
+				AWSEC2Billing:          map[string]apis.AWSEC2Billing{"ComputeSavingsPlans/1yr/no": {Rate: 0.07}},
+				AWSEC2ReservedBilling:  map[string]apis.AWSEC2ReservedBilling{"standard/1yr/partial": {Rate: 0.06, UpfrontFee: 263, HourlyRate: 0.03}},
+				GCPCommittedUseBilling: map[string]apis.GCPCommittedUseBilling{"1yr": {Rate: 0.06}},
+				AzureBilling:           map[string]apis.AzureBilling{"reservation/1yr": {Rate: 0.05}},
+				AlibabaCloudBilling:    map[string]apis.AlibabaCloudBilling{"subscription/1yr": {Rate: 0.04, UpfrontFee: 350}},
+				SpotPricePerHour:       map[string]float64{"us-east-1a": 0.04},
+				SpotInterruption:       &apis.SpotInterruption{Bucket: 1, Range: "5-10%", Savings: 60},
+				SpotPlacementScores:    map[string]map[string]int{"10": {"us-east-1a": 9}},
+				Platforms: map[string]*apis.PlatformPrice{
+					"windows/shared": {OnDemandPricePerHour: 0.188, SpotPricePerHour: map[string]float64{"us-east-1a": 0.09}},
+				},
+				SitePrices: map[string]*apis.SitePrice{apis.SiteIntl: {OnDemandPricePerHour: 0.1, Currency: "USD"}},
+			},
+			want: &pricepb.InstanceTypePrice{
+				Arch:                 "amd64",
+				Vcpu:                 2,
+				Memory:               8,
+				Zones:                []string{"us-east-1a"},
+				OnDemandPricePerHour: 0.096,
+				ZoneIds:              map[string]string{"us-east-1a": "use1-az4"},
+				ZoneAvailability:     map[string]*pricepb.ZoneAvailability{"us-east-1a": {Available: true, StockStatus: "WithStock"}},
+				Spec: &pricepb.InstanceTypeSpec{
+					MaxNetworkInterfaces: 3,
+					CurrentGeneration:    &currentGeneration,
+					Accelerators:         []*pricepb.Accelerator{{Type: apis.AcceleratorInferentia, Name: "Inferentia", Count: 1, Memory: 8}},
+				},
+				Currency:               "USD",
+				AwsEc2Billing:          map[string]*pricepb.AWSEC2Billing{"ComputeSavingsPlans/1yr/no": {Rate: 0.07}},
+				AwsEc2ReservedBilling:  map[string]*pricepb.AWSEC2ReservedBilling{"standard/1yr/partial": {Rate: 0.06, UpfrontFee: 263, HourlyRate: 0.03}},
+				GcpCommittedUseBilling: map[string]*pricepb.GCPCommittedUseBilling{"1yr": {Rate: 0.06}},
+				AzureBilling:           map[string]*pricepb.AzureBilling{"reservation/1yr": {Rate: 0.05}},
+				AlibabaCloudBilling:    map[string]*pricepb.AlibabaCloudBilling{"subscription/1yr": {Rate: 0.04, UpfrontFee: 350}},
+				SpotPricePerHour:       map[string]float64{"us-east-1a": 0.04},
+				SpotInterruption:       &pricepb.SpotInterruption{Bucket: 1, Range: "5-10%", Savings: 60},
+				SpotPlacementScores:    map[string]*pricepb.SpotPlacementScores{"10": {Zones: map[string]int32{"us-east-1a": 9}}},
+				Platforms: map[string]*pricepb.PlatformPrice{
+					"windows/shared": {OnDemandPricePerHour: 0.188, SpotPricePerHour: map[string]float64{"us-east-1a": 0.09}},
+				},
+				SitePrices: map[string]*pricepb.SitePrice{apis.SiteIntl: {OnDemandPricePerHour: 0.1, Currency: "USD"}},
+			},
+		},
+		{
+			// The nil message values are invalid in the protobuf maps, so they're omitted
+			name: "nil platform and site prices",
+			price: &apis.InstanceTypePrice{
+				Arch:                 "amd64",
+				OnDemandPricePerHour: 0.096,
+				Platforms: map[string]*apis.PlatformPrice{
+					"windows/shared": {OnDemandPricePerHour: 0.188},
+					"rhel/shared":    nil,
+				},
+				SitePrices: map[string]*apis.SitePrice{apis.SiteIntl: nil},
+			},
+			want: &pricepb.InstanceTypePrice{
+				Arch:                 "amd64",
+				OnDemandPricePerHour: 0.096,
+				Platforms:            map[string]*pricepb.PlatformPrice{"windows/shared": {OnDemandPricePerHour: 0.188}},
+			},
+		},
+		{
+			name:  "only on-demand price",
+			price: &apis.InstanceTypePrice{Arch: "arm64", VCPU: 2, Memory: 8, OnDemandPricePerHour: 0.077},
+			want:  &pricepb.InstanceTypePrice{Arch: "arm64", Vcpu: 2, Memory: 8, OnDemandPricePerHour: 0.077},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := toInstanceTypePrice(tt.price)
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if _, err := proto.Marshal(got); err != nil {
+				t.Errorf("failed to marshal %v: %v", got, err)
+			}
+		})
+	}
+}
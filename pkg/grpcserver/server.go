@@ -0,0 +1,219 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/apis/pricepb"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+	"github.com/cloudpilot-ai/priceserver/pkg/query"
+)
+
+// keepaliveInterval keeps the idle watch streams alive through the proxies
+const keepaliveInterval = 30 * time.Second
+
+// priceService serves the price data of the registry like the REST handlers
+type priceService struct {
+	pricepb.UnimplementedPriceServiceServer
+
+	registry   *client.Registry
+	rateSource currency.RateSource
+}
+
+func NewPriceServer(registry *client.Registry, rateSource currency.RateSource) *grpc.Server {
+	server := grpc.NewServer(grpc.KeepaliveParams(keepalive.ServerParameters{Time: keepaliveInterval}))
+	pricepb.RegisterPriceServiceServer(server, &priceService{
+		registry:   registry,
+		rateSource: rateSource,
+	})
+	return server
+}
+
+func (s *priceService) ListRegions(_ context.Context, req *pricepb.ListRegionsRequest) (*pricepb.ListRegionsResponse, error) {
+	provider, err := s.getPriceProvider(req.Provider)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(4).Infof("Start to list %s regions...", provider.Name())
+
+	return &pricepb.ListRegionsResponse{Regions: provider.ListRegions()}, nil
+}
+
+func (s *priceService) ListPrices(_ context.Context, req *pricepb.ListPricesRequest) (*pricepb.ListPricesResponse, error) {
+	provider, err := s.getPriceProvider(req.Provider)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(4).Infof("Start to list %s price...", provider.Name())
+
+	var data map[string]*apis.RegionalInstancePrice
+	if len(req.Regions) == 0 {
+		data = provider.ListRegionsInstancesPrice()
+	} else {
+		data = map[string]*apis.RegionalInstancePrice{}
+		for _, region := range req.Regions {
+			if d := provider.ListInstancesPrice(region); d != nil {
+				regionData := (*d)[region]
+				data[region] = &regionData
+			}
+		}
+	}
+	if data, err = s.selectRegionsPrice(provider.Name(), data, req.Selector); err != nil {
+		return nil, err
+	}
+
+	resp := &pricepb.ListPricesResponse{Regions: map[string]*pricepb.RegionalInstancePrice{}}
+	for region, d := range data {
+		resp.Regions[region] = toRegionalInstancePrice(d)
+	}
+	return resp, nil
+}
+
+func (s *priceService) GetInstancePrice(_ context.Context, req *pricepb.GetInstancePriceRequest) (*pricepb.GetInstancePriceResponse, error) {
+	provider, err := s.getPriceProvider(req.Provider)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(4).Infof("Start to get %s instance price...", provider.Name())
+
+	price := provider.GetInstancePrice(req.Region, req.InstanceType)
+	if price != nil {
+		regionData := &apis.RegionalInstancePrice{
+			InstanceTypePrices: map[string]*apis.InstanceTypePrice{req.InstanceType: price},
+		}
+		data, err := s.selectRegionsPrice(provider.Name(), map[string]*apis.RegionalInstancePrice{req.Region: regionData}, req.Selector)
+		if err != nil {
+			return nil, err
+		}
+		// The instance type is removed if it has no price of the platform or the site
+		price = data[req.Region].InstanceTypePrices[req.InstanceType]
+	}
+	if price == nil {
+		return nil, status.Errorf(codes.NotFound, "no price of %s in %s", req.InstanceType, req.Region)
+	}
+	return &pricepb.GetInstancePriceResponse{Price: toInstanceTypePrice(price)}, nil
+}
+
+func (s *priceService) SearchInstances(_ context.Context, req *pricepb.SearchInstancesRequest) (*pricepb.SearchInstancesResponse, error) {
+	klog.V(4).Infof("Start to search instances of %v...", req.Providers)
+
+	providers := s.registry.List()
+	if len(req.Providers) != 0 {
+		providers = nil
+		for _, name := range req.Providers {
+			provider, err := s.getPriceProvider(name)
+			if err != nil {
+				return nil, err
+			}
+			providers = append(providers, provider)
+		}
+	}
+	opts := &query.SearchOptions{
+		Regions:      req.Regions,
+		Arch:         req.Arch,
+		VCPU:         query.Range{Min: req.MinVcpu, Max: req.MaxVcpu},
+		Memory:       query.Range{Min: req.MinMemory, Max: req.MaxMemory},
+		GPU:          query.Range{Min: req.MinGpu, Max: req.MaxGpu},
+		Zone:         req.Zone,
+		CapacityType: req.CapacityType,
+		MaxPrice:     req.MaxPrice,
+		Rates:        s.rateSource.Rates(),
+		SortBy:       req.SortBy,
+		Desc:         req.Desc,
+		Offset:       int(req.Offset),
+		Limit:        int(req.Limit),
+	}
+	if selector := req.Selector; selector != nil {
+		opts.OS, opts.Tenancy, opts.Site, opts.Currency = selector.Os, selector.Tenancy, selector.Site, selector.Currency
+	}
+
+	result, err := query.Search(providers, opts)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp := &pricepb.SearchInstancesResponse{
+		Total:    int32(result.Total),
+		Currency: result.Currency,
+	}
+	for _, item := range result.Items {
+		resp.Items = append(resp.Items, toInstanceSearchItem(item))
+	}
+	return resp, nil
+}
+
+func (s *priceService) WatchPrices(req *pricepb.WatchPricesRequest, stream pricepb.PriceService_WatchPricesServer) error {
+	provider, err := s.getPriceProvider(req.Provider)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("Start to watch %s price events...", provider.Name())
+
+	watcher, ok := provider.(client.PriceWatcher)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "price events are not supported by %s", provider.Name())
+	}
+	filter := &apis.PriceEventFilter{
+		Regions:       req.Regions,
+		InstanceTypes: req.InstanceTypes,
+		CapacityTypes: req.CapacityTypes,
+	}
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	watch, err := watcher.WatchPrices(ctx, req.LastEventId, filter)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if watch.Missed {
+		if err := stream.Send(&pricepb.WatchPricesResponse{Missed: true}); err != nil {
+			return err
+		}
+	}
+	for _, event := range watch.Events {
+		if err := stream.Send(&pricepb.WatchPricesResponse{Event: toPriceChangeEvent(event)}); err != nil {
+			return err
+		}
+	}
+	for event := range watch.Changes {
+		if err := stream.Send(&pricepb.WatchPricesResponse{Event: toPriceChangeEvent(event)}); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	// The watch is closed since the client falls behind
+	return status.Error(codes.Unavailable, "resume after the last event")
+}
+
+func (s *priceService) getPriceProvider(name string) (client.PriceProvider, error) {
+	provider, ok := s.registry.Get(name)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown price provider %s", name)
+	}
+	return provider, nil
+}
+
+// selectRegionsPrice returns the prices of the selector, data is returned if it's not set
+func (s *priceService) selectRegionsPrice(provider string, data map[string]*apis.RegionalInstancePrice,
+	selector *pricepb.PriceSelector) (map[string]*apis.RegionalInstancePrice, error) {
+	if selector == nil {
+		return data, nil
+	}
+	data, err := query.SelectRegionsPrice(data, selector.Os, selector.Tenancy, selector.Site)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if data, err = query.ConvertRegionsPrice(data, provider, s.rateSource.Rates(), selector.Currency); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return data, nil
+}
@@ -0,0 +1,230 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/apis/pricepb"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+)
+
+// testRateSource serves the fixed exchange rates
+type testRateSource struct{}
+
+func (testRateSource) Rates() currency.Rates {
+	return currency.Rates{currency.USD: 1, currency.CNY: 8}
+}
+
+// testProvider only has a name, the other methods of PriceProvider aren't used by the watches
+type testProvider struct {
+	client.PriceProvider
+	name string
+}
+
+func (p *testProvider) Name() string { return p.name }
+
+// testWatchProvider returns the kept events after the last event ID and sends the following events of changes
+type testWatchProvider struct {
+	testProvider
+	events  []*apis.PriceChangeEvent
+	changes chan *apis.PriceChangeEvent
+
+	lastEventID string
+	filter      *apis.PriceEventFilter
+	// done is closed when the context of the watch is done
+	done chan struct{}
+}
+
+func (p *testWatchProvider) WatchPrices(ctx context.Context, lastEventID string, filter *apis.PriceEventFilter) (*client.PriceWatch, error) {
+	p.lastEventID, p.filter = lastEventID, filter
+	watch := &client.PriceWatch{Changes: p.changes}
+	for i, event := range p.events {
+		if event.ID == lastEventID {
+			watch.Events = p.events[i+1:]
+			break
+		}
+	}
+	// The last event is not kept any more
+	if lastEventID != "" && watch.Events == nil {
+		watch.Missed, watch.Events = true, p.events
+	}
+	go func() {
+		<-ctx.Done()
+		close(p.done)
+	}()
+	return watch, nil
+}
+
+func newTestWatchProvider() *testWatchProvider {
+	return &testWatchProvider{
+		testProvider: testProvider{name: apis.AWSCloudProvider},
+		events: []*apis.PriceChangeEvent{
+			{ID: "1-0", Provider: apis.AWSCloudProvider, Region: "us-east-1", InstanceType: "m5.large",
+				CapacityType: apis.CapacityTypeOnDemand, OldPrice: 0.1, NewPrice: 0.096, Currency: "USD"},
+			{ID: "2-0", Provider: apis.AWSCloudProvider, Region: "us-east-1", InstanceType: "m5.large", Zone: "us-east-1a",
+				CapacityType: apis.CapacityTypeSpot, OldPrice: 0.04, NewPrice: 0.03, Currency: "USD"},
+		},
+		changes: make(chan *apis.PriceChangeEvent, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+// newTestClient serves the providers over an in-memory connection
+func newTestClient(t *testing.T, providers ...client.PriceProvider) pricepb.PriceServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := NewPriceServer(client.NewRegistry(providers...), testRateSource{})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return pricepb.NewPriceServiceClient(conn)
+}
+
+// recvEventIDs receives n responses and returns the event IDs, the response of the missed events is "missed"
+func recvEventIDs(t *testing.T, stream pricepb.PriceService_WatchPricesClient, n int) []string {
+	var ret []string
+	for i := 0; i < n; i++ {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Missed {
+			ret = append(ret, "missed")
+			continue
+		}
+		ret = append(ret, resp.Event.Id)
+	}
+	return ret
+}
+
+func TestWatchPrices(t *testing.T) {
+	tests := []struct {
+		name        string
+		lastEventID string
+		// want are the IDs of the kept events
+		want []string
+	}{
+		{name: "new events"},
+		{name: "resume", lastEventID: "1-0", want: []string{"2-0"}},
+		{name: "resume from the last event", lastEventID: "2-0"},
+		{name: "missed events", lastEventID: "0-0", want: []string{"missed", "1-0", "2-0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestWatchProvider()
+			c := newTestClient(t, provider)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			stream, err := c.WatchPrices(ctx, &pricepb.WatchPricesRequest{
+				Provider:      apis.AWSCloudProvider,
+				LastEventId:   tt.lastEventID,
+				Regions:       []string{"us-east-1"},
+				CapacityTypes: []string{apis.CapacityTypeSpot},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			provider.changes <- &apis.PriceChangeEvent{ID: "3-0", Provider: apis.AWSCloudProvider, Time: time.Now()}
+
+			got := recvEventIDs(t, stream, len(tt.want)+1)
+			if want := append(tt.want, "3-0"); !reflect.DeepEqual(got, want) {
+				t.Errorf("got events %v, want %v", got, want)
+			}
+			if provider.lastEventID != tt.lastEventID {
+				t.Errorf("got last event ID %q, want %q", provider.lastEventID, tt.lastEventID)
+			}
+			wantFilter := &apis.PriceEventFilter{Regions: []string{"us-east-1"}, CapacityTypes: []string{apis.CapacityTypeSpot}}
+			if !reflect.DeepEqual(provider.filter, wantFilter) {
+				t.Errorf("got filter %+v, want %+v", provider.filter, wantFilter)
+			}
+		})
+	}
+}
+
+func TestWatchPricesClose(t *testing.T) {
+	t.Run("watcher falls behind", func(t *testing.T) {
+		provider := newTestWatchProvider()
+		c := newTestClient(t, provider)
+
+		stream, err := c.WatchPrices(context.Background(), &pricepb.WatchPricesRequest{Provider: apis.AWSCloudProvider})
+		if err != nil {
+			t.Fatal(err)
+		}
+		close(provider.changes)
+		// The client resumes after the last event when the stream is unavailable
+		if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+			t.Errorf("got error %v, want unavailable", err)
+		}
+		select {
+		case <-provider.done:
+		case <-time.After(10 * time.Second):
+			t.Errorf("got the watch running after the stream is closed")
+		}
+	})
+
+	t.Run("client cancels", func(t *testing.T) {
+		provider := newTestWatchProvider()
+		c := newTestClient(t, provider)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := c.WatchPrices(ctx, &pricepb.WatchPricesRequest{Provider: apis.AWSCloudProvider})
+		if err != nil {
+			t.Fatal(err)
+		}
+		provider.changes <- &apis.PriceChangeEvent{ID: "3-0", Time: time.Now()}
+		recvEventIDs(t, stream, 1)
+		cancel()
+		select {
+		case <-provider.done:
+		case <-time.After(10 * time.Second):
+			t.Errorf("got the watch running after the client cancels")
+		}
+	})
+}
+
+func TestWatchPricesUnsupported(t *testing.T) {
+	c := newTestClient(t, &testProvider{name: apis.GCPCloudProvider})
+	tests := []struct {
+		provider string
+		want     codes.Code
+	}{
+		{provider: apis.GCPCloudProvider, want: codes.Unimplemented},
+		{provider: "unknown", want: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			stream, err := c.WatchPrices(context.Background(), &pricepb.WatchPricesRequest{Provider: tt.provider})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := stream.Recv(); status.Code(err) != tt.want {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
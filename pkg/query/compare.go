@@ -0,0 +1,194 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/samber/lo"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+)
+
+const (
+	// ShapeMatchExact matches the instance types with the same specs
+	ShapeMatchExact = "exact"
+	// ShapeMatchAtLeast matches the instance types with at least the specs
+	ShapeMatchAtLeast = "atLeast"
+
+	DefaultCompareLimit = 20
+	MaxCompareLimit     = 500
+)
+
+type CompareOptions struct {
+	// Regions limits the regions, empty means all
+	Regions []string
+	Arch    string
+	VCPU    float64
+	Memory  float64
+	GPU     float64
+	// Match is exact or atLeast, default to exact
+	Match string
+	// CapacityTypes are on-demand, spot or billing keys, default to on-demand and spot
+	CapacityTypes []string
+	// OS and Tenancy select the prices of the platform, default to linux with shared tenancy
+	OS      string
+	Tenancy string
+	// Site selects the prices of the site, cn or intl, default to cn
+	Site string
+	// Currency is the currency of the normalized prices, default to USD
+	Currency string
+	Rates    currency.Rates
+	// Limit is the max number of items of each capacity type
+	Limit int
+}
+
+func (o *CompareOptions) Validate() error {
+	if o.VCPU <= 0 && o.Memory <= 0 {
+		return fmt.Errorf("vcpu or memory should be set")
+	}
+	if o.VCPU < 0 || o.Memory < 0 || o.GPU < 0 {
+		return fmt.Errorf("resources should not be negative")
+	}
+	if o.Match == "" {
+		o.Match = ShapeMatchExact
+	}
+	if o.Match != ShapeMatchExact && o.Match != ShapeMatchAtLeast {
+		return fmt.Errorf("unsupported match %s", o.Match)
+	}
+	if len(o.CapacityTypes) == 0 {
+		o.CapacityTypes = []string{apis.CapacityTypeOnDemand, apis.CapacityTypeSpot}
+	}
+	if err := ValidatePlatform(&o.OS, &o.Tenancy); err != nil {
+		return err
+	}
+	if err := ValidateSite(o.Site); err != nil {
+		return err
+	}
+	if err := validateCurrency(&o.Currency, &o.Rates); err != nil {
+		return err
+	}
+	if o.Limit == 0 {
+		o.Limit = DefaultCompareLimit
+	}
+	if o.Limit < 0 || o.Limit > MaxCompareLimit {
+		return fmt.Errorf("limit should be in [1, %d]", MaxCompareLimit)
+	}
+	return nil
+}
+
+type compareKey struct {
+	capacityType string
+	zone         string
+}
+
+func (o *CompareOptions) matchShape(price *apis.InstanceTypePrice) bool {
+	if o.Arch != "" && price.Arch != o.Arch {
+		return false
+	}
+	if o.Match == ShapeMatchAtLeast {
+		return price.VCPU >= o.VCPU && price.Memory >= o.Memory && price.GPU >= o.GPU
+	}
+	return (o.VCPU <= 0 || price.VCPU == o.VCPU) && (o.Memory <= 0 || price.Memory == o.Memory) && price.GPU == o.GPU
+}
+
+// Compare returns the cheapest regions of the providers for the instance shape, the prices are converted
+// to the same currency.
+func Compare(providers []client.PriceProvider, opts *CompareOptions) (*apis.PriceComparison, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	ret := &apis.PriceComparison{
+		Currency:      opts.Currency,
+		CapacityTypes: map[string][]*apis.PriceComparisonItem{},
+	}
+	for _, capacityType := range opts.CapacityTypes {
+		ret.CapacityTypes[capacityType] = []*apis.PriceComparisonItem{}
+	}
+
+	for _, provider := range providers {
+		for region, d := range provider.ListRegionsInstancesPrice() {
+			if len(opts.Regions) != 0 && !lo.Contains(opts.Regions, region) {
+				continue
+			}
+			// The cheapest item of each capacity type, and each zone for spot
+			cheapest := map[compareKey]*apis.PriceComparisonItem{}
+			add := func(key compareKey, instanceType string, price *apis.InstanceTypePrice, p float64) error {
+				originalCurrency := price.Currency
+				if originalCurrency == "" {
+					originalCurrency = client.PriceCurrency(provider.Name(), region)
+				}
+				normalized, err := opts.Rates.Convert(p, originalCurrency, opts.Currency)
+				if err != nil {
+					return err
+				}
+				if c, ok := cheapest[key]; ok && (c.Price < normalized || (c.Price == normalized && c.InstanceType < instanceType)) {
+					return nil
+				}
+				cheapest[key] = &apis.PriceComparisonItem{
+					Provider:         provider.Name(),
+					Region:           region,
+					Zone:             key.zone,
+					InstanceType:     instanceType,
+					Arch:             price.Arch,
+					VCPU:             price.VCPU,
+					Memory:           price.Memory,
+					GPU:              price.GPU,
+					Price:            normalized,
+					OriginalPrice:    p,
+					OriginalCurrency: originalCurrency,
+				}
+				return nil
+			}
+
+			// The prices are shared with the provider, only the compared prices are converted
+			for instanceType, price := range d.InstanceTypePrices {
+				price, ok := selectPrice(price, opts.OS, opts.Tenancy, opts.Site)
+				if !ok || !opts.matchShape(price) {
+					continue
+				}
+				for _, capacityType := range opts.CapacityTypes {
+					if capacityType == apis.CapacityTypeSpot {
+						for zone, p := range price.SpotPricePerHour {
+							if p <= 0 {
+								continue
+							}
+							if err := add(compareKey{capacityType, zone}, instanceType, price, p); err != nil {
+								return nil, err
+							}
+						}
+						continue
+					}
+					if p, ok := CapacityPrice(price, capacityType, ""); ok {
+						if err := add(compareKey{capacityType: capacityType}, instanceType, price, p); err != nil {
+							return nil, err
+						}
+					}
+				}
+			}
+
+			for key, item := range cheapest {
+				ret.CapacityTypes[key.capacityType] = append(ret.CapacityTypes[key.capacityType], item)
+			}
+		}
+	}
+
+	for capacityType, items := range ret.CapacityTypes {
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].Price != items[j].Price {
+				return items[i].Price < items[j].Price
+			}
+			if items[i].Provider != items[j].Provider {
+				return items[i].Provider < items[j].Provider
+			}
+			if items[i].Region != items[j].Region {
+				return items[i].Region < items[j].Region
+			}
+			return items[i].Zone < items[j].Zone
+		})
+		ret.CapacityTypes[capacityType] = items[:min(opts.Limit, len(items))]
+	}
+	return ret, nil
+}
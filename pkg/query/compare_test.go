@@ -0,0 +1,170 @@
+package query
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+)
+
+func newCompareTestProviders() []client.PriceProvider {
+	return []client.PriceProvider{
+		&testProvider{name: apis.AWSCloudProvider, data: map[string]*apis.RegionalInstancePrice{
+			"us-east-1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+				"m5.large": {Arch: "amd64", VCPU: 2, Memory: 8, OnDemandPricePerHour: 0.096,
+					SpotPricePerHour: map[string]float64{"us-east-1a": 0.04, "us-east-1b": 0.03}},
+				"m5a.large":  {Arch: "amd64", VCPU: 2, Memory: 8, OnDemandPricePerHour: 0.096},
+				"m6g.large":  {Arch: "arm64", VCPU: 2, Memory: 8, OnDemandPricePerHour: 0.077},
+				"m5.xlarge":  {Arch: "amd64", VCPU: 4, Memory: 16, OnDemandPricePerHour: 0.192},
+				"g4dn.large": {Arch: "amd64", VCPU: 2, Memory: 8, GPU: 1, OnDemandPricePerHour: 0.526},
+			}},
+			// The prices of AWS China are in CNY
+			"cn-north-1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+				"m5.large": {Arch: "amd64", VCPU: 2, Memory: 8, OnDemandPricePerHour: 0.8,
+					SpotPricePerHour: map[string]float64{"cn-north-1a": 0.16}},
+			}},
+		}},
+		&testProvider{name: apis.GCPCloudProvider, data: map[string]*apis.RegionalInstancePrice{
+			"us-central1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+				"n2-standard-2": {Arch: "amd64", VCPU: 2, Memory: 8, OnDemandPricePerHour: 0.097,
+					SpotPricePerHour:       map[string]float64{"us-central1-a": 0.035},
+					GCPCommittedUseBilling: map[string]apis.GCPCommittedUseBilling{"1yr": {Rate: 0.06}}},
+				"e2-standard-4": {Arch: "amd64", VCPU: 4, Memory: 16, OnDemandPricePerHour: 0.134},
+			}},
+		}},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	rates := currency.Rates{currency.USD: 1, currency.CNY: 8}
+	tests := []struct {
+		name string
+		opts CompareOptions
+		// want are the items of each capacity type as provider/region/zone/instanceType
+		want       map[string][]string
+		wantPrices map[string][]float64
+		wantErr    bool
+	}{
+		{
+			name: "exact shape",
+			opts: CompareOptions{VCPU: 2, Memory: 8},
+			want: map[string][]string{
+				apis.CapacityTypeOnDemand: {"aws/us-east-1//m6g.large", "gcp/us-central1//n2-standard-2", "aws/cn-north-1//m5.large"},
+				apis.CapacityTypeSpot: {"aws/cn-north-1/cn-north-1a/m5.large", "aws/us-east-1/us-east-1b/m5.large",
+					"gcp/us-central1/us-central1-a/n2-standard-2", "aws/us-east-1/us-east-1a/m5.large"},
+			},
+			wantPrices: map[string][]float64{
+				apis.CapacityTypeOnDemand: {0.077, 0.097, 0.1},
+				apis.CapacityTypeSpot:     {0.02, 0.03, 0.035, 0.04},
+			},
+		},
+		{
+			// The instance type of the smaller name is kept if the prices are equal
+			name: "arch",
+			opts: CompareOptions{VCPU: 2, Arch: "amd64", CapacityTypes: []string{apis.CapacityTypeOnDemand}},
+			want: map[string][]string{
+				apis.CapacityTypeOnDemand: {"aws/us-east-1//m5.large", "gcp/us-central1//n2-standard-2", "aws/cn-north-1//m5.large"},
+			},
+		},
+		{
+			name: "at least",
+			opts: CompareOptions{VCPU: 4, Match: ShapeMatchAtLeast, CapacityTypes: []string{apis.CapacityTypeOnDemand}},
+			want: map[string][]string{
+				apis.CapacityTypeOnDemand: {"gcp/us-central1//e2-standard-4", "aws/us-east-1//m5.xlarge"},
+			},
+		},
+		{
+			name: "gpu",
+			opts: CompareOptions{VCPU: 2, GPU: 1, CapacityTypes: []string{apis.CapacityTypeOnDemand}},
+			want: map[string][]string{apis.CapacityTypeOnDemand: {"aws/us-east-1//g4dn.large"}},
+		},
+		{
+			name: "currency",
+			opts: CompareOptions{VCPU: 2, Arch: "amd64", Currency: currency.CNY, CapacityTypes: []string{apis.CapacityTypeOnDemand}},
+			want: map[string][]string{
+				apis.CapacityTypeOnDemand: {"aws/us-east-1//m5.large", "gcp/us-central1//n2-standard-2", "aws/cn-north-1//m5.large"},
+			},
+			wantPrices: map[string][]float64{apis.CapacityTypeOnDemand: {0.768, 0.776, 0.8}},
+		},
+		{
+			name: "billing and regions",
+			opts: CompareOptions{Memory: 8, Regions: []string{"us-central1"}, CapacityTypes: []string{"1yr", apis.CapacityTypeOnDemand}},
+			want: map[string][]string{
+				"1yr":                     {"gcp/us-central1//n2-standard-2"},
+				apis.CapacityTypeOnDemand: {"gcp/us-central1//n2-standard-2"},
+			},
+		},
+		{
+			name: "limit",
+			opts: CompareOptions{VCPU: 2, Memory: 8, Limit: 1},
+			want: map[string][]string{
+				apis.CapacityTypeOnDemand: {"aws/us-east-1//m6g.large"},
+				apis.CapacityTypeSpot:     {"aws/cn-north-1/cn-north-1a/m5.large"},
+			},
+		},
+		{
+			name: "no match",
+			opts: CompareOptions{VCPU: 64, CapacityTypes: []string{apis.CapacityTypeSpot}},
+			want: map[string][]string{apis.CapacityTypeSpot: nil},
+		},
+		{name: "no shape", opts: CompareOptions{GPU: 1}, wantErr: true},
+		{name: "negative resources", opts: CompareOptions{VCPU: 2, Memory: -1}, wantErr: true},
+		{name: "invalid match", opts: CompareOptions{VCPU: 2, Match: "near"}, wantErr: true},
+		{name: "invalid currency", opts: CompareOptions{VCPU: 2, Currency: currency.EUR}, wantErr: true},
+		{name: "invalid limit", opts: CompareOptions{VCPU: 2, Limit: MaxCompareLimit + 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Rates = rates
+			result, err := Compare(newCompareTestProviders(), &tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if result.Currency != tt.opts.Currency {
+				t.Errorf("got currency %s, want %s", result.Currency, tt.opts.Currency)
+			}
+
+			got := map[string][]string{}
+			for capacityType, items := range result.CapacityTypes {
+				got[capacityType] = nil
+				for i, item := range items {
+					got[capacityType] = append(got[capacityType],
+						item.Provider+"/"+item.Region+"/"+item.Zone+"/"+item.InstanceType)
+					if prices, ok := tt.wantPrices[capacityType]; ok && math.Abs(item.Price-prices[i]) > 1e-9 {
+						t.Errorf("got %s price %v of %s, want %v", capacityType, item.Price, item.InstanceType, prices[i])
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareOriginalPrice(t *testing.T) {
+	opts := &CompareOptions{
+		VCPU:          2,
+		Memory:        8,
+		Regions:       []string{"cn-north-1"},
+		CapacityTypes: []string{apis.CapacityTypeOnDemand},
+		Rates:         currency.Rates{currency.USD: 1, currency.CNY: 8},
+	}
+	result, err := Compare(newCompareTestProviders(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := result.CapacityTypes[apis.CapacityTypeOnDemand]
+	if len(items) != 1 {
+		t.Fatalf("got %d items, want 1", len(items))
+	}
+	if item := items[0]; item.OriginalPrice != 0.8 || item.OriginalCurrency != currency.CNY || math.Abs(item.Price-0.1) > 1e-9 {
+		t.Errorf("got price %v from %v %s, want 0.1 from 0.8 CNY", item.Price, item.OriginalPrice, item.OriginalCurrency)
+	}
+}
@@ -0,0 +1,39 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+var (
+	SupportedOperatingSystems = []string{apis.OSLinux, apis.OSWindows, apis.OSRHEL, apis.OSSUSE}
+	SupportedTenancies        = []string{apis.TenancyShared, apis.TenancyDedicated}
+)
+
+// ValidatePlatform fills the default operating system and tenancy, and checks they are supported
+func ValidatePlatform(os, tenancy *string) error {
+	if *os == "" {
+		*os = apis.OSLinux
+	}
+	if *tenancy == "" {
+		*tenancy = apis.TenancyShared
+	}
+	if !lo.Contains(SupportedOperatingSystems, *os) {
+		return fmt.Errorf("unsupported os %s", *os)
+	}
+	if !lo.Contains(SupportedTenancies, *tenancy) {
+		return fmt.Errorf("unsupported tenancy %s", *tenancy)
+	}
+	return nil
+}
+
+// SelectPlatform returns the prices of the platform, the instance types without the prices of the platform are
+// removed. The prices are shared with data, so they must not be modified.
+func SelectPlatform(data *apis.RegionalInstancePrice, os, tenancy string) *apis.RegionalInstancePrice {
+	return selectRegionalPrice(data, func(price *apis.InstanceTypePrice) (*apis.InstanceTypePrice, bool) {
+		return price.ForPlatform(os, tenancy)
+	})
+}
@@ -0,0 +1,324 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+)
+
+const (
+	DefaultRecommendationLimit = 20
+	MaxRecommendationLimit     = 200
+	MaxRecommendationPods      = 10000
+
+	// mixCandidates is the number of instance types with the lowest price per pod considered for a mix
+	mixCandidates = 50
+)
+
+func validateRecommendationRequest(req *apis.RecommendationRequest, rates *currency.Rates) error {
+	if err := validateCurrency(&req.Currency, rates); err != nil {
+		return err
+	}
+	if err := ValidatePlatform(&req.OS, &req.Tenancy); err != nil {
+		return err
+	}
+	if err := ValidateSite(req.Site); err != nil {
+		return err
+	}
+	if len(req.CapacityTypes) == 0 {
+		req.CapacityTypes = []string{apis.CapacityTypeOnDemand}
+	}
+	if req.Limit == 0 {
+		req.Limit = DefaultRecommendationLimit
+	}
+	if req.Limit < 0 || req.Limit > MaxRecommendationLimit {
+		return fmt.Errorf("limit should be in [1, %d]", MaxRecommendationLimit)
+	}
+	if req.VCPU < 0 || req.Memory < 0 || req.GPU < 0 {
+		return fmt.Errorf("resources should not be negative")
+	}
+
+	if req.Pods == nil {
+		return nil
+	}
+	if req.Pods.Count <= 0 || req.Pods.Count > MaxRecommendationPods {
+		return fmt.Errorf("pods count should be in [1, %d]", MaxRecommendationPods)
+	}
+	if req.Pods.VCPU < 0 || req.Pods.Memory < 0 || req.Pods.GPU < 0 {
+		return fmt.Errorf("pod resources should not be negative")
+	}
+	if req.Pods.VCPU == 0 && req.Pods.Memory == 0 && req.Pods.GPU == 0 {
+		return fmt.Errorf("pod resources should not be all zero")
+	}
+	return nil
+}
+
+// Recommend returns the cheapest instance types of the providers for the request, the prices are converted
+// to the currency of the request with the rates
+func Recommend(providers []client.PriceProvider, req *apis.RecommendationRequest,
+	rates currency.Rates) (*apis.RecommendationResult, error) {
+	if err := validateRecommendationRequest(req, &rates); err != nil {
+		return nil, err
+	}
+
+	var candidates []*apis.InstanceRecommendation
+	for _, provider := range providers {
+		regions := provider.ListRegions()
+		if len(req.Regions) != 0 {
+			regions = lo.Intersect(regions, req.Regions)
+		}
+		for _, region := range regions {
+			data := provider.ListInstancesPrice(region)
+			if data == nil {
+				continue
+			}
+			from := client.PriceCurrency(provider.Name(), region)
+			// The prices are shared with the provider, only the recommended prices are converted
+			for instanceType, price := range (*data)[region].InstanceTypePrices {
+				price, ok := selectPrice(price, req.OS, req.Tenancy, req.Site)
+				if !ok {
+					continue
+				}
+				for _, rec := range recommendInstance(req, instanceType, price) {
+					p, err := rates.ConvertPrice(price, rec.PricePerHour, from, req.Currency)
+					if err != nil {
+						return nil, err
+					}
+					rec.PricePerHour, rec.TotalPricePerHour = p, p*float64(rec.Count)
+					rec.Currency = req.Currency
+					rec.Provider = provider.Name()
+					rec.Region = region
+					candidates = append(candidates, rec)
+				}
+			}
+		}
+	}
+	sortRecommendations(candidates)
+
+	ret := &apis.RecommendationResult{
+		Instances: candidates[:min(req.Limit, len(candidates))],
+	}
+	if req.Pods != nil {
+		ret.Mixes = recommendMixes(candidates, req.Pods.Count, req.Limit)
+	}
+	return ret, nil
+}
+
+// recommendInstance returns a recommendation of each capacity type if the instance type matches the request
+func recommendInstance(req *apis.RecommendationRequest, instanceType string,
+	price *apis.InstanceTypePrice) []*apis.InstanceRecommendation {
+	if req.Arch != "" && price.Arch != req.Arch {
+		return nil
+	}
+	if len(req.Families) != 0 && !lo.ContainsBy(req.Families, func(f string) bool { return matchFamily(instanceType, f) }) {
+		return nil
+	}
+	if price.VCPU < req.VCPU || price.Memory < req.Memory || price.GPU < req.GPU {
+		return nil
+	}
+	if len(req.Zones) != 0 && !lo.ContainsBy(req.Zones, func(z string) bool {
+		_, ok := price.SpotPricePerHour[z]
+		return ok || lo.Contains(price.Zones, z)
+	}) {
+		return nil
+	}
+
+	podsPerInstance, count := 0, 1
+	if req.Pods != nil {
+		podsPerInstance = fitPods(req.Pods, price)
+		if podsPerInstance == 0 {
+			return nil
+		}
+		count = (req.Pods.Count + podsPerInstance - 1) / podsPerInstance
+	}
+
+	var ret []*apis.InstanceRecommendation
+	for _, capacityType := range req.CapacityTypes {
+		p, zone, ok := allowedZonesPrice(price, capacityType, req.Zones)
+		if !ok {
+			continue
+		}
+		ret = append(ret, &apis.InstanceRecommendation{
+			InstanceType:      instanceType,
+			CapacityType:      capacityType,
+			Currency:          price.Currency,
+			Arch:              price.Arch,
+			VCPU:              price.VCPU,
+			Memory:            price.Memory,
+			GPU:               price.GPU,
+			Zone:              zone,
+			PricePerHour:      p,
+			PodsPerInstance:   podsPerInstance,
+			Count:             count,
+			TotalPricePerHour: p * float64(count),
+		})
+	}
+	return ret
+}
+
+// matchFamily returns true if the instance type is the family or starts with the family and a separator
+func matchFamily(instanceType, family string) bool {
+	if !strings.HasPrefix(instanceType, family) {
+		return false
+	}
+	rest := instanceType[len(family):]
+	return rest == "" || strings.ContainsRune(".-_", rune(rest[0]))
+}
+
+// fitPods returns the number of pods fit in an instance, it's at most MaxRecommendationPods since no more pods are
+// placed, so the tiny pod resources don't overflow the number
+func fitPods(pods *apis.PodsRequirement, price *apis.InstanceTypePrice) int {
+	ret := MaxRecommendationPods
+	for _, r := range [][2]float64{{pods.VCPU, price.VCPU}, {pods.Memory, price.Memory}, {pods.GPU, price.GPU}} {
+		if r[0] > 0 {
+			if n := math.Floor(r[1] / r[0]); n < float64(ret) {
+				ret = int(n)
+			}
+		}
+	}
+	return ret
+}
+
+// allowedZonesPrice returns the price of the capacity type, the spot price is the cheapest one of the allowed zones
+func allowedZonesPrice(price *apis.InstanceTypePrice, capacityType string, zones []string) (float64, string, bool) {
+	if capacityType != apis.CapacityTypeSpot {
+		p, ok := CapacityPrice(price, capacityType, "")
+		return p, "", ok
+	}
+
+	ret, zone := 0.0, ""
+	for z, p := range price.SpotPricePerHour {
+		if p <= 0 || (len(zones) != 0 && !lo.Contains(zones, z)) {
+			continue
+		}
+		if zone == "" || p < ret || (p == ret && z < zone) {
+			ret, zone = p, z
+		}
+	}
+	return ret, zone, zone != ""
+}
+
+func sortRecommendations(recs []*apis.InstanceRecommendation) {
+	sort.Slice(recs, func(i, j int) bool {
+		a, b := recs[i], recs[j]
+		if a.TotalPricePerHour != b.TotalPricePerHour {
+			return a.TotalPricePerHour < b.TotalPricePerHour
+		}
+		if a.PricePerHour != b.PricePerHour {
+			return a.PricePerHour < b.PricePerHour
+		}
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		if a.InstanceType != b.InstanceType {
+			return a.InstanceType < b.InstanceType
+		}
+		return a.CapacityType < b.CapacityType
+	})
+}
+
+type mixGroup struct {
+	provider     string
+	region       string
+	capacityType string
+	candidates   []*apis.InstanceRecommendation
+	// lowerBound is the cost if every instance is fully used, no mix can be cheaper
+	lowerBound float64
+}
+
+// recommendMixes returns the cheapest mixes of the groups, the groups are searched in the order of
+// their lower bounds, so the groups which can't beat the found mixes are skipped.
+func recommendMixes(candidates []*apis.InstanceRecommendation, pods, limit int) []*apis.InstanceMixRecommendation {
+	groups := map[string]*mixGroup{}
+	for _, c := range candidates {
+		key := c.Provider + "/" + c.Region + "/" + c.CapacityType
+		g, ok := groups[key]
+		if !ok {
+			g = &mixGroup{provider: c.Provider, region: c.Region, capacityType: c.CapacityType}
+			groups[key] = g
+		}
+		g.candidates = append(g.candidates, c)
+	}
+
+	sortedGroups := lo.Values(groups)
+	for _, g := range sortedGroups {
+		pricePerPod := func(c *apis.InstanceRecommendation) float64 {
+			return c.PricePerHour / float64(min(c.PodsPerInstance, pods))
+		}
+		sort.SliceStable(g.candidates, func(i, j int) bool {
+			return pricePerPod(g.candidates[i]) < pricePerPod(g.candidates[j])
+		})
+		g.candidates = g.candidates[:min(mixCandidates, len(g.candidates))]
+		g.lowerBound = pricePerPod(g.candidates[0]) * float64(pods)
+	}
+	sort.Slice(sortedGroups, func(i, j int) bool {
+		if sortedGroups[i].lowerBound != sortedGroups[j].lowerBound {
+			return sortedGroups[i].lowerBound < sortedGroups[j].lowerBound
+		}
+		a, b := sortedGroups[i], sortedGroups[j]
+		if a.provider != b.provider {
+			return a.provider < b.provider
+		}
+		if a.region != b.region {
+			return a.region < b.region
+		}
+		return a.capacityType < b.capacityType
+	})
+
+	var ret []*apis.InstanceMixRecommendation
+	for _, g := range sortedGroups {
+		if len(ret) >= limit && g.lowerBound >= ret[limit-1].TotalPricePerHour {
+			break
+		}
+		ret = append(ret, packPods(g, pods))
+		sort.SliceStable(ret, func(i, j int) bool { return ret[i].TotalPricePerHour < ret[j].TotalPricePerHour })
+		ret = ret[:min(limit, len(ret))]
+	}
+	return ret
+}
+
+// packPods finds the cheapest mix of the candidates for the pods, it's an unbounded knapsack
+// where cost[n] is the cheapest price to run at least n pods.
+func packPods(g *mixGroup, pods int) *apis.InstanceMixRecommendation {
+	cost := make([]float64, pods+1)
+	choice := make([]int, pods+1)
+	for n := 1; n <= pods; n++ {
+		cost[n] = math.Inf(1)
+		for i, c := range g.candidates {
+			if v := cost[max(0, n-c.PodsPerInstance)] + c.PricePerHour; v < cost[n] {
+				cost[n], choice[n] = v, i
+			}
+		}
+	}
+
+	counts := map[int]int{}
+	for n := pods; n > 0; n = max(0, n-g.candidates[choice[n]].PodsPerInstance) {
+		counts[choice[n]]++
+	}
+
+	ret := &apis.InstanceMixRecommendation{
+		Provider:          g.provider,
+		Region:            g.region,
+		CapacityType:      g.capacityType,
+		TotalPricePerHour: cost[pods],
+		Currency:          g.candidates[0].Currency,
+	}
+	for _, i := range lo.Keys(counts) {
+		rec := *g.candidates[i]
+		rec.Count = counts[i]
+		rec.TotalPricePerHour = rec.PricePerHour * float64(rec.Count)
+		ret.Instances = append(ret.Instances, &rec)
+	}
+	sortRecommendations(ret.Instances)
+	return ret
+}
@@ -0,0 +1,140 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+func newMixCandidate(provider, instanceType string, podsPerInstance int, price float64) *apis.InstanceRecommendation {
+	return &apis.InstanceRecommendation{
+		Provider:        provider,
+		Region:          "us-east-1",
+		CapacityType:    apis.CapacityTypeOnDemand,
+		InstanceType:    instanceType,
+		PricePerHour:    price,
+		PodsPerInstance: podsPerInstance,
+	}
+}
+
+func mixCounts(mix *apis.InstanceMixRecommendation) map[string]int {
+	ret := map[string]int{}
+	for _, ins := range mix.Instances {
+		ret[ins.InstanceType] = ins.Count
+	}
+	return ret
+}
+
+func TestPackPods(t *testing.T) {
+	tests := []struct {
+		pods       int
+		candidates []*apis.InstanceRecommendation
+		wantTotal  float64
+		wantCounts map[string]int
+	}{
+		{
+			pods:       4,
+			candidates: []*apis.InstanceRecommendation{newMixCandidate("aws", "a", 2, 1), newMixCandidate("aws", "b", 3, 1.4)},
+			wantTotal:  2,
+			wantCounts: map[string]int{"a": 2},
+		},
+		{
+			pods:       3,
+			candidates: []*apis.InstanceRecommendation{newMixCandidate("aws", "a", 2, 1), newMixCandidate("aws", "b", 3, 1.4)},
+			wantTotal:  1.4,
+			wantCounts: map[string]int{"b": 1},
+		},
+		{
+			pods:       5,
+			candidates: []*apis.InstanceRecommendation{newMixCandidate("aws", "a", 2, 1), newMixCandidate("aws", "b", 3, 1.4)},
+			wantTotal:  2.4,
+			wantCounts: map[string]int{"a": 1, "b": 1},
+		},
+		{
+			// An instance larger than the pods is still used if it's the cheapest
+			pods:       1,
+			candidates: []*apis.InstanceRecommendation{newMixCandidate("aws", "a", 1, 1), newMixCandidate("aws", "b", 8, 0.5)},
+			wantTotal:  0.5,
+			wantCounts: map[string]int{"b": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d pods", tt.pods), func(t *testing.T) {
+			g := &mixGroup{provider: "aws", region: "us-east-1", capacityType: apis.CapacityTypeOnDemand, candidates: tt.candidates}
+			mix := packPods(g, tt.pods)
+			if math.Abs(mix.TotalPricePerHour-tt.wantTotal) > 1e-9 {
+				t.Errorf("got total %v, want %v", mix.TotalPricePerHour, tt.wantTotal)
+			}
+			counts := mixCounts(mix)
+			if !reflect.DeepEqual(counts, tt.wantCounts) {
+				t.Errorf("got counts %v, want %v", counts, tt.wantCounts)
+			}
+			var total float64
+			for _, ins := range mix.Instances {
+				total += ins.TotalPricePerHour
+			}
+			if math.Abs(total-mix.TotalPricePerHour) > 1e-9 {
+				t.Errorf("got the sum of the instances %v, want %v", total, mix.TotalPricePerHour)
+			}
+		})
+	}
+}
+
+func TestRecommendMixes(t *testing.T) {
+	// The lower bounds are 4, 5 and 8 for 4 pods, but the mix of the first group costs 6 since the pods don't fit
+	// in the instances exactly
+	candidates := []*apis.InstanceRecommendation{
+		newMixCandidate("p1", "a", 3, 3),
+		newMixCandidate("p2", "b", 4, 5),
+		newMixCandidate("p3", "c", 1, 2),
+	}
+	tests := []struct {
+		limit         int
+		wantProviders []string
+		wantTotals    []float64
+	}{
+		{limit: 1, wantProviders: []string{"p2"}, wantTotals: []float64{5}},
+		{limit: 2, wantProviders: []string{"p2", "p1"}, wantTotals: []float64{5, 6}},
+		{limit: 3, wantProviders: []string{"p2", "p1", "p3"}, wantTotals: []float64{5, 6, 8}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("limit %d", tt.limit), func(t *testing.T) {
+			mixes := recommendMixes(candidates, 4, tt.limit)
+			if len(mixes) != len(tt.wantProviders) {
+				t.Fatalf("got %d mixes, want %d", len(mixes), len(tt.wantProviders))
+			}
+			for i, mix := range mixes {
+				if mix.Provider != tt.wantProviders[i] || math.Abs(mix.TotalPricePerHour-tt.wantTotals[i]) > 1e-9 {
+					t.Errorf("got mix %d of %s costing %v, want %s costing %v", i, mix.Provider, mix.TotalPricePerHour,
+						tt.wantProviders[i], tt.wantTotals[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFitPods(t *testing.T) {
+	price := &apis.InstanceTypePrice{VCPU: 4, Memory: 16, GPU: 1}
+	tests := []struct {
+		pods *apis.PodsRequirement
+		want int
+	}{
+		{pods: &apis.PodsRequirement{VCPU: 1}, want: 4},
+		{pods: &apis.PodsRequirement{VCPU: 1, Memory: 8}, want: 2},
+		{pods: &apis.PodsRequirement{VCPU: 0.5, GPU: 1}, want: 1},
+		{pods: &apis.PodsRequirement{VCPU: 8}, want: 0},
+		// The number is clamped instead of overflowing
+		{pods: &apis.PodsRequirement{VCPU: 1e-300}, want: MaxRecommendationPods},
+		{pods: &apis.PodsRequirement{Memory: 0.001}, want: MaxRecommendationPods},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%+v", *tt.pods), func(t *testing.T) {
+			if got := fitPods(tt.pods, price); got != tt.want {
+				t.Errorf("got %d pods, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/samber/lo"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+)
+
+const (
+	SortByPrice        = "price"
+	SortByPricePerVCPU = "pricePerVCPU"
+	SortByPricePerGiB  = "pricePerGiB"
+
+	DefaultSearchLimit = 100
+	MaxSearchLimit     = 1000
+)
+
+// Range is a closed interval, nil bound means unbounded
+type Range struct {
+	Min *float64
+	Max *float64
+}
+
+func (r Range) contains(v float64) bool {
+	return (r.Min == nil || v >= *r.Min) && (r.Max == nil || v <= *r.Max)
+}
+
+type SearchOptions struct {
+	// Regions limits the search in the regions, empty means all
+	Regions []string
+	Arch    string
+	VCPU    Range
+	Memory  Range
+	GPU     Range
+	// Zone only matches the instance types available in the zone, the spot price of the zone is used if it's set
+	Zone string
+	// CapacityType is on-demand, spot or a key of the billing maps, e.g. ComputeSavingsPlans/1yr/no
+	CapacityType string
+	// MaxPrice is the max price per hour of the capacity type in Currency, 0 means unlimited
+	MaxPrice float64
+	// OS and Tenancy select the prices of the platform, default to linux with shared tenancy
+	OS      string
+	Tenancy string
+	// Site selects the prices of the site, cn or intl, default to cn
+	Site string
+	// Currency is the currency of the returned prices, default to USD
+	Currency string
+	Rates    currency.Rates
+	SortBy   string
+	Desc     bool
+	Offset   int
+	Limit    int
+}
+
+// Validate fills the default values and checks the options
+func (o *SearchOptions) Validate() error {
+	if o.CapacityType == "" {
+		o.CapacityType = apis.CapacityTypeOnDemand
+	}
+	if o.SortBy == "" {
+		o.SortBy = SortByPrice
+	}
+	if !lo.Contains([]string{SortByPrice, SortByPricePerVCPU, SortByPricePerGiB}, o.SortBy) {
+		return fmt.Errorf("unsupported sort by %s", o.SortBy)
+	}
+	if o.Limit == 0 {
+		o.Limit = DefaultSearchLimit
+	}
+	if o.Limit < 0 || o.Limit > MaxSearchLimit {
+		return fmt.Errorf("limit should be in [1, %d]", MaxSearchLimit)
+	}
+	if o.Offset < 0 {
+		return fmt.Errorf("offset should not be negative")
+	}
+	if o.MaxPrice < 0 {
+		return fmt.Errorf("max price should not be negative")
+	}
+	if err := ValidatePlatform(&o.OS, &o.Tenancy); err != nil {
+		return err
+	}
+	if err := ValidateSite(o.Site); err != nil {
+		return err
+	}
+	return validateCurrency(&o.Currency, &o.Rates)
+}
+
+// Search returns the instance types of the providers matching the options
+func Search(providers []client.PriceProvider, opts *SearchOptions) (*apis.InstanceSearchResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	var items []*apis.InstanceSearchItem
+	for _, provider := range providers {
+		regions := provider.ListRegions()
+		if len(opts.Regions) != 0 {
+			regions = lo.Intersect(regions, opts.Regions)
+		}
+		for _, region := range regions {
+			data := provider.ListInstancesPrice(region)
+			if data == nil {
+				continue
+			}
+			from := client.PriceCurrency(provider.Name(), region)
+			for instanceType, price := range (*data)[region].InstanceTypePrices {
+				// The prices are shared with the provider, only the ones of the returned page are copied
+				price, ok := selectPrice(price, opts.OS, opts.Tenancy, opts.Site)
+				if !ok {
+					continue
+				}
+				item, err := matchInstance(opts, price, from)
+				if err != nil {
+					return nil, err
+				}
+				if item != nil {
+					item.Provider = provider.Name()
+					item.Region = region
+					item.InstanceType = instanceType
+					items = append(items, item)
+				}
+			}
+		}
+	}
+
+	sortKey := func(item *apis.InstanceSearchItem) float64 {
+		switch opts.SortBy {
+		case SortByPricePerVCPU:
+			return item.PricePerVCPU
+		case SortByPricePerGiB:
+			return item.PricePerGiB
+		default:
+			return item.Price
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		ki, kj := sortKey(items[i]), sortKey(items[j])
+		if ki != kj {
+			if opts.Desc {
+				return ki > kj
+			}
+			return ki < kj
+		}
+		// Keep the order stable for pagination
+		if items[i].Provider != items[j].Provider {
+			return items[i].Provider < items[j].Provider
+		}
+		if items[i].Region != items[j].Region {
+			return items[i].Region < items[j].Region
+		}
+		return items[i].InstanceType < items[j].InstanceType
+	})
+
+	ret := &apis.InstanceSearchResult{
+		Total:    len(items),
+		Currency: opts.Currency,
+		Items:    []*apis.InstanceSearchItem{},
+	}
+	if opts.Offset < len(items) {
+		ret.Items = items[opts.Offset:min(opts.Offset+opts.Limit, len(items))]
+	}
+	for _, item := range ret.Items {
+		price := item.InstanceTypePrice.DeepCopy()
+		err := opts.Rates.ConvertInstanceTypePrice(price, client.PriceCurrency(item.Provider, item.Region), opts.Currency)
+		if err != nil {
+			return nil, err
+		}
+		item.InstanceTypePrice = price
+	}
+	return ret, nil
+}
+
+// matchInstance returns the item of the price if it matches the options, the price of the item is converted to the
+// currency of the options from the currency of the price, from is used if it's not set
+func matchInstance(opts *SearchOptions, price *apis.InstanceTypePrice, from string) (*apis.InstanceSearchItem, error) {
+	if opts.Arch != "" && price.Arch != opts.Arch {
+		return nil, nil
+	}
+	if !opts.VCPU.contains(price.VCPU) || !opts.Memory.contains(price.Memory) || !opts.GPU.contains(price.GPU) {
+		return nil, nil
+	}
+	if opts.Zone != "" && !lo.Contains(price.Zones, opts.Zone) {
+		if _, ok := price.SpotPricePerHour[opts.Zone]; !ok {
+			return nil, nil
+		}
+	}
+	// The instance types without specs can't be sorted by the price per unit
+	if (opts.SortBy == SortByPricePerVCPU && price.VCPU <= 0) || (opts.SortBy == SortByPricePerGiB && price.Memory <= 0) {
+		return nil, nil
+	}
+
+	p, ok := CapacityPrice(price, opts.CapacityType, opts.Zone)
+	if !ok {
+		return nil, nil
+	}
+	p, err := opts.Rates.ConvertPrice(price, p, from, opts.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if opts.MaxPrice > 0 && p > opts.MaxPrice {
+		return nil, nil
+	}
+
+	item := &apis.InstanceSearchItem{
+		Price:             p,
+		InstanceTypePrice: price,
+	}
+	if price.VCPU > 0 {
+		item.PricePerVCPU = p / price.VCPU
+	}
+	if price.Memory > 0 {
+		item.PricePerGiB = p / price.Memory
+	}
+	return item, nil
+}
+
+// CapacityPrice returns the price per hour of the capacity type, the cheapest spot price of all zones
+// is returned if zone is empty.
+func CapacityPrice(price *apis.InstanceTypePrice, capacityType, zone string) (float64, bool) {
+	switch capacityType {
+	case apis.CapacityTypeOnDemand:
+		return price.OnDemandPricePerHour, price.OnDemandPricePerHour > 0
+	case apis.CapacityTypeSpot:
+		if zone != "" {
+			p, ok := price.SpotPricePerHour[zone]
+			return p, ok && p > 0
+		}
+		ret, found := 0.0, false
+		for _, p := range price.SpotPricePerHour {
+			if p > 0 && (!found || p < ret) {
+				ret, found = p, true
+			}
+		}
+		return ret, found
+	}
+
+	if b, ok := price.AWSEC2Billing[capacityType]; ok && b.Rate > 0 {
+		return b.Rate, true
+	}
+	if b, ok := price.AWSEC2ReservedBilling[capacityType]; ok && b.Rate > 0 {
+		return b.Rate, true
+	}
+	if b, ok := price.GCPCommittedUseBilling[capacityType]; ok && b.Rate > 0 {
+		return b.Rate, true
+	}
+	if b, ok := price.AzureBilling[capacityType]; ok && b.Rate > 0 {
+		return b.Rate, true
+	}
+	if b, ok := price.AlibabaCloudBilling[capacityType]; ok && b.Rate > 0 {
+		return b.Rate, true
+	}
+	return 0, false
+}
+
+// validateCurrency fills the default currency and rates, and checks the currency is supported
+func validateCurrency(to *string, rates *currency.Rates) error {
+	if *to == "" {
+		*to = currency.USD
+	}
+	if *rates == nil {
+		*rates = currency.DefaultRates
+	}
+	if _, ok := (*rates)[*to]; !ok {
+		return fmt.Errorf("unsupported currency %s", *to)
+	}
+	return nil
+}
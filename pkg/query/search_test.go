@@ -0,0 +1,175 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+)
+
+// testProvider serves the price data without refreshing it
+type testProvider struct {
+	name string
+	data map[string]*apis.RegionalInstancePrice
+}
+
+func (p *testProvider) Name() string            { return p.name }
+func (p *testProvider) Service() string         { return "" }
+func (p *testProvider) Run(ctx context.Context) { <-ctx.Done() }
+func (p *testProvider) Refresh(_, _ string)     {}
+func (p *testProvider) Health() error           { return nil }
+func (p *testProvider) ListRegions() []string {
+	ret := make([]string, 0, len(p.data))
+	for region := range p.data {
+		ret = append(ret, region)
+	}
+	return ret
+}
+
+func (p *testProvider) ListRegionsInstancesPrice() map[string]*apis.RegionalInstancePrice {
+	return p.data
+}
+
+func (p *testProvider) ListInstancesPrice(region string) *map[string]apis.RegionalInstancePrice {
+	d, ok := p.data[region]
+	if !ok {
+		return nil
+	}
+	return &map[string]apis.RegionalInstancePrice{region: *d}
+}
+
+func (p *testProvider) GetInstancePrice(region, instanceType string) *apis.InstanceTypePrice {
+	if d, ok := p.data[region]; ok {
+		return d.InstanceTypePrices[instanceType]
+	}
+	return nil
+}
+
+func newSearchTestProviders() []*testProvider {
+	return []*testProvider{
+		{name: "p1", data: map[string]*apis.RegionalInstancePrice{
+			"r1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+				"a": {Arch: "amd64", VCPU: 2, Memory: 8, Zones: []string{"r1a", "r1b"}, OnDemandPricePerHour: 0.2,
+					SpotPricePerHour: map[string]float64{"r1a": 0.05, "r1b": 0.04},
+					AWSEC2Billing:    map[string]apis.AWSEC2Billing{"ri/1yr": {Rate: 0.12}}},
+				"b": {Arch: "arm64", VCPU: 4, Memory: 16, Zones: []string{"r1a"}, OnDemandPricePerHour: 0.3},
+			}},
+			"r2": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+				"a": {Arch: "amd64", VCPU: 2, Memory: 8, Zones: []string{"r2a"}, OnDemandPricePerHour: 0.25},
+			}},
+		}},
+		{name: "p2", data: map[string]*apis.RegionalInstancePrice{
+			"r1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+				"c": {Arch: "amd64", VCPU: 8, Memory: 32, GPU: 1, Zones: []string{"r1a"}, OnDemandPricePerHour: 1.2,
+					SpotPricePerHour: map[string]float64{"r1a": 0.5}},
+				// The instance type without specs can't be sorted by the price per unit
+				"d": {OnDemandPricePerHour: 0.1},
+			}},
+		}},
+	}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestSearch(t *testing.T) {
+	var providers []client.PriceProvider
+	for _, p := range newSearchTestProviders() {
+		providers = append(providers, p)
+	}
+
+	tests := []struct {
+		name       string
+		opts       SearchOptions
+		want       []string
+		wantPrices []float64
+		wantTotal  int
+		wantErr    bool
+	}{
+		{
+			name:       "default",
+			want:       []string{"p2/r1/d", "p1/r1/a", "p1/r2/a", "p1/r1/b", "p2/r1/c"},
+			wantPrices: []float64{0.1, 0.2, 0.25, 0.3, 1.2},
+		},
+		{name: "region", opts: SearchOptions{Regions: []string{"r2", "r3"}}, want: []string{"p1/r2/a"}},
+		{name: "arch", opts: SearchOptions{Arch: "arm64"}, want: []string{"p1/r1/b"}},
+		{name: "vcpu", opts: SearchOptions{VCPU: Range{Min: floatPtr(4)}}, want: []string{"p1/r1/b", "p2/r1/c"}},
+		{
+			name: "memory",
+			opts: SearchOptions{Memory: Range{Min: floatPtr(8), Max: floatPtr(16)}},
+			want: []string{"p1/r1/a", "p1/r2/a", "p1/r1/b"},
+		},
+		{name: "gpu", opts: SearchOptions{GPU: Range{Min: floatPtr(1)}}, want: []string{"p2/r1/c"}},
+		{
+			// The cheapest spot price of the zones is used
+			name:       "spot",
+			opts:       SearchOptions{CapacityType: apis.CapacityTypeSpot},
+			want:       []string{"p1/r1/a", "p2/r1/c"},
+			wantPrices: []float64{0.04, 0.5},
+		},
+		{
+			name:       "spot in a zone",
+			opts:       SearchOptions{CapacityType: apis.CapacityTypeSpot, Zone: "r1a"},
+			want:       []string{"p1/r1/a", "p2/r1/c"},
+			wantPrices: []float64{0.05, 0.5},
+		},
+		{name: "zone", opts: SearchOptions{Zone: "r1b"}, want: []string{"p1/r1/a"}},
+		{name: "billing", opts: SearchOptions{CapacityType: "ri/1yr"}, want: []string{"p1/r1/a"}, wantPrices: []float64{0.12}},
+		{name: "max price", opts: SearchOptions{MaxPrice: 0.25}, want: []string{"p2/r1/d", "p1/r1/a", "p1/r2/a"}},
+		{
+			name:       "price per vcpu",
+			opts:       SearchOptions{SortBy: SortByPricePerVCPU},
+			want:       []string{"p1/r1/b", "p1/r1/a", "p1/r2/a", "p2/r1/c"},
+			wantPrices: []float64{0.3, 0.2, 0.25, 1.2},
+		},
+		{
+			name: "price per gib desc",
+			opts: SearchOptions{SortBy: SortByPricePerGiB, Desc: true},
+			want: []string{"p2/r1/c", "p1/r2/a", "p1/r1/a", "p1/r1/b"},
+		},
+		{
+			name:      "page",
+			opts:      SearchOptions{Offset: 1, Limit: 2},
+			want:      []string{"p1/r1/a", "p1/r2/a"},
+			wantTotal: 5,
+		},
+		{name: "offset out of range", opts: SearchOptions{Offset: 10}, wantTotal: 5},
+		{name: "invalid sort by", opts: SearchOptions{SortBy: "name"}, wantErr: true},
+		{name: "invalid limit", opts: SearchOptions{Limit: MaxSearchLimit + 1}, wantErr: true},
+		{name: "invalid offset", opts: SearchOptions{Offset: -1}, wantErr: true},
+		{name: "invalid max price", opts: SearchOptions{MaxPrice: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Search(providers, &tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var got []string
+			var prices []float64
+			for _, item := range result.Items {
+				got = append(got, item.Provider+"/"+item.Region+"/"+item.InstanceType)
+				prices = append(prices, item.Price)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if tt.wantPrices != nil && !reflect.DeepEqual(prices, tt.wantPrices) {
+				t.Errorf("got prices %v, want %v", prices, tt.wantPrices)
+			}
+			wantTotal := tt.wantTotal
+			if wantTotal == 0 {
+				wantTotal = len(tt.want)
+			}
+			if result.Total != wantTotal {
+				t.Errorf("got total %d, want %d", result.Total, wantTotal)
+			}
+		})
+	}
+}
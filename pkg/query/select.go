@@ -0,0 +1,89 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+	"github.com/cloudpilot-ai/priceserver/pkg/client"
+	"github.com/cloudpilot-ai/priceserver/pkg/currency"
+)
+
+// SelectRegionsPrice returns the prices of the os, tenancy and site, data is returned if none is set. The data is
+// not changed, the returned prices are shared with it.
+func SelectRegionsPrice(data map[string]*apis.RegionalInstancePrice, os, tenancy, site string) (map[string]*apis.RegionalInstancePrice, error) {
+	if os != "" || tenancy != "" {
+		if err := ValidatePlatform(&os, &tenancy); err != nil {
+			return nil, err
+		}
+		data = lo.MapValues(data, func(d *apis.RegionalInstancePrice, _ string) *apis.RegionalInstancePrice {
+			return SelectPlatform(d, os, tenancy)
+		})
+	}
+	if site != "" {
+		if err := ValidateSite(site); err != nil {
+			return nil, err
+		}
+		data = lo.MapValues(data, func(d *apis.RegionalInstancePrice, _ string) *apis.RegionalInstancePrice {
+			return SelectSite(d, site)
+		})
+	}
+	return data, nil
+}
+
+// selectRegionalPrice returns the prices selected by selectFunc in a new map, the legacy field InstanceTypeEC2Price
+// is kept if it's set
+func selectRegionalPrice(data *apis.RegionalInstancePrice,
+	selectFunc func(*apis.InstanceTypePrice) (*apis.InstanceTypePrice, bool)) *apis.RegionalInstancePrice {
+	ret := &apis.RegionalInstancePrice{
+		InstanceTypePrices: make(map[string]*apis.InstanceTypePrice, len(data.InstanceTypePrices)),
+	}
+	for instanceType, price := range data.InstanceTypePrices {
+		if p, ok := selectFunc(price); ok {
+			ret.InstanceTypePrices[instanceType] = p
+		}
+	}
+	if data.InstanceTypeEC2Price != nil {
+		ret.InstanceTypeEC2Price = ret.InstanceTypePrices
+	}
+	return ret
+}
+
+// selectPrice returns the price of the platform and the site without copying, it shares the maps with price
+func selectPrice(price *apis.InstanceTypePrice, os, tenancy, site string) (*apis.InstanceTypePrice, bool) {
+	p, ok := price.ForPlatform(os, tenancy)
+	if !ok {
+		return nil, false
+	}
+	return p.ForSite(site)
+}
+
+// ConvertRegionsPrice returns the prices converted to the currency, data is returned if the currency is empty. The
+// data is not changed, the prices are copied before converting.
+func ConvertRegionsPrice(data map[string]*apis.RegionalInstancePrice, provider string, rates currency.Rates,
+	to string) (map[string]*apis.RegionalInstancePrice, error) {
+	if to == "" {
+		return data, nil
+	}
+	if _, ok := rates[to]; !ok {
+		return nil, fmt.Errorf("unsupported currency %s", to)
+	}
+
+	ret := make(map[string]*apis.RegionalInstancePrice, len(data))
+	for region, d := range data {
+		converted := make(map[string]*apis.InstanceTypePrice, len(d.InstanceTypePrices))
+		for instanceType, price := range d.InstanceTypePrices {
+			price = price.DeepCopy()
+			if err := rates.ConvertInstanceTypePrice(price, client.PriceCurrency(provider, region), to); err != nil {
+				return nil, err
+			}
+			converted[instanceType] = price
+		}
+		ret[region] = &apis.RegionalInstancePrice{InstanceTypePrices: converted}
+		if d.InstanceTypeEC2Price != nil {
+			ret[region].InstanceTypeEC2Price = converted
+		}
+	}
+	return ret, nil
+}
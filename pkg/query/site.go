@@ -0,0 +1,27 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+var SupportedSites = []string{apis.SiteCN, apis.SiteIntl}
+
+// ValidateSite checks the site is supported, empty means the default site
+func ValidateSite(site string) error {
+	if site != "" && !lo.Contains(SupportedSites, site) {
+		return fmt.Errorf("unsupported site %s", site)
+	}
+	return nil
+}
+
+// SelectSite returns the prices of the site, the instance types without the prices of the site are removed. The
+// prices are shared with data, so they must not be modified.
+func SelectSite(data *apis.RegionalInstancePrice, site string) *apis.RegionalInstancePrice {
+	return selectRegionalPrice(data, func(price *apis.InstanceTypePrice) (*apis.InstanceTypePrice, bool) {
+		return price.ForSite(site)
+	})
+}
@@ -0,0 +1,72 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+func TestSelectSite(t *testing.T) {
+	newData := func() *apis.RegionalInstancePrice {
+		return &apis.RegionalInstancePrice{InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+			"ecs.g6.large": {VCPU: 2, Memory: 8, OnDemandPricePerHour: 0.8, Currency: "CNY",
+				AlibabaCloudBilling: map[string]apis.AlibabaCloudBilling{"subscription/1yr": {Rate: 0.5, UpfrontFee: 4380}},
+				SitePrices:          map[string]*apis.SitePrice{apis.SiteIntl: {OnDemandPricePerHour: 0.12, Currency: "USD"}}},
+			// The instance type is only sold on the China site
+			"ecs.g6e.large": {VCPU: 2, Memory: 8, OnDemandPricePerHour: 0.9, Currency: "CNY"},
+		}}
+	}
+
+	type sitePrice struct {
+		price    float64
+		currency string
+		// billing means the subscription prices are kept
+		billing bool
+	}
+	tests := []struct {
+		site string
+		want map[string]sitePrice
+	}{
+		{
+			site: "",
+			want: map[string]sitePrice{
+				"ecs.g6.large":  {price: 0.8, currency: "CNY", billing: true},
+				"ecs.g6e.large": {price: 0.9, currency: "CNY"},
+			},
+		},
+		{
+			site: apis.SiteCN,
+			want: map[string]sitePrice{
+				"ecs.g6.large":  {price: 0.8, currency: "CNY", billing: true},
+				"ecs.g6e.large": {price: 0.9, currency: "CNY"},
+			},
+		},
+		{
+			site: apis.SiteIntl,
+			want: map[string]sitePrice{"ecs.g6.large": {price: 0.12, currency: "USD"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.site, func(t *testing.T) {
+			origin := newData()
+			data := SelectSite(origin, tt.site)
+			if len(data.InstanceTypePrices) != len(tt.want) {
+				t.Fatalf("got %d instance types, want %d", len(data.InstanceTypePrices), len(tt.want))
+			}
+			for instanceType, want := range tt.want {
+				p, ok := data.InstanceTypePrices[instanceType]
+				if !ok {
+					t.Fatalf("got no price of %s", instanceType)
+				}
+				got := sitePrice{price: p.OnDemandPricePerHour, currency: p.Currency, billing: len(p.AlibabaCloudBilling) > 0}
+				if got != want || p.SitePrices != nil || p.VCPU != 2 {
+					t.Errorf("got %s %+v, want %+v", instanceType, p, want)
+				}
+			}
+			// The prices are shared with the origin data, so they must not be modified
+			if len(origin.InstanceTypePrices) != 2 || origin.InstanceTypePrices["ecs.g6.large"].SitePrices == nil {
+				t.Errorf("got the origin data changed %+v", origin.InstanceTypePrices)
+			}
+		})
+	}
+}
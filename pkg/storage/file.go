@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+// FileStore persists the price data of each provider in {dir}/{provider}_price.json,
+// which has the same format as the builtin data.
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (f *FileStore) path(provider string) string {
+	return filepath.Join(f.dir, fmt.Sprintf("%s_price.json", provider))
+}
+
+func (f *FileStore) Save(provider string, data map[string]*apis.RegionalInstancePrice) error {
+	marshalData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(f.path(provider), marshalData)
+}
+
+func (f *FileStore) Load(provider string) (map[string]*apis.RegionalInstancePrice, time.Time, error) {
+	path := f.path(provider)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, time.Time{}, ErrNotFound
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	ret := map[string]*apis.RegionalInstancePrice{}
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	return ret, info.ModTime(), nil
+}
+
+// writeFileAtomic writes to a temporary file and renames it, so the readers never see a partial file
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	f, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := f.Load("aws"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got error %v before saving, want ErrNotFound", err)
+	}
+
+	data := map[string]*apis.RegionalInstancePrice{
+		"us-east-1": {InstanceTypePrices: map[string]*apis.InstanceTypePrice{
+			"m5.large": {Arch: "amd64", VCPU: 2, Memory: 8, OnDemandPricePerHour: 0.096},
+		}},
+	}
+	for i := 0; i < 2; i++ {
+		data["us-east-1"].InstanceTypePrices["m5.large"].OnDemandPricePerHour += float64(i)
+		before := time.Now().Add(-time.Second)
+		if err := f.Save("aws", data); err != nil {
+			t.Fatal(err)
+		}
+		got, savedAt, err := f.Load("aws")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, data) {
+			t.Errorf("got %+v, want the saved data", got["us-east-1"].InstanceTypePrices["m5.large"])
+		}
+		if savedAt.Before(before) {
+			t.Errorf("got saved time %v, want after %v", savedAt, before)
+		}
+	}
+
+	// The temporary files are renamed or removed
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "aws_price.json" {
+		t.Errorf("got files %v, want aws_price.json only", entries)
+	}
+	if _, _, err := f.Load("gcp"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got error %v of another provider, want ErrNotFound", err)
+	}
+}
+
+func TestFileStoreLoadInvalid(t *testing.T) {
+	dir := t.TempDir()
+	f, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "aws_price.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := f.Load("aws"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("got error %v, want the unmarshal error", err)
+	}
+}
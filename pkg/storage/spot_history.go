@@ -0,0 +1,302 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+// DefaultSpotHistoryRetention is long enough for the spot risk models which require 90 days of history
+const DefaultSpotHistoryRetention = 90 * 24 * time.Hour
+
+// spotHistoryCompactionInterval is the interval to drop the expired points and rewrite the log
+const spotHistoryCompactionInterval = 24 * time.Hour
+
+// SpotHistory keeps the spot prices in memory, a point is added only when the price changes, so each
+// series is a step function. If dir is set, the changed points are appended to {dir}/{provider}_spot_history.jsonl
+// and the log is compacted daily.
+type SpotHistory struct {
+	dir       string
+	retention time.Duration
+
+	mutex     sync.Mutex
+	providers map[string]*providerSpotHistory
+}
+
+type spotPoint struct {
+	time  int64
+	price float64
+}
+
+type providerSpotHistory struct {
+	// series is indexed by region, instance type and zone
+	series         map[string]map[string]map[string][]spotPoint
+	lastCompaction time.Time
+}
+
+// spotLogRecord is a line of the log, the field names are shortened to keep the log compact
+type spotLogRecord struct {
+	Time    int64           `json:"t"`
+	Samples []spotLogSample `json:"s"`
+}
+
+type spotLogSample struct {
+	Region       string  `json:"r"`
+	InstanceType string  `json:"i"`
+	Zone         string  `json:"z"`
+	Price        float64 `json:"p"`
+}
+
+func NewSpotHistory(dir string, retention time.Duration) (*SpotHistory, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	if retention <= 0 {
+		retention = DefaultSpotHistoryRetention
+	}
+
+	return &SpotHistory{
+		dir:       dir,
+		retention: retention,
+		providers: map[string]*providerSpotHistory{},
+	}, nil
+}
+
+func (h *SpotHistory) path(provider string) string {
+	return filepath.Join(h.dir, fmt.Sprintf("%s_spot_history.jsonl", provider))
+}
+
+func (h *SpotHistory) Append(provider string, t time.Time, samples []SpotPriceSample) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	p := h.getProvider(provider)
+	record := spotLogRecord{Time: t.Unix()}
+	for _, s := range samples {
+		if p.add(s.Region, s.InstanceType, s.Zone, spotPoint{time: record.Time, price: s.Price}) {
+			record.Samples = append(record.Samples, spotLogSample{
+				Region:       s.Region,
+				InstanceType: s.InstanceType,
+				Zone:         s.Zone,
+				Price:        s.Price,
+			})
+		}
+	}
+
+	if t.Sub(p.lastCompaction) >= spotHistoryCompactionInterval {
+		p.prune(t.Add(-h.retention).Unix())
+		p.lastCompaction = t
+		if h.dir != "" {
+			return h.compact(provider, p)
+		}
+		return nil
+	}
+
+	if h.dir == "" || len(record.Samples) == 0 {
+		return nil
+	}
+	return h.appendLog(provider, &record)
+}
+
+func (h *SpotHistory) Query(provider, region, instanceType string, from, to time.Time) map[string][]apis.SpotPricePoint {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	fromUnix, toUnix := from.Unix(), to.Unix()
+	ret := map[string][]apis.SpotPricePoint{}
+	for zone, points := range h.getProvider(provider).series[region][instanceType] {
+		start := sort.Search(len(points), func(i int) bool { return points[i].time >= fromUnix })
+		if start > 0 && (start == len(points) || points[start].time > fromUnix) {
+			start--
+		}
+		end := sort.Search(len(points), func(i int) bool { return points[i].time > toUnix })
+		if start >= end {
+			continue
+		}
+
+		zonePoints := make([]apis.SpotPricePoint, 0, end-start)
+		for _, point := range points[start:end] {
+			zonePoints = append(zonePoints, apis.SpotPricePoint{
+				Timestamp: time.Unix(point.time, 0).UTC(),
+				Price:     point.price,
+			})
+		}
+		ret[zone] = zonePoints
+	}
+	return ret
+}
+
+// getProvider returns the history of the provider, the log is loaded at the first access
+func (h *SpotHistory) getProvider(provider string) *providerSpotHistory {
+	if p, ok := h.providers[provider]; ok {
+		return p
+	}
+
+	p := &providerSpotHistory{
+		series: map[string]map[string]map[string][]spotPoint{},
+	}
+	h.providers[provider] = p
+	if h.dir != "" {
+		if err := h.loadLog(provider, p); err != nil {
+			klog.Errorf("Failed to load spot price history of %s: %v", provider, err)
+		}
+	}
+	return p
+}
+
+// loadLog replays the log, the broken records are skipped since they're the results of interrupted writes
+func (h *SpotHistory) loadLog(provider string, p *providerSpotHistory) error {
+	f, err := os.Open(h.path(provider))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	skipped := 0
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if len(bytes.TrimSpace(line)) != 0 {
+			var record spotLogRecord
+			if err := json.Unmarshal(line, &record); err != nil {
+				skipped++
+			}
+			for _, s := range record.Samples {
+				p.add(s.Region, s.InstanceType, s.Zone, spotPoint{time: record.Time, price: s.Price})
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	if skipped != 0 {
+		klog.Warningf("Skip %d broken records of spot price history of %s", skipped, provider)
+	}
+	klog.Infof("Load spot price history of %s", provider)
+	return nil
+}
+
+func (h *SpotHistory) appendLog(provider string, record *spotLogRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(h.path(provider), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// compact rewrites the log with the points in memory
+func (h *SpotHistory) compact(provider string, p *providerSpotHistory) error {
+	records := map[int64]*spotLogRecord{}
+	for region, types := range p.series {
+		for instanceType, zones := range types {
+			for zone, points := range zones {
+				for _, point := range points {
+					record, ok := records[point.time]
+					if !ok {
+						record = &spotLogRecord{Time: point.time}
+						records[point.time] = record
+					}
+					record.Samples = append(record.Samples, spotLogSample{
+						Region:       region,
+						InstanceType: instanceType,
+						Zone:         zone,
+						Price:        point.price,
+					})
+				}
+			}
+		}
+	}
+
+	times := make([]int64, 0, len(records))
+	for t := range records {
+		times = append(times, t)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+
+	var data []byte
+	for _, t := range times {
+		line, err := json.Marshal(records[t])
+		if err != nil {
+			return err
+		}
+		data = append(data, line...)
+		data = append(data, '\n')
+	}
+	return writeFileAtomic(h.path(provider), data)
+}
+
+// add appends the point if the price is changed, it returns false if the point is dropped. The points are in
+// seconds, so a point at the time of the last one replaces it, and the one before the last one is dropped.
+func (p *providerSpotHistory) add(region, instanceType, zone string, point spotPoint) bool {
+	if point.price <= 0 {
+		return false
+	}
+
+	if _, ok := p.series[region]; !ok {
+		p.series[region] = map[string]map[string][]spotPoint{}
+	}
+	if _, ok := p.series[region][instanceType]; !ok {
+		p.series[region][instanceType] = map[string][]spotPoint{}
+	}
+
+	points := p.series[region][instanceType][zone]
+	n := len(points)
+	if n > 0 && (points[n-1].price == point.price || points[n-1].time > point.time) {
+		return false
+	}
+	if n > 0 && points[n-1].time == point.time {
+		points = points[:n-1]
+		// The replaced point is dropped if the price is back to the previous one
+		if n > 1 && points[n-2].price == point.price {
+			p.series[region][instanceType][zone] = points
+			return true
+		}
+	}
+	p.series[region][instanceType][zone] = append(points, point)
+	return true
+}
+
+// prune drops the points before cutoff, the last one of them is kept as the price in effect at cutoff
+func (p *providerSpotHistory) prune(cutoff int64) {
+	for _, types := range p.series {
+		for _, zones := range types {
+			for zone, points := range zones {
+				i := sort.Search(len(points), func(i int) bool { return points[i].time >= cutoff })
+				if i <= 1 {
+					continue
+				}
+				zones[zone] = append([]spotPoint(nil), points[i-1:]...)
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSpotHistoryAdd(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []spotPoint
+		want   []spotPoint
+	}{
+		{
+			name:   "unchanged prices are dropped",
+			points: []spotPoint{{time: 1, price: 0.1}, {time: 2, price: 0.1}, {time: 3, price: 0.2}},
+			want:   []spotPoint{{time: 1, price: 0.1}, {time: 3, price: 0.2}},
+		},
+		{
+			name:   "a point in the same second replaces the last one",
+			points: []spotPoint{{time: 1, price: 0.1}, {time: 2, price: 0.2}, {time: 2, price: 0.3}},
+			want:   []spotPoint{{time: 1, price: 0.1}, {time: 2, price: 0.3}},
+		},
+		{
+			name:   "the replaced point is dropped if the price is back",
+			points: []spotPoint{{time: 1, price: 0.1}, {time: 2, price: 0.2}, {time: 2, price: 0.1}},
+			want:   []spotPoint{{time: 1, price: 0.1}},
+		},
+		{
+			name:   "points before the last one are dropped",
+			points: []spotPoint{{time: 2, price: 0.1}, {time: 1, price: 0.2}},
+			want:   []spotPoint{{time: 2, price: 0.1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &providerSpotHistory{series: map[string]map[string]map[string][]spotPoint{}}
+			for _, point := range tt.points {
+				p.add("us-east-1", "m5.large", "us-east-1a", point)
+			}
+			if got := p.series["us-east-1"]["m5.large"]["us-east-1a"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpotHistoryLoadLog(t *testing.T) {
+	h, err := NewSpotHistory(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := `{"t":1,"s":[{"r":"us-east-1","i":"m5.large","z":"us-east-1a","p":0.1}]}
+{"t":2,"s":[{"r":"us-east-1","i":"m5.large","z":"us-e
+{"t":3,"s":[{"r":"us-east-1","i":"m5.large","z":"us-east-1a","p":0.3}]}
+`
+	if err := os.WriteFile(h.path("aws"), []byte(log), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := h.Query("aws", "us-east-1", "m5.large", time.Unix(0, 0), time.Unix(10, 0))["us-east-1a"]
+	if len(got) != 2 || got[0].Price != 0.1 || got[1].Price != 0.3 {
+		t.Errorf("got %v, want the records around the broken one", got)
+	}
+}
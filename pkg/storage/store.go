@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudpilot-ai/priceserver/pkg/apis"
+)
+
+// ErrNotFound is returned by Load if no data is persisted for the provider
+var ErrNotFound = errors.New("price data is not found")
+
+// Store persists the price data of providers
+type Store interface {
+	// Save replaces the persisted price data of the provider atomically
+	Save(provider string, data map[string]*apis.RegionalInstancePrice) error
+	// Load returns the newest persisted price data of the provider and the time when it was saved
+	Load(provider string) (map[string]*apis.RegionalInstancePrice, time.Time, error)
+}
+
+// SpotPriceSample is the spot price of an instance type in a zone
+type SpotPriceSample struct {
+	Region       string
+	InstanceType string
+	Zone         string
+	Price        float64
+}
+
+// SpotHistoryStore keeps the observed spot prices as time series
+type SpotHistoryStore interface {
+	// Append records the spot prices of the provider observed at t, only the changed prices are kept
+	Append(provider string, t time.Time, samples []SpotPriceSample) error
+	// Query returns the points of the instance type in [from, to] keyed by zone, the last point before
+	// from is included to represent the price in effect at from
+	Query(provider, region, instanceType string, from, to time.Time) map[string][]apis.SpotPricePoint
+}
@@ -3,10 +3,12 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"sync"
 	"time"
 
@@ -31,40 +33,57 @@ type QueryClientInterface interface {
 type QueryClientImpl struct {
 	region       string
 	queryBaseUrl string
+	// ec2Compatible means the legacy field InstanceTypeEC2Price is set for the aws price data
+	ec2Compatible bool
 
 	awsMutex  sync.Mutex
 	priceData map[string]*apis.RegionalInstancePrice
+	// version is the version of the synced price data, only the changes since it are synced
+	version uint64
+	// etag is the ETag of the synced price data, the data isn't sent again if it's not modified. It's only used
+	// if the price server doesn't support the delta.
+	etag string
 }
 
+// errDeltaNotSupported means the price server is too old to serve the price delta
+var errDeltaNotSupported = errors.New("price delta is not supported")
+
 const (
-	AlibabaCloudProvider = "alibabacloud"
-	AWSCloudProvider     = "aws"
+	AlibabaCloudProvider = apis.AlibabaCloudProvider
+	AWSCloudProvider     = apis.AWSCloudProvider
+	GCPCloudProvider     = apis.GCPCloudProvider
+	AzureCloudProvider   = apis.AzureCloudProvider
+	TencentCloudProvider = apis.TencentCloudProvider
+	HuaweiCloudProvider  = apis.HuaweiCloudProvider
 )
 
+// queryClientServices are the services in the query paths of the providers
+var queryClientServices = map[string]string{
+	AWSCloudProvider:     "ec2",
+	AlibabaCloudProvider: "ecs",
+}
+
+// NewQueryClient creates a query client for the given cloud provider, the provider
+// should be one of the providers served by the price server, e.g. aws, alibabacloud or azure.
 func NewQueryClient(endpoint, cloudProvider, region string) (QueryClientInterface, error) {
-	var (
-		err          error
-		queryBaseUrl string
-	)
-	switch cloudProvider {
-	case AWSCloudProvider:
-		queryBaseUrl, err = url.JoinPath(endpoint, "/api/v1/aws/ec2")
-		if err != nil {
-			return nil, err
-		}
-	case AlibabaCloudProvider:
-		queryBaseUrl, err = url.JoinPath(endpoint, "/api/v1/alibabacloud/ecs")
-		if err != nil {
-			return nil, err
-		}
-	default:
-		return nil, fmt.Errorf("unsupported cloud provider: %s", cloudProvider)
+	if cloudProvider == "" {
+		return nil, fmt.Errorf("cloud provider is not set")
+	}
+	elem := []string{"/api/v1", cloudProvider}
+	// The aws and alibabacloud prices are queried under the service, since the old price servers only serve them there
+	if service, ok := queryClientServices[cloudProvider]; ok {
+		elem = append(elem, service)
+	}
+	queryBaseUrl, err := url.JoinPath(endpoint, elem...)
+	if err != nil {
+		return nil, err
 	}
 
 	ret := &QueryClientImpl{
-		region:       region,
-		queryBaseUrl: queryBaseUrl,
-		priceData:    map[string]*apis.RegionalInstancePrice{},
+		region:        region,
+		queryBaseUrl:  queryBaseUrl,
+		ec2Compatible: cloudProvider == AWSCloudProvider,
+		priceData:     map[string]*apis.RegionalInstancePrice{},
 	}
 	if err := ret.Sync(); err != nil {
 		return nil, err
@@ -126,7 +145,94 @@ func (q *QueryClientImpl) refreshSpecificInstanceTypeData(region, instanceType s
 	return &price
 }
 
+// Sync gets the prices changed since the last sync, all the price data is synced at the first time or if the
+// changes are not kept by the price server any more
 func (q *QueryClientImpl) Sync() error {
+	err := q.syncDelta()
+	if errors.Is(err, errDeltaNotSupported) {
+		klog.V(4).Infof("Price delta is not supported, sync all the price data")
+		return q.syncAll()
+	}
+	return err
+}
+
+func (q *QueryClientImpl) syncDelta() error {
+	q.awsMutex.Lock()
+	since := q.version
+	q.awsMutex.Unlock()
+
+	params := url.Values{}
+	params.Set("since", strconv.FormatUint(since, 10))
+	if q.region != "" {
+		params.Set("region", q.region)
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/price/delta?%s", q.queryBaseUrl, params.Encode()), nil)
+	if err != nil {
+		klog.Errorf("Failed to create request: %v", err)
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		klog.Errorf("Failed to get price delta: %v", err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errDeltaNotSupported
+	}
+	if resp.StatusCode != http.StatusOK {
+		klog.Errorf("Failed to get price delta: %s", resp.Status)
+		return fmt.Errorf("failed to get price delta: %s", resp.Status)
+	}
+
+	var delta apis.PriceDelta
+	if err := json.NewDecoder(resp.Body).Decode(&delta); err != nil {
+		klog.Errorf("Failed to unmarshal price delta: %v", err)
+		return err
+	}
+
+	q.awsMutex.Lock()
+	defer q.awsMutex.Unlock()
+	q.applyDelta(&delta)
+	return nil
+}
+
+// applyDelta applies the delta to the synced price data, the lock must be held
+func (q *QueryClientImpl) applyDelta(delta *apis.PriceDelta) {
+	if delta.Reset {
+		q.priceData = map[string]*apis.RegionalInstancePrice{}
+	}
+	for region, d := range delta.Regions {
+		regionData, ok := q.priceData[region]
+		if !ok || regionData.InstanceTypePrices == nil {
+			regionData = &apis.RegionalInstancePrice{InstanceTypePrices: map[string]*apis.InstanceTypePrice{}}
+			// TODO: this line is used to ensure the api compatibility, we should remove this line in the future
+			if q.ec2Compatible {
+				regionData.InstanceTypeEC2Price = regionData.InstanceTypePrices
+			}
+			q.priceData[region] = regionData
+		}
+		for instanceType, price := range d.InstanceTypePrices {
+			regionData.InstanceTypePrices[instanceType] = price
+		}
+	}
+	for region, instanceTypes := range delta.RemovedInstanceTypes {
+		if regionData, ok := q.priceData[region]; ok {
+			for _, instanceType := range instanceTypes {
+				delete(regionData.InstanceTypePrices, instanceType)
+			}
+		}
+	}
+	for _, region := range delta.RemovedRegions {
+		delete(q.priceData, region)
+	}
+	q.version = delta.Version
+}
+
+// syncAll gets all the price data from the price server which doesn't support the delta
+func (q *QueryClientImpl) syncAll() error {
 	url := fmt.Sprintf("%s/price", q.queryBaseUrl)
 	if q.region != "" {
 		url = fmt.Sprintf("%s/regions/%s/price", q.queryBaseUrl, q.region)
@@ -139,6 +245,11 @@ func (q *QueryClientImpl) Sync() error {
 	}
 	// Use gzip to compress the response
 	req.Header.Add("Accept", "Accept-Encoding: gzip")
+	q.awsMutex.Lock()
+	if q.etag != "" {
+		req.Header.Set("If-None-Match", q.etag)
+	}
+	q.awsMutex.Unlock()
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -147,9 +258,13 @@ func (q *QueryClientImpl) Sync() error {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified {
+		klog.V(4).Infof("Price data is not modified")
+		return nil
+	}
 	if resp.StatusCode != http.StatusOK {
 		klog.Errorf("Failed to get price data: %s", resp.Status)
-		return err
+		return fmt.Errorf("failed to get price data: %s", resp.Status)
 	}
 
 	data, err := io.ReadAll(resp.Body)
@@ -165,6 +280,7 @@ func (q *QueryClientImpl) Sync() error {
 		klog.Errorf("Failed to unmarshal price data: %v", err)
 		return err
 	}
+	q.etag = resp.Header.Get("ETag")
 
 	return nil
 }